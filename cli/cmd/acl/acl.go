@@ -0,0 +1,25 @@
+// Package acl implements the acl command.
+package acl
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/peter-evans/kdef/cli/cmd/acl/check"
+	"github.com/peter-evans/kdef/cli/config"
+)
+
+// Command creates the acl command.
+func Command(cOpts *config.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "acl",
+		Short: "Perform ACL operations",
+		Long:  "Perform ACL operations",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		check.Command(cOpts),
+	)
+
+	return cmd
+}
@@ -0,0 +1,124 @@
+// Package check implements the acl check command and executes the controller.
+package check
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/peter-evans/kdef/cli/config"
+	"github.com/peter-evans/kdef/cli/ctl/aclcheck"
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/model/opt"
+)
+
+// Command creates the acl check command.
+func Command(cOpts *config.Options) *cobra.Command {
+	opts := aclcheck.ControllerOptions{}
+	var defFormat string
+
+	cmd := &cobra.Command{
+		Use:   "check [options]",
+		Short: "Check if ACLs authorize a request",
+		Long: `Check if ACLs authorize a request (Kafka 2.0.0+).
+
+Simulates the decision of Kafka's standard authorizer for a principal performing an operation on a resource from a host.
+Checks the ACLs of the cluster by default. Supply the --definitions option to check the ACLs of acl and principalAcl definitions offline.
+
+Manual: https://peter-evans.github.io/kdef`,
+		Example: `# check if a principal can read a topic from a host using the ACLs of the cluster
+kdef acl check --principal User:foo --host 10.0.0.1 --operation READ --type topic --name store.events.order-created
+
+# check if a principal can read a group using the ACLs of definitions
+kdef acl check -u User:foo -H 10.0.0.1 -o READ -t group -n store.order-service --definitions "acls/**/*.yml"
+
+# exit with 1 if the request is denied
+kdef acl check -u User:foo -H 10.0.0.1 -o IDEMPOTENT_WRITE -t cluster --exit-code`,
+		SilenceUsage:          true,
+		SilenceErrors:         true,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if len(defFormat) > 0 {
+				opts.DefinitionFormat = opt.ParseDefinitionFormat(defFormat)
+				if opts.DefinitionFormat == opt.UnsupportedFormat {
+					return fmt.Errorf("\"format\" must be one of %q", strings.Join(opt.DefinitionFormatValidValues, "|"))
+				}
+			}
+			if opts.Request.ResourceType == "cluster" && len(opts.Request.ResourceName) == 0 {
+				opts.Request.ResourceName = "kafka-cluster"
+			}
+			if len(opts.ACLRolesFile) > 0 && len(opts.Definitions) == 0 {
+				return fmt.Errorf("\"acl-roles-file\" requires \"definitions\"")
+			}
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if opts.JSONOutput {
+				log.Quiet = true
+			}
+
+			// Checking the ACLs of definitions does not connect to the cluster.
+			var cl *client.Client
+			if len(opts.Definitions) == 0 {
+				var err error
+				if cl, err = config.NewClient(cOpts); err != nil {
+					return err
+				}
+			}
+
+			ctx := context.Background()
+			ctl := aclcheck.NewACLCheckController(cl, opts)
+			return ctl.Execute(ctx)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Request.Principal, "principal", "u", "", "principal performing the operation (e.g. User:foo)")
+	cmd.Flags().StringVarP(&opts.Request.Host, "host", "H", "", "host address the operation is performed from")
+	cmd.Flags().StringVarP(&opts.Request.Operation, "operation", "o", "", "operation performed on the resource (e.g. READ)")
+	cmd.Flags().StringVarP(&opts.Request.ResourceType, "type", "t", "", "type of the resource (e.g. topic)")
+	cmd.Flags().StringVarP(
+		&opts.Request.ResourceName,
+		"name",
+		"n",
+		"",
+		"name of the resource; defaults to \"kafka-cluster\" for type \"cluster\"",
+	)
+	cmd.Flags().BoolVar(
+		&opts.AllowIfNoACLs,
+		"allow-if-no-acls",
+		false,
+		"allow the request if no ACLs exist for the resource (allow.everyone.if.no.acl.found)",
+	)
+	cmd.Flags().StringArrayVarP(
+		&opts.Definitions,
+		"definitions",
+		"d",
+		nil,
+		"glob pattern matching the paths of acl and principalAcl definitions to check instead of the cluster; repeatable",
+	)
+	cmd.Flags().StringVarP(
+		&defFormat,
+		"format",
+		"f",
+		"",
+		fmt.Sprintf(
+			"resource definition format, overriding detection from the file extension or content [%s]",
+			strings.Join(opt.DefinitionFormatValidValues, "|"),
+		),
+	)
+	cmd.Flags().StringVarP(
+		&opts.ACLRolesFile,
+		"acl-roles-file",
+		"a",
+		"",
+		"path to a file of user-defined roles for acl definitions",
+	)
+	cmd.Flags().BoolVarP(&opts.ExitCode, "exit-code", "e", false, "exit with 1 if the request is denied and 0 otherwise")
+	cmd.Flags().BoolVarP(&opts.JSONOutput, "json-output", "j", false, "implies --quiet and outputs JSON results")
+
+	return cmd
+}
@@ -11,13 +11,16 @@ import (
 	"github.com/peter-evans/kdef/cli/config"
 	"github.com/peter-evans/kdef/cli/ctl/apply"
 	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/model/def"
 	"github.com/peter-evans/kdef/core/model/opt"
+	"github.com/peter-evans/kdef/core/util/str"
 )
 
 // Command creates the apply command.
 func Command(cOpts *config.Options) *cobra.Command {
 	opts := apply.ControllerOptions{}
 	var defFormat string
+	var acceptACLPruning bool
 
 	cmd := &cobra.Command{
 		Use:   "apply <definitions>... [options]",
@@ -26,11 +29,19 @@ func Command(cOpts *config.Options) *cobra.Command {
 
 Accepts one or more glob patterns matching the paths of definitions to apply.
 Directories matching patterns are ignored.
+The format of each definition file is determined by its extension, or its content if the extension is not recognised.
+References to fragment files with "$ref" are resolved relative to the definition file.
 
 The minimum Kafka version required to apply definitions:
 acl (Kafka 0.11.0+)
 broker (Kafka 0.11.0+)
+brokerLogger (Kafka 2.4.0+)
 brokers (Kafka 0.11.0+)
+clientMetrics (Kafka 3.7.0+)
+features (Kafka 2.7.0+)
+group (Kafka 4.0.0+)
+groups (Kafka 1.1.0+)
+principalAcl (Kafka 2.0.0+)
 topic (Kafka 2.4.0+)
 
 Manual: https://peter-evans.github.io/kdef`,
@@ -40,20 +51,55 @@ kdef apply "topics/*.yml" --dry-run
 # apply definitions in all directories under "resources" (dry-run)
 kdef apply "resources/**/*.yml" --dry-run
 
+# apply YAML and JSON definitions in directory "topics" (dry-run)
+kdef apply "topics/*.{yml,json}" --dry-run
+
 # apply a topic definition from stdin (dry-run)
-cat topics/my_topic.yml | kdef apply - --dry-run`,
+cat topics/my_topic.yml | kdef apply - --dry-run
+
+# apply a features definition that downgrades a feature
+kdef apply features.yml --accept-downgrades
+
+# apply acl definitions that use roles defined in a roles file (dry-run)
+kdef apply "acls/*.yml" --dry-run --acl-roles-file roles.yml
+
+# list ACLs of topics that are not defined by acl or principalAcl definitions (dry-run)
+kdef apply "acls/**/*.yml" --dry-run --prune-acls --prune-acls-type topic
+
+# delete ACLs of topics starting with "myapp" that are not defined by acl or principalAcl definitions
+kdef apply "acls/**/*.yml" --prune-acls --prune-acls-match "myapp.*" --accept-acl-pruning
+
+# write the partition moves of all topic definitions to a reassignment plan file (dry-run)
+kdef apply "topics/*.yml" --dry-run --reass-plan-file plan.json`,
 		SilenceUsage:          true,
 		SilenceErrors:         true,
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.MinimumNArgs(1),
 		PreRunE: func(_ *cobra.Command, _ []string) error {
-			opts.DefinitionFormat = opt.ParseDefinitionFormat(defFormat)
-			if opts.DefinitionFormat == opt.UnsupportedFormat {
-				return fmt.Errorf("\"format\" must be one of %q", strings.Join(opt.DefinitionFormatValidValues, "|"))
+			if len(defFormat) > 0 {
+				opts.DefinitionFormat = opt.ParseDefinitionFormat(defFormat)
+				if opts.DefinitionFormat == opt.UnsupportedFormat {
+					return fmt.Errorf("\"format\" must be one of %q", strings.Join(opt.DefinitionFormatValidValues, "|"))
+				}
 			}
 			if opts.ReassAwaitTimeout < 0 {
 				return fmt.Errorf("\"reass-await-timeout\" must be greater or equal to 0")
 			}
+			if opts.ReassMaxMoves < 0 {
+				return fmt.Errorf("\"reass-max-moves\" must be greater or equal to 0")
+			}
+			if opts.ReassMaxMoves > 0 && opts.ReassAwaitTimeout == 0 && !opts.DryRun && !opts.ExitCode {
+				return fmt.Errorf("\"reass-max-moves\" requires \"reass-await-timeout\" unless \"dry-run\" is set")
+			}
+			if !str.Contains(opts.PruneACLsType, opt.ACLResourceTypeValidValues) {
+				return fmt.Errorf("\"prune-acls-type\" must be one of %q", strings.Join(opt.ACLResourceTypeValidValues, "|"))
+			}
+			if opts.PruneACLs && !opts.DryRun && !opts.ExitCode && !acceptACLPruning {
+				return fmt.Errorf("\"prune-acls\" requires \"accept-acl-pruning\" unless \"dry-run\" is set")
+			}
+			if len(opts.ReassPlanFile) > 0 && !opts.DryRun && !opts.ExitCode {
+				return fmt.Errorf("\"reass-plan-file\" requires \"dry-run\" or \"exit-code\"")
+			}
 			return nil
 		},
 		RunE: func(_ *cobra.Command, args []string) error {
@@ -82,8 +128,11 @@ cat topics/my_topic.yml | kdef apply - --dry-run`,
 		&defFormat,
 		"format",
 		"f",
-		"yaml",
-		fmt.Sprintf("resource definition format [%s]", strings.Join(opt.DefinitionFormatValidValues, "|")),
+		"",
+		fmt.Sprintf(
+			"resource definition format, overriding detection from the file extension or content [%s]",
+			strings.Join(opt.DefinitionFormatValidValues, "|"),
+		),
 	)
 	cmd.Flags().BoolVarP(&opts.DryRun, "dry-run", "d", false, "validate and review the operation only")
 	cmd.Flags().BoolVarP(
@@ -108,6 +157,68 @@ cat topics/my_topic.yml | kdef apply - --dry-run`,
 		0,
 		"time in seconds to wait for topic partition reassignments to complete before timing out",
 	)
+	cmd.Flags().IntVarP(
+		&opts.ReassMaxMoves,
+		"reass-max-moves",
+		"m",
+		0,
+		"maximum number of concurrent partition moves per topic; reassignments are submitted in batches (0 is unlimited)",
+	)
+	cmd.Flags().StringVar(
+		&opts.ReassPlanFile,
+		"reass-plan-file",
+		"",
+		"requires --dry-run and writes planned partition moves to a kafka-reassign-partitions compatible JSON file",
+	)
+	cmd.Flags().StringVarP(
+		&opts.ACLRolesFile,
+		"acl-roles-file",
+		"a",
+		"",
+		"path to a file of user-defined roles for acl definitions",
+	)
+	cmd.Flags().StringSliceVar(
+		&opts.ACLPrincipalTypes,
+		"acl-principal-types",
+		def.DefaultACLPrincipalTypes,
+		"principal types allowed in acl and principalAcl definitions",
+	)
+	cmd.Flags().BoolVar(
+		&opts.AcceptDowngrades,
+		"accept-downgrades",
+		false,
+		"accept downgrades of finalized feature levels by features definitions",
+	)
+	cmd.Flags().BoolVar(
+		&opts.PruneACLs,
+		"prune-acls",
+		false,
+		"delete ACLs of resources and principals in scope that are not defined by acl or principalAcl definitions",
+	)
+	cmd.Flags().StringVar(
+		&opts.PruneACLsType,
+		"prune-acls-type",
+		"any",
+		fmt.Sprintf("acl resource type in scope of pruning [%s]", strings.Join(opt.ACLResourceTypeValidValues, "|")),
+	)
+	cmd.Flags().StringVar(
+		&opts.PruneACLsMatch,
+		"prune-acls-match",
+		".*",
+		"regular expression matching resource names in scope of pruning",
+	)
+	cmd.Flags().StringVar(
+		&opts.PruneACLsExclude,
+		"prune-acls-exclude",
+		".^",
+		"regular expression matching resource names to exclude from the scope of pruning",
+	)
+	cmd.Flags().BoolVar(
+		&acceptACLPruning,
+		"accept-acl-pruning",
+		false,
+		"confirm the deletion of ACLs by --prune-acls",
+	)
 	cmd.Flags().StringArrayVarP(
 		&opts.PropertyOverrides,
 		"prop-override",
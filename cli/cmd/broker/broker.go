@@ -0,0 +1,25 @@
+// Package broker implements the broker command.
+package broker
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/peter-evans/kdef/cli/cmd/broker/drain"
+	"github.com/peter-evans/kdef/cli/config"
+)
+
+// Command creates the broker command.
+func Command(cOpts *config.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "broker",
+		Short: "Perform operations on cluster brokers",
+		Long:  "Perform operations on cluster brokers",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		drain.Command(cOpts),
+	)
+
+	return cmd
+}
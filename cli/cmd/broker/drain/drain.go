@@ -0,0 +1,101 @@
+// Package drain implements the broker drain command and executes the controller.
+package drain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/peter-evans/kdef/cli/config"
+	"github.com/peter-evans/kdef/cli/ctl/drain"
+	"github.com/peter-evans/kdef/cli/log"
+	opreassignments "github.com/peter-evans/kdef/core/operators/reassignments"
+	"github.com/peter-evans/kdef/core/util/i32"
+)
+
+// Command creates the broker drain command.
+func Command(cOpts *config.Options) *cobra.Command {
+	opts := drain.ControllerOptions{}
+	var brokerID int32
+
+	cmd := &cobra.Command{
+		Use:   "drain <broker-id> [options]",
+		Short: "Move all partition replicas off a broker",
+		Long: `Move all partition replicas off a broker (Kafka 2.4.0+).
+
+Computes new assignments for every topic with replicas on the broker,
+preferring replacement brokers in the same rack as the drained broker.
+Partition reassignments are awaited, after which the broker is verified
+to hold no partition replicas and no partition leadership.
+
+Manual: https://peter-evans.github.io/kdef`,
+		Example: `# drain broker 3 (dry-run)
+kdef broker drain 3 --dry-run
+
+# drain broker 3 with at most 10 concurrent partition moves
+kdef broker drain 3 --reass-max-moves 10
+
+# drain broker 3 throttling replication to 50 MB/s
+kdef broker drain 3 --throttle 50000000`,
+		SilenceUsage:          true,
+		SilenceErrors:         true,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		PreRunE: func(_ *cobra.Command, args []string) error {
+			var err error
+			brokerID, err = i32.ParseStr(args[0])
+			if err != nil || brokerID < 0 {
+				return fmt.Errorf("\"broker-id\" must be a valid broker ID")
+			}
+			if opts.ReassMaxMoves < 0 {
+				return fmt.Errorf("\"reass-max-moves\" must be greater or equal to 0")
+			}
+			if opts.ReassAwaitTimeout < 1 {
+				return fmt.Errorf("\"reass-await-timeout\" must be greater than 0")
+			}
+			if opts.ThrottleRate < 0 {
+				return fmt.Errorf("\"throttle\" must be greater or equal to 0")
+			}
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if opts.DryRun {
+				log.InfoWithKeyf("dry-run", "Enabled")
+			}
+
+			cl, err := config.NewClient(cOpts)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			ctl := drain.NewDrainController(cl, brokerID, opts)
+			return ctl.Execute(ctx)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.DryRun, "dry-run", "d", false, "validate and review the operation only")
+	cmd.Flags().IntVarP(
+		&opts.ReassMaxMoves,
+		"reass-max-moves",
+		"m",
+		0,
+		"maximum number of concurrent partition moves; reassignments are submitted in batches (0 is unlimited)",
+	)
+	cmd.Flags().IntVar(
+		&opts.ReassAwaitTimeout,
+		"reass-await-timeout",
+		opreassignments.DefaultAwaitTimeout,
+		"time in seconds to wait for each batch of partition reassignments to complete before timing out",
+	)
+	cmd.Flags().Int64VarP(
+		&opts.ThrottleRate,
+		"throttle",
+		"t",
+		0,
+		"replication throttle rate in bytes/sec applied while partitions move (0 is unthrottled)",
+	)
+
+	return cmd
+}
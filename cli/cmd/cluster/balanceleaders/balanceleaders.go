@@ -0,0 +1,60 @@
+// Package balanceleaders implements the cluster balance-leaders command and executes the controller.
+package balanceleaders
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/peter-evans/kdef/cli/config"
+	"github.com/peter-evans/kdef/cli/ctl/balanceleaders"
+	"github.com/peter-evans/kdef/cli/log"
+)
+
+// Command creates the cluster balance-leaders command.
+func Command(cOpts *config.Options) *cobra.Command {
+	opts := balanceleaders.ControllerOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "balance-leaders [options]",
+		Short: "Balance preferred partition leaders across brokers",
+		Long: `Balance preferred partition leaders across brokers (Kafka 2.4.0+).
+
+Reorders the replicas of partitions across all topics so that preferred
+leadership is evenly distributed across brokers and racks, followed by a
+preferred leader election. Replicas are only reordered, so no data is moved.
+Partition leader counts per broker are displayed before and after balancing.
+
+Manual: https://peter-evans.github.io/kdef`,
+		Example: `# review the balancing of partition leaders of all topics (dry-run)
+kdef cluster balance-leaders --dry-run
+
+# balance partition leaders of topics starting with "myapp"
+kdef cluster balance-leaders --match "myapp.*"`,
+		SilenceUsage:          true,
+		SilenceErrors:         true,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if opts.DryRun {
+				log.InfoWithKeyf("dry-run", "Enabled")
+			}
+
+			cl, err := config.NewClient(cOpts)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			ctl := balanceleaders.NewBalanceLeadersController(cl, opts)
+			return ctl.Execute(ctx)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Match, "match", "m", ".*", "regular expression matching topic names to include")
+	cmd.Flags().StringVarP(&opts.Exclude, "exclude", "e", ".^", "regular expression matching topic names to exclude")
+	cmd.Flags().BoolVarP(&opts.IncludeInternal, "include-internal", "i", false, "include internal topics")
+	cmd.Flags().BoolVarP(&opts.DryRun, "dry-run", "d", false, "validate and review the operation only")
+
+	return cmd
+}
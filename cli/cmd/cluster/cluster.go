@@ -0,0 +1,29 @@
+// Package cluster implements the cluster command.
+package cluster
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/peter-evans/kdef/cli/cmd/cluster/balanceleaders"
+	"github.com/peter-evans/kdef/cli/cmd/cluster/rebalance"
+	"github.com/peter-evans/kdef/cli/cmd/cluster/uncleanelect"
+	"github.com/peter-evans/kdef/cli/config"
+)
+
+// Command creates the cluster command.
+func Command(cOpts *config.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Perform cluster-wide operations",
+		Long:  "Perform cluster-wide operations",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		balanceleaders.Command(cOpts),
+		rebalance.Command(cOpts),
+		uncleanelect.Command(cOpts),
+	)
+
+	return cmd
+}
@@ -0,0 +1,97 @@
+// Package rebalance implements the cluster rebalance command and executes the controller.
+package rebalance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/peter-evans/kdef/cli/config"
+	"github.com/peter-evans/kdef/cli/ctl/rebalance"
+	"github.com/peter-evans/kdef/cli/log"
+	opreassignments "github.com/peter-evans/kdef/core/operators/reassignments"
+)
+
+// Command creates the cluster rebalance command.
+func Command(cOpts *config.Options) *cobra.Command {
+	opts := rebalance.ControllerOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "rebalance [options]",
+		Short: "Rebalance partition replicas across brokers",
+		Long: `Rebalance partition replicas across brokers (Kafka 2.4.0+).
+
+Plans partition moves across all topics to even out replica and preferred
+leader counts per broker, such as after adding brokers to the cluster.
+The number of moves and the estimated data movement are displayed before
+partition reassignments are executed.
+
+Manual: https://peter-evans.github.io/kdef`,
+		Example: `# review the rebalance plan of all topics (dry-run)
+kdef cluster rebalance --dry-run
+
+# rebalance topics starting with "myapp"
+kdef cluster rebalance --match "myapp.*"
+
+# rebalance all topics with at most 10 concurrent partition moves
+kdef cluster rebalance --reass-max-moves 10`,
+		SilenceUsage:          true,
+		SilenceErrors:         true,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if opts.ReassMaxMoves < 0 {
+				return fmt.Errorf("\"reass-max-moves\" must be greater or equal to 0")
+			}
+			if opts.ReassAwaitTimeout < 1 {
+				return fmt.Errorf("\"reass-await-timeout\" must be greater than 0")
+			}
+			if opts.ThrottleRate < 0 {
+				return fmt.Errorf("\"throttle\" must be greater or equal to 0")
+			}
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if opts.DryRun {
+				log.InfoWithKeyf("dry-run", "Enabled")
+			}
+
+			cl, err := config.NewClient(cOpts)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			ctl := rebalance.NewRebalanceController(cl, opts)
+			return ctl.Execute(ctx)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Match, "match", "m", ".*", "regular expression matching topic names to include")
+	cmd.Flags().StringVarP(&opts.Exclude, "exclude", "e", ".^", "regular expression matching topic names to exclude")
+	cmd.Flags().BoolVarP(&opts.IncludeInternal, "include-internal", "i", false, "include internal topics")
+	cmd.Flags().BoolVarP(&opts.DryRun, "dry-run", "d", false, "validate and review the operation only")
+	// Unlike apply, "-m" is the shorthand of "--match", so "--reass-max-moves" has no shorthand.
+	cmd.Flags().IntVar(
+		&opts.ReassMaxMoves,
+		"reass-max-moves",
+		0,
+		"maximum number of concurrent partition moves; reassignments are submitted in batches (0 is unlimited)",
+	)
+	cmd.Flags().IntVar(
+		&opts.ReassAwaitTimeout,
+		"reass-await-timeout",
+		opreassignments.DefaultAwaitTimeout,
+		"time in seconds to wait for each batch of partition reassignments to complete before timing out",
+	)
+	cmd.Flags().Int64VarP(
+		&opts.ThrottleRate,
+		"throttle",
+		"t",
+		0,
+		"replication throttle rate in bytes/sec applied while partitions move (0 is unthrottled)",
+	)
+
+	return cmd
+}
@@ -0,0 +1,96 @@
+// Package uncleanelect implements the cluster unclean-elect command and executes the controller.
+package uncleanelect
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/peter-evans/kdef/cli/config"
+	"github.com/peter-evans/kdef/cli/ctl/uncleanelect"
+	"github.com/peter-evans/kdef/cli/log"
+)
+
+// Command creates the cluster unclean-elect command.
+func Command(cOpts *config.Options) *cobra.Command {
+	opts := uncleanelect.ControllerOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "unclean-elect [options]",
+		Short: "Elect leaders of offline partitions from out-of-sync replicas",
+		Long: `Elect leaders of offline partitions from out-of-sync replicas (Kafka 2.4.0+).
+
+Finds partitions without a leader and performs an unclean leader election,
+allowing a replica that is not in-sync to become the leader.
+Unclean leader election brings partitions back online at the cost of losing
+messages that were not replicated to the elected replica.
+
+The --accept-data-loss option must be supplied unless using --dry-run.
+
+Manual: https://peter-evans.github.io/kdef`,
+		Example: `# review the offline partitions of all topics (dry-run)
+kdef cluster unclean-elect --dry-run
+
+# elect unclean leaders for offline partitions of topic "mytopic"
+kdef cluster unclean-elect --match "mytopic" --accept-data-loss
+
+# elect unclean leaders for offline partitions 0 and 3 of topic "mytopic"
+kdef cluster unclean-elect --match "mytopic" --partitions 0,3 --accept-data-loss`,
+		SilenceUsage:          true,
+		SilenceErrors:         true,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			for _, partition := range opts.Partitions {
+				if partition < 0 {
+					return fmt.Errorf("\"partitions\" must be greater or equal to 0")
+				}
+			}
+			if !opts.DryRun && !opts.AcceptDataLoss {
+				return fmt.Errorf("\"accept-data-loss\" must be supplied to perform unclean leader election; review with \"dry-run\" first")
+			}
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if opts.JSONOutput {
+				log.Quiet = true
+			}
+			if opts.DryRun {
+				log.InfoWithKeyf("dry-run", "Enabled")
+			}
+
+			cl, err := config.NewClient(cOpts)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			ctl := uncleanelect.NewUncleanElectController(cl, opts)
+			return ctl.Execute(ctx)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Match, "match", "m", ".*", "regular expression matching topic names to include")
+	cmd.Flags().StringVarP(&opts.Exclude, "exclude", "e", ".^", "regular expression matching topic names to exclude")
+	cmd.Flags().BoolVarP(&opts.IncludeInternal, "include-internal", "i", false, "include internal topics")
+	cmd.Flags().Int32SliceVar(
+		&opts.Partitions,
+		"partitions",
+		nil,
+		"partition IDs of matching topics to include (default all offline partitions)",
+	)
+	// pflag does not recognise an empty int32 slice as a zero value and would print it as a second default.
+	cmd.Flags().Lookup("partitions").DefValue = ""
+	cmd.Flags().BoolVarP(&opts.DryRun, "dry-run", "d", false, "validate and review the operation only")
+	cmd.Flags().BoolVarP(&opts.JSONOutput, "json-output", "j", false, "implies --quiet and outputs JSON results")
+	// Deliberately has no shorthand to prevent accidental data loss.
+	cmd.Flags().BoolVar(
+		&opts.AcceptDataLoss,
+		"accept-data-loss",
+		false,
+		"confirm that messages not replicated to the elected replicas may be lost",
+	)
+
+	return cmd
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/peter-evans/kdef/cli/cmd/export/acl"
 	"github.com/peter-evans/kdef/cli/cmd/export/broker"
 	"github.com/peter-evans/kdef/cli/cmd/export/brokers"
+	"github.com/peter-evans/kdef/cli/cmd/export/clientmetrics"
+	"github.com/peter-evans/kdef/cli/cmd/export/features"
+	"github.com/peter-evans/kdef/cli/cmd/export/group"
 	"github.com/peter-evans/kdef/cli/cmd/export/topic"
 	"github.com/peter-evans/kdef/cli/config"
 )
@@ -24,6 +27,9 @@ func Command(cOpts *config.Options) *cobra.Command {
 		acl.Command(cOpts),
 		broker.Command(cOpts),
 		brokers.Command(cOpts),
+		clientmetrics.Command(cOpts),
+		features.Command(cOpts),
+		group.Command(cOpts),
 		topic.Command(cOpts),
 	)
 
@@ -4,6 +4,7 @@ package topic
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -35,7 +36,16 @@ kdef export topic --output-dir "topics"
 kdef export topic --quiet
 
 # export all topics starting with "myapp"
-kdef export topic --match "myapp.*"`,
+kdef export topic --match "myapp.*"
+
+# export all topics including broker assignments and replica log dirs
+kdef export topic --assignments broker --log-dirs
+
+# export all topics and write their current assignments to a rollback reassignment plan file
+kdef export topic --output-dir "topics" --reass-plan-file rollback.json
+
+# export all topics, writing configs common to all topics to a fragment referenced by each definition
+kdef export topic --output-dir "topics" --configs-fragment fragments/configs.yml`,
 		SilenceUsage:          true,
 		SilenceErrors:         true,
 		DisableFlagsInUseLine: true,
@@ -49,6 +59,16 @@ kdef export topic --match "myapp.*"`,
 			if opts.TopicAssignments == opt.UnsupportedAssignments {
 				return fmt.Errorf("\"assignments\" must be one of %q", strings.Join(opt.AssignmentsValidValues, "|"))
 			}
+			if opts.TopicLogDirs && opts.TopicAssignments != opt.BrokerAssignments {
+				return fmt.Errorf("\"log-dirs\" requires \"assignments\" to be \"broker\"")
+			}
+			if len(opts.ConfigsFragment) > 0 {
+				switch strings.ToLower(filepath.Ext(opts.ConfigsFragment)) {
+				case ".yml", ".yaml", ".json":
+				default:
+					return fmt.Errorf("\"configs-fragment\" must be a path to a YAML or JSON file")
+				}
+			}
 			return nil
 		},
 		RunE: func(_ *cobra.Command, _ []string) error {
@@ -88,6 +108,25 @@ kdef export topic --match "myapp.*"`,
 		"none",
 		fmt.Sprintf("partition assignments to include in topic definitions [%s]", strings.Join(opt.AssignmentsValidValues, "|")),
 	)
+	cmd.Flags().BoolVarP(
+		&opts.TopicLogDirs,
+		"log-dirs",
+		"l",
+		false,
+		"include the log dirs of partition replicas in topic definitions; requires --assignments broker",
+	)
+	cmd.Flags().StringVar(
+		&opts.TopicReassPlanFile,
+		"reass-plan-file",
+		"",
+		"write the current partition assignments of exported topics to a kafka-reassign-partitions compatible JSON file",
+	)
+	cmd.Flags().StringVar(
+		&opts.ConfigsFragment,
+		"configs-fragment",
+		"",
+		"write configs common to exported topics to a YAML or JSON fragment file referenced by each definition",
+	)
 
 	return cmd
 }
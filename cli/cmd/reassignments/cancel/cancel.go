@@ -0,0 +1,60 @@
+// Package cancel implements the reassignments cancel command and executes the controller.
+package cancel
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/peter-evans/kdef/cli/config"
+	"github.com/peter-evans/kdef/cli/ctl/reassignments"
+	"github.com/peter-evans/kdef/cli/log"
+)
+
+// Command creates the reassignments cancel command.
+func Command(cOpts *config.Options) *cobra.Command {
+	opts := reassignments.ControllerOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "cancel [options]",
+		Short: "Cancel in-progress partition reassignments",
+		Long: `Cancel in-progress partition reassignments (Kafka 2.4.0+).
+
+Cancelled partitions revert to the replicas they had before the reassignment started.
+
+Manual: https://peter-evans.github.io/kdef`,
+		Example: `# cancel in-progress partition reassignments of all topics (dry-run)
+kdef reassignments cancel --dry-run
+
+# cancel in-progress partition reassignments of topics starting with "myapp"
+kdef reassignments cancel --match "myapp.*"`,
+		SilenceUsage:          true,
+		SilenceErrors:         true,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if opts.JSONOutput {
+				log.Quiet = true
+			}
+			if opts.DryRun {
+				log.InfoWithKeyf("dry-run", "Enabled")
+			}
+
+			cl, err := config.NewClient(cOpts)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			ctl := reassignments.NewReassignmentsController(cl, opts, reassignments.OperationCancel)
+			return ctl.Execute(ctx)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Match, "match", "m", ".*", "regular expression matching topic names to include")
+	cmd.Flags().StringVarP(&opts.Exclude, "exclude", "e", ".^", "regular expression matching topic names to exclude")
+	cmd.Flags().BoolVarP(&opts.DryRun, "dry-run", "d", false, "validate and review the operation only")
+	cmd.Flags().BoolVarP(&opts.JSONOutput, "json-output", "j", false, "implies --quiet and outputs JSON results")
+
+	return cmd
+}
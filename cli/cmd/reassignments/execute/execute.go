@@ -0,0 +1,102 @@
+// Package execute implements the reassignments execute command and executes the controller.
+package execute
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/peter-evans/kdef/cli/config"
+	"github.com/peter-evans/kdef/cli/ctl/reassignments"
+	"github.com/peter-evans/kdef/cli/log"
+	opreassignments "github.com/peter-evans/kdef/core/operators/reassignments"
+)
+
+// Command creates the reassignments execute command.
+func Command(cOpts *config.Options) *cobra.Command {
+	opts := reassignments.ControllerOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "execute <plan-file> [options]",
+		Short: "Execute a partition reassignment plan",
+		Long: `Execute a partition reassignment plan (Kafka 2.4.0+).
+
+Accepts a reassignment plan file in the JSON format of the kafka-reassign-partitions tool.
+Plan files can be created by "kdef apply --dry-run --reass-plan-file" and
+"kdef export topic --reass-plan-file", or by kafka-reassign-partitions itself.
+
+Partitions already assigned the replicas in the plan are not moved.
+Partition reassignments are awaited, after which preferred leaders are elected
+for partitions with a changed preferred leader.
+
+Manual: https://peter-evans.github.io/kdef`,
+		Example: `# execute the reassignment plan "plan.json" (dry-run)
+kdef reassignments execute plan.json --dry-run
+
+# execute the reassignment plan "plan.json" with at most 10 concurrent partition moves
+kdef reassignments execute plan.json --reass-max-moves 10
+
+# execute the reassignment plan "plan.json" throttling replication to 50 MB/s
+kdef reassignments execute plan.json --throttle 50000000`,
+		SilenceUsage:          true,
+		SilenceErrors:         true,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		PreRunE: func(_ *cobra.Command, args []string) error {
+			opts.PlanFile = args[0]
+			if opts.ReassMaxMoves < 0 {
+				return fmt.Errorf("\"reass-max-moves\" must be greater or equal to 0")
+			}
+			if opts.ReassAwaitTimeout < 1 {
+				return fmt.Errorf("\"reass-await-timeout\" must be greater than 0")
+			}
+			if opts.ThrottleRate < 0 {
+				return fmt.Errorf("\"throttle\" must be greater or equal to 0")
+			}
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if opts.JSONOutput {
+				log.Quiet = true
+			}
+			if opts.DryRun {
+				log.InfoWithKeyf("dry-run", "Enabled")
+			}
+
+			cl, err := config.NewClient(cOpts)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			ctl := reassignments.NewReassignmentsController(cl, opts, reassignments.OperationExecute)
+			return ctl.Execute(ctx)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.DryRun, "dry-run", "d", false, "validate and review the operation only")
+	cmd.Flags().BoolVarP(&opts.JSONOutput, "json-output", "j", false, "implies --quiet and outputs JSON results")
+	cmd.Flags().IntVarP(
+		&opts.ReassMaxMoves,
+		"reass-max-moves",
+		"m",
+		0,
+		"maximum number of concurrent partition moves; reassignments are submitted in batches (0 is unlimited)",
+	)
+	cmd.Flags().IntVar(
+		&opts.ReassAwaitTimeout,
+		"reass-await-timeout",
+		opreassignments.DefaultAwaitTimeout,
+		"time in seconds to wait for each batch of partition reassignments to complete before timing out",
+	)
+	cmd.Flags().Int64VarP(
+		&opts.ThrottleRate,
+		"throttle",
+		"t",
+		0,
+		"replication throttle rate in bytes/sec applied while partitions move (0 is unthrottled)",
+	)
+
+	return cmd
+}
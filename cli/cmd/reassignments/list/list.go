@@ -0,0 +1,54 @@
+// Package list implements the reassignments list command and executes the controller.
+package list
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/peter-evans/kdef/cli/config"
+	"github.com/peter-evans/kdef/cli/ctl/reassignments"
+	"github.com/peter-evans/kdef/cli/log"
+)
+
+// Command creates the reassignments list command.
+func Command(cOpts *config.Options) *cobra.Command {
+	opts := reassignments.ControllerOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list [options]",
+		Short: "List in-progress partition reassignments",
+		Long: `List in-progress partition reassignments (Kafka 2.4.0+).
+
+Manual: https://peter-evans.github.io/kdef`,
+		Example: `# list in-progress partition reassignments of all topics
+kdef reassignments list
+
+# list in-progress partition reassignments of topics starting with "myapp"
+kdef reassignments list --match "myapp.*"`,
+		SilenceUsage:          true,
+		SilenceErrors:         true,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if opts.JSONOutput {
+				log.Quiet = true
+			}
+
+			cl, err := config.NewClient(cOpts)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			ctl := reassignments.NewReassignmentsController(cl, opts, reassignments.OperationList)
+			return ctl.Execute(ctx)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Match, "match", "m", ".*", "regular expression matching topic names to include")
+	cmd.Flags().StringVarP(&opts.Exclude, "exclude", "e", ".^", "regular expression matching topic names to exclude")
+	cmd.Flags().BoolVarP(&opts.JSONOutput, "json-output", "j", false, "implies --quiet and outputs JSON results")
+
+	return cmd
+}
@@ -0,0 +1,29 @@
+// Package reassignments implements the reassignments command.
+package reassignments
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/peter-evans/kdef/cli/cmd/reassignments/cancel"
+	"github.com/peter-evans/kdef/cli/cmd/reassignments/execute"
+	"github.com/peter-evans/kdef/cli/cmd/reassignments/list"
+	"github.com/peter-evans/kdef/cli/config"
+)
+
+// Command creates the reassignments command.
+func Command(cOpts *config.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reassignments",
+		Short: "Manage partition reassignments",
+		Long:  "Manage partition reassignments",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		cancel.Command(cOpts),
+		execute.Command(cOpts),
+		list.Command(cOpts),
+	)
+
+	return cmd
+}
@@ -7,9 +7,14 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"github.com/peter-evans/kdef/cli/cmd/acl"
 	"github.com/peter-evans/kdef/cli/cmd/apply"
+	"github.com/peter-evans/kdef/cli/cmd/broker"
+	"github.com/peter-evans/kdef/cli/cmd/cluster"
 	"github.com/peter-evans/kdef/cli/cmd/configure"
 	"github.com/peter-evans/kdef/cli/cmd/export"
+	"github.com/peter-evans/kdef/cli/cmd/reassignments"
+	"github.com/peter-evans/kdef/cli/cmd/schema"
 	"github.com/peter-evans/kdef/cli/config"
 	"github.com/peter-evans/kdef/cli/log"
 )
@@ -63,6 +68,11 @@ Manual: https://peter-evans.github.io/kdef`,
 		configure.Command(),
 		apply.Command(cOpts),
 		export.Command(cOpts),
+		acl.Command(cOpts),
+		broker.Command(cOpts),
+		cluster.Command(cOpts),
+		reassignments.Command(cOpts),
+		schema.Command(),
 	)
 
 	cmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output")
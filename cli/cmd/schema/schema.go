@@ -0,0 +1,56 @@
+// Package schema implements the schema command and executes the controller.
+package schema
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/peter-evans/kdef/cli/ctl/schema"
+)
+
+// Command creates the schema command.
+func Command() *cobra.Command {
+	opts := schema.ControllerOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "schema [options]",
+		Short: "Generate JSON Schema for resource definitions",
+		Long: `Generate JSON Schema for resource definitions.
+
+Generates a schema matching a definition of any kind by default. Supply the --kind
+option to generate the schema of a single definition kind.
+
+Outputs to stdout by default. Supply the --output-dir option to create a schema file
+for each definition kind and apiVersion, and a schema matching any definition.
+
+Manual: https://peter-evans.github.io/kdef`,
+		Example: `# generate a schema matching any definition to stdout
+kdef schema
+
+# generate the schema of topic definitions to stdout
+kdef schema --kind topic
+
+# generate schema files in the directory "schema"
+kdef schema --output-dir "schema"`,
+		SilenceUsage:          true,
+		SilenceErrors:         true,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctl := schema.NewSchemaController(opts)
+			return ctl.Execute()
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Kind, "kind", "k", "", "definition kind to generate the schema of")
+	cmd.Flags().StringVarP(&opts.APIVersion, "api-version", "a", "v1", "definition apiVersion of the supplied kind")
+	cmd.Flags().StringVarP(
+		&opts.OutputDir,
+		"output-dir",
+		"o",
+		"",
+		"output directory path for schema files; non-existent directories will be created",
+	)
+	cmd.Flags().BoolVarP(&opts.Overwrite, "overwrite", "w", false, "overwrite existing files in output directory")
+
+	return cmd
+}
@@ -0,0 +1,191 @@
+// Package aclcheck implements the acl check controller.
+package aclcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/ghodss/yaml"
+	"github.com/peter-evans/kdef/cli/ctl/apply/docparse"
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/helpers/acls"
+	"github.com/peter-evans/kdef/core/helpers/authorizer"
+	"github.com/peter-evans/kdef/core/helpers/fragments"
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/peter-evans/kdef/core/model/opt"
+	"github.com/peter-evans/kdef/core/operators/aclcheck"
+)
+
+// ControllerOptions represents options to configure an acl check controller.
+type ControllerOptions struct {
+	// Checker options.
+	Request       meta.ACLCheckRequest
+	AllowIfNoACLs bool
+
+	// ACL check controller specific options.
+	Definitions      []string
+	DefinitionFormat opt.DefinitionFormat
+	ACLRolesFile     string
+	ExitCode         bool
+	JSONOutput       bool
+}
+
+// NewACLCheckController creates a new acl check controller.
+func NewACLCheckController(
+	cl *client.Client,
+	opts ControllerOptions,
+) *aclCheckController { //revive:disable-line:unexported-return
+	return &aclCheckController{
+		cl:   cl,
+		opts: opts,
+	}
+}
+
+type aclCheckController struct {
+	cl   *client.Client
+	opts ControllerOptions
+}
+
+// Execute implements the execution of the acl check controller.
+func (a *aclCheckController) Execute(ctx context.Context) error {
+	var bindings meta.ACLBindings
+	if len(a.opts.Definitions) > 0 {
+		var err error
+		if bindings, err = a.readBindings(); err != nil {
+			return err
+		}
+	}
+
+	checker := aclcheck.NewChecker(a.cl, aclcheck.CheckerOptions{
+		Request:       a.opts.Request,
+		AllowIfNoACLs: a.opts.AllowIfNoACLs,
+		Bindings:      bindings,
+	})
+	result := checker.Execute(ctx)
+
+	if a.opts.JSONOutput {
+		out, err := result.JSON()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", out)
+	} else if result.GetErr() == nil {
+		// Ignores --quiet.
+		authorizer.Display(result.Request, result.Decision)
+	}
+
+	if result.GetErr() != nil {
+		return fmt.Errorf("check completed with errors")
+	}
+
+	if a.opts.ExitCode && !result.Decision.Allowed {
+		return fmt.Errorf("request denied")
+	}
+
+	return nil
+}
+
+// readBindings reads the ACLs of acl and principalAcl definitions matching the definitions patterns.
+func (a *aclCheckController) readBindings() (meta.ACLBindings, error) {
+	roles, err := def.NewACLRoles(nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(a.opts.ACLRolesFile) > 0 {
+		log.Infof("Reading acl roles file %q", a.opts.ACLRolesFile)
+		if roles, err = acls.ReadRolesFile(a.opts.ACLRolesFile); err != nil {
+			return nil, err
+		}
+	}
+
+	bindings := meta.ACLBindings{}
+	for _, arg := range a.opts.Definitions {
+		basepath, pattern := doublestar.SplitPattern(arg)
+		fsys := os.DirFS(basepath)
+
+		err := doublestar.GlobWalk(fsys, pattern, func(p string, d fs.DirEntry) error {
+			if d.IsDir() {
+				return nil
+			}
+
+			path := filepath.Join(basepath, p)
+			log.Debugf("Reading definition(s) from file %q", path)
+			defDocs, format, err := docparse.FromFile(path, docparse.Format(a.opts.DefinitionFormat))
+			if err != nil {
+				return fmt.Errorf("failed to read definition(s): %v", err)
+			}
+
+			for _, defDoc := range defDocs {
+				content, docFormat, err := fragments.ResolveRefs(defDoc.Content, opt.DefinitionFormat(format), filepath.Dir(path))
+				if err != nil {
+					return fmt.Errorf("invalid resource definition: %v", defDoc.LocateErr(err))
+				}
+				defBindings, err := docBindings(content, docFormat, roles)
+				if err != nil {
+					return fmt.Errorf("invalid resource definition: %v", defDoc.LocateErr(err))
+				}
+				bindings = append(bindings, defBindings...)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	log.Infof("Read %d ACL(s) from definitions", len(bindings))
+
+	return bindings, nil
+}
+
+// docBindings returns the ACLs of a definition document, ignoring kinds other than acl and principalAcl.
+func docBindings(defDoc string, format opt.DefinitionFormat, roles def.ACLRoles) (meta.ACLBindings, error) {
+	var resourceDef def.ResourceDefinition
+	switch format {
+	case opt.YAMLFormat:
+		if err := yaml.Unmarshal([]byte(defDoc), &resourceDef); err != nil {
+			return nil, err
+		}
+	case opt.JSONFormat:
+		if err := json.Unmarshal([]byte(defDoc), &resourceDef); err != nil {
+			return nil, err
+		}
+	case opt.HCLFormat:
+		if err := def.UnmarshalHCL(defDoc, &resourceDef); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format")
+	}
+
+	switch resourceDef.Kind {
+	case def.KindACL:
+		aclDef, err := def.LoadACLDefinition(defDoc, format)
+		if err != nil {
+			return nil, err
+		}
+		if err := aclDef.Validate(); err != nil {
+			return nil, err
+		}
+		return aclDef.ACLBindings(roles)
+	case def.KindPrincipalACL:
+		principalACLDef, err := def.LoadPrincipalACLDefinition(defDoc, format)
+		if err != nil {
+			return nil, err
+		}
+		if err := principalACLDef.Validate(); err != nil {
+			return nil, err
+		}
+		return principalACLDef.ACLBindings(), nil
+	default:
+		return nil, nil
+	}
+}
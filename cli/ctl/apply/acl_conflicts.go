@@ -0,0 +1,111 @@
+package apply
+
+import (
+	"fmt"
+
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/opt"
+)
+
+// deleteUndefinedACLs tracks the ACLs managed by acl and principal acl definitions with deleteUndefinedAcls enabled.
+// An acl definition and a principal acl definition that both delete undefined ACLs of the same principal on the
+// same resource undo each other's changes on every apply.
+type deleteUndefinedACLs struct {
+	// Principals of acl definitions by resource.
+	aclPrincipals map[def.ACLResource]map[string]bool
+	// Resources granted by principal acl definitions by principal.
+	principalResources map[string]map[def.ACLResource]bool
+}
+
+func newDeleteUndefinedACLs() *deleteUndefinedACLs {
+	return &deleteUndefinedACLs{
+		aclPrincipals:      make(map[def.ACLResource]map[string]bool),
+		principalResources: make(map[string]map[def.ACLResource]bool),
+	}
+}
+
+// add records an acl or principal acl definition with deleteUndefinedAcls enabled.
+// An error is returned if the definition conflicts with a definition added previously.
+// Definitions that cannot be loaded are ignored and left to their applier to report.
+func (d *deleteUndefinedACLs) add(kind string, defDoc string, format opt.DefinitionFormat, roles def.ACLRoles) error {
+	switch kind {
+	case def.KindACL:
+		aclDef, err := def.LoadACLDefinition(defDoc, format)
+		if err != nil || !aclDef.Spec.DeleteUndefinedACLs {
+			return nil
+		}
+		if roles == nil {
+			if roles, err = def.NewACLRoles(nil); err != nil {
+				return nil
+			}
+		}
+		bindings, err := aclDef.ACLBindings(roles)
+		if err != nil {
+			return nil
+		}
+		var principals []string
+		for _, binding := range bindings {
+			principals = append(principals, binding.Principal)
+		}
+		return d.addACL(aclDef.Metadata.Type, aclDef.Metadata.Name, aclDef.Metadata.ResourcePatternType, principals)
+	case def.KindPrincipalACL:
+		principalACLDef, err := def.LoadPrincipalACLDefinition(defDoc, format)
+		if err != nil || !principalACLDef.Spec.DeleteUndefinedACLs {
+			return nil
+		}
+		var resources []def.ACLResource
+		for _, grant := range principalACLDef.Spec.Grants {
+			resources = append(resources, grant.Resource())
+		}
+		return d.addPrincipalACL(principalACLDef.Metadata.Name, resources)
+	}
+
+	return nil
+}
+
+func (d *deleteUndefinedACLs) addACL(resourceType, resourceName, patternType string, principals []string) error {
+	resource := def.ACLResource{Type: resourceType, Name: resourceName, PatternType: patternType}
+	defined := make(map[string]bool)
+	for _, principal := range principals {
+		defined[principal] = true
+	}
+
+	for principal, resources := range d.principalResources {
+		if defined[principal] || resources[resource] {
+			return fmt.Errorf(
+				"field \"spec.deleteUndefinedAcls\" conflicts with the principalAcl definition of principal %q "+
+					"that also deletes undefined ACLs of the principal on %s %q; "+
+					"enable \"deleteUndefinedAcls\" in only one of the definitions",
+				principal,
+				resourceType,
+				resourceName,
+			)
+		}
+	}
+
+	d.aclPrincipals[resource] = defined
+	return nil
+}
+
+func (d *deleteUndefinedACLs) addPrincipalACL(principal string, resources []def.ACLResource) error {
+	granted := make(map[def.ACLResource]bool)
+	for _, resource := range resources {
+		granted[resource] = true
+	}
+
+	for resource, principals := range d.aclPrincipals {
+		if principals[principal] || granted[resource] {
+			return fmt.Errorf(
+				"field \"spec.deleteUndefinedAcls\" conflicts with the acl definition of %s %q "+
+					"that also deletes undefined ACLs of principal %q on the resource; "+
+					"enable \"deleteUndefinedAcls\" in only one of the definitions",
+				resource.Type,
+				resource.Name,
+				principal,
+			)
+		}
+	}
+
+	d.principalResources[principal] = granted
+	return nil
+}
@@ -0,0 +1,117 @@
+// Package apply implements the apply controller.
+package apply
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/peter-evans/kdef/cli/test/tutil"
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/opt"
+)
+
+func Test_deleteUndefinedACLs_add(t *testing.T) {
+	aclDoc := func(deleteUndefined bool, principal string) string {
+		return `apiVersion: v1
+kind: acl
+metadata:
+  name: store.events
+  type: topic
+spec:
+  acls:
+    - principals: ["` + principal + `"]
+      hosts: ["*"]
+      operations: ["READ"]
+      permissionType: ALLOW
+  deleteUndefinedAcls: ` + strconv.FormatBool(deleteUndefined)
+	}
+	principalACLDoc := func(deleteUndefined bool, principal string, topic string) string {
+		return `apiVersion: v1
+kind: principalAcl
+metadata:
+  name: ` + principal + `
+spec:
+  grants:
+    - resourceType: topic
+      resourceName: ` + topic + `
+      hosts: ["*"]
+      operations: ["WRITE"]
+      permissionType: ALLOW
+  deleteUndefinedAcls: ` + strconv.FormatBool(deleteUndefined)
+	}
+
+	type definition struct {
+		kind string
+		doc  string
+	}
+	tests := []struct {
+		name    string
+		defs    []definition
+		wantErr string
+	}{
+		{
+			name: "Tests definitions without overlapping principals and resources",
+			defs: []definition{
+				{kind: def.KindACL, doc: aclDoc(true, "User:foo")},
+				{kind: def.KindPrincipalACL, doc: principalACLDoc(true, "User:bar", "store.orders")},
+			},
+		},
+		{
+			name: "Tests overlapping definitions without deleteUndefinedAcls",
+			defs: []definition{
+				{kind: def.KindACL, doc: aclDoc(true, "User:foo")},
+				{kind: def.KindPrincipalACL, doc: principalACLDoc(false, "User:foo", "store.events")},
+				{kind: def.KindPrincipalACL, doc: principalACLDoc(true, "User:bar", "store.orders")},
+				{kind: def.KindACL, doc: aclDoc(false, "User:bar")},
+			},
+		},
+		{
+			name: "Tests a principal acl definition granting on the resource of an acl definition",
+			defs: []definition{
+				{kind: def.KindACL, doc: aclDoc(true, "User:foo")},
+				{kind: def.KindPrincipalACL, doc: principalACLDoc(true, "User:bar", "store.events")},
+			},
+			wantErr: "field \"spec.deleteUndefinedAcls\" conflicts with the acl definition of topic \"store.events\" " +
+				"that also deletes undefined ACLs of principal \"User:bar\" on the resource",
+		},
+		{
+			name: "Tests a principal acl definition of a principal of an acl definition",
+			defs: []definition{
+				{kind: def.KindACL, doc: aclDoc(true, "User:foo")},
+				{kind: def.KindPrincipalACL, doc: principalACLDoc(true, "User:foo", "store.orders")},
+			},
+			wantErr: "field \"spec.deleteUndefinedAcls\" conflicts with the acl definition of topic \"store.events\"",
+		},
+		{
+			name: "Tests an acl definition of a principal of a principal acl definition",
+			defs: []definition{
+				{kind: def.KindPrincipalACL, doc: principalACLDoc(true, "User:foo", "store.orders")},
+				{kind: def.KindACL, doc: aclDoc(true, "User:foo")},
+			},
+			wantErr: "field \"spec.deleteUndefinedAcls\" conflicts with the principalAcl definition of principal \"User:foo\" " +
+				"that also deletes undefined ACLs of the principal on topic \"store.events\"",
+		},
+		{
+			name: "Tests an acl definition on a resource granted by a principal acl definition",
+			defs: []definition{
+				{kind: def.KindPrincipalACL, doc: principalACLDoc(true, "User:bar", "store.events")},
+				{kind: def.KindACL, doc: aclDoc(true, "User:foo")},
+			},
+			wantErr: "field \"spec.deleteUndefinedAcls\" conflicts with the principalAcl definition of principal \"User:bar\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDeleteUndefinedACLs()
+			var err error
+			for _, defn := range tt.defs {
+				if err = d.add(defn.kind, defn.doc, opt.YAMLFormat, nil); err != nil {
+					break
+				}
+			}
+			if !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("deleteUndefinedACLs.add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -14,12 +14,22 @@ import (
 	"github.com/peter-evans/kdef/cli/ctl/apply/docparse"
 	"github.com/peter-evans/kdef/cli/log"
 	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/helpers/acls"
+	"github.com/peter-evans/kdef/core/helpers/fragments"
+	"github.com/peter-evans/kdef/core/helpers/reassignments"
 	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/meta"
 	"github.com/peter-evans/kdef/core/model/opt"
 	"github.com/peter-evans/kdef/core/model/res"
 	"github.com/peter-evans/kdef/core/operators/acl"
 	"github.com/peter-evans/kdef/core/operators/broker"
+	"github.com/peter-evans/kdef/core/operators/brokerlogger"
 	"github.com/peter-evans/kdef/core/operators/brokers"
+	"github.com/peter-evans/kdef/core/operators/clientmetrics"
+	"github.com/peter-evans/kdef/core/operators/features"
+	"github.com/peter-evans/kdef/core/operators/group"
+	"github.com/peter-evans/kdef/core/operators/groups"
+	"github.com/peter-evans/kdef/core/operators/principalacl"
 	"github.com/peter-evans/kdef/core/operators/topic"
 )
 
@@ -32,15 +42,27 @@ type applier interface {
 // ControllerOptions represents options to configure an apply controller.
 type ControllerOptions struct {
 	// Applier options.
+	// The definition format is detected per file if not set.
 	DefinitionFormat  opt.DefinitionFormat
 	PropertyOverrides []string
 	DryRun            bool
 	ReassAwaitTimeout int
+	ReassMaxMoves     int
+	AcceptDowngrades  bool
+	ACLPrincipalTypes []string
+
+	// Pruner options.
+	PruneACLs        bool
+	PruneACLsType    string
+	PruneACLsMatch   string
+	PruneACLsExclude string
 
 	// Apply controller specific options.
 	ContinueOnError bool
 	ExitCode        bool
 	JSONOutput      bool
+	ReassPlanFile   string
+	ACLRolesFile    string
 }
 
 // NewApplyController creates a new apply controller.
@@ -50,16 +72,19 @@ func NewApplyController(
 	opts ControllerOptions,
 ) *applyController { //revive:disable-line:unexported-return
 	return &applyController{
-		cl:   cl,
-		args: args,
-		opts: opts,
+		cl:                  cl,
+		args:                args,
+		opts:                opts,
+		deleteUndefinedACLs: newDeleteUndefinedACLs(),
 	}
 }
 
 type applyController struct {
-	cl   *client.Client
-	args []string
-	opts ControllerOptions
+	cl                  *client.Client
+	args                []string
+	opts                ControllerOptions
+	aclRoles            def.ACLRoles
+	deleteUndefinedACLs *deleteUndefinedACLs
 }
 
 // Execute implements the execution of the apply controller.
@@ -67,6 +92,14 @@ func (a *applyController) Execute(ctx context.Context) error {
 	results := res.ApplyResults{}
 	var ctlErrors bool
 
+	if len(a.opts.ACLRolesFile) > 0 {
+		log.Infof("Reading acl roles file %q", a.opts.ACLRolesFile)
+		var err error
+		if a.aclRoles, err = acls.ReadRolesFile(a.opts.ACLRolesFile); err != nil {
+			return err
+		}
+	}
+
 	if a.args[0] == "-" {
 		// Apply definitions from stdin.
 		res, err := a.applyDefsFromStdin(ctx)
@@ -110,6 +143,14 @@ func (a *applyController) Execute(ctx context.Context) error {
 		}
 	}
 
+	if a.opts.PruneACLs {
+		if ctlErrors || results.ContainsErr() {
+			log.Warnf("Skipping ACL pruning because definitions were not applied successfully")
+		} else {
+			results = append(results, a.pruneACLs(ctx, results))
+		}
+	}
+
 	if a.opts.JSONOutput {
 		out, err := results.JSON()
 		if err != nil {
@@ -118,6 +159,13 @@ func (a *applyController) Execute(ctx context.Context) error {
 		fmt.Printf("%s\n", out)
 	}
 
+	if len(a.opts.ReassPlanFile) > 0 {
+		if err := a.writeReassPlanFile(results); err != nil {
+			log.Error(err)
+			ctlErrors = true
+		}
+	}
+
 	if len(results) == 0 {
 		log.Error(fmt.Errorf("no valid resource definitions found"))
 		ctlErrors = true
@@ -134,30 +182,90 @@ func (a *applyController) Execute(ctx context.Context) error {
 	return nil
 }
 
+// pruneACLs prunes ACLs in scope that are not defined by the acl and principal acl definitions of apply results.
+func (a *applyController) pruneACLs(ctx context.Context, results res.ApplyResults) *res.ApplyResult {
+	var resources []def.ACLResource
+	var principalBindings meta.ACLBindings
+	for _, result := range results {
+		switch localDef := result.LocalDef.(type) {
+		case *def.ACLDefinition:
+			resources = append(resources, def.ACLResource{
+				Type:        localDef.Metadata.Type,
+				Name:        localDef.Metadata.Name,
+				PatternType: localDef.Metadata.ResourcePatternType,
+			})
+		case *def.PrincipalACLDefinition:
+			principalBindings = append(principalBindings, localDef.ACLBindings()...)
+		}
+	}
+
+	pruner := acl.NewPruner(a.cl, acl.PrunerOptions{
+		Match:                    a.opts.PruneACLsMatch,
+		Exclude:                  a.opts.PruneACLsExclude,
+		ResourceType:             a.opts.PruneACLsType,
+		DryRun:                   a.opts.DryRun,
+		DefinedResources:         resources,
+		DefinedPrincipalBindings: principalBindings,
+	})
+
+	return pruner.Execute(ctx)
+}
+
+// writeReassPlanFile writes the partition moves of topic apply results to a reassignment plan file.
+func (a *applyController) writeReassPlanFile(results res.ApplyResults) error {
+	var moves meta.TopicPartitionAssignments
+	for _, result := range results {
+		if data, ok := result.Data.(res.TopicApplyResultData); ok {
+			moves = append(moves, data.PartitionMoves...)
+		}
+	}
+
+	log.Infof("Writing reassignment plan file %q with %d partition move(s)", a.opts.ReassPlanFile, len(moves))
+	if err := reassignments.WritePlanFile(a.opts.ReassPlanFile, meta.NewReassignmentPlan(moves)); err != nil {
+		return fmt.Errorf("failed to write reassignment plan file: %v", err)
+	}
+
+	return nil
+}
+
 func (a *applyController) applyDefsFromStdin(ctx context.Context) (res.ApplyResults, error) {
 	log.Infof("Reading definition(s) from stdin")
-	defDocs, err := docparse.FromStdin(docparse.Format(a.opts.DefinitionFormat))
+	defDocs, format, err := docparse.FromStdin(docparse.Format(a.opts.DefinitionFormat))
 	if err != nil {
 		return nil, fmt.Errorf("failed to read definition(s): %v", err)
 	}
-	return a.applyDefinitions(ctx, defDocs)
+	return a.applyDefinitions(ctx, defDocs, opt.DefinitionFormat(format))
 }
 
 func (a *applyController) applyDefsFromFile(ctx context.Context, filepath string) (res.ApplyResults, error) {
 	log.Infof("Reading definition(s) from file %q", filepath)
-	defDocs, err := docparse.FromFile(filepath, docparse.Format(a.opts.DefinitionFormat))
+	defDocs, format, err := docparse.FromFile(filepath, docparse.Format(a.opts.DefinitionFormat))
 	if err != nil {
 		return nil, fmt.Errorf("failed to read definition(s): %v", err)
 	}
-	return a.applyDefinitions(ctx, defDocs)
+	return a.applyDefinitions(ctx, defDocs, opt.DefinitionFormat(format))
 }
 
-func (a *applyController) applyDefinitions(ctx context.Context, defDocs []string) (res.ApplyResults, error) {
-	resourceDefs, err := getResourceDefinitions(defDocs, a.opts.DefinitionFormat)
+func (a *applyController) applyDefinitions(
+	ctx context.Context,
+	docs docparse.Docs,
+	format opt.DefinitionFormat,
+) (res.ApplyResults, error) {
+	resourceDefs, err := getResourceDefinitions(docs, format)
 	if err != nil {
 		return nil, fmt.Errorf("invalid resource definition: %v", err)
 	}
 
+	// Resolve references to fragment files before definitions are loaded and validated.
+	defDocs := make([]string, len(docs))
+	formats := make([]opt.DefinitionFormat, len(docs))
+	for i, doc := range docs {
+		defDocs[i], formats[i], err = fragments.ResolveRefs(doc.Content, format, filepath.Dir(doc.Path))
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource definition: %v", doc.LocateErr(err))
+		}
+	}
+
 	var results res.ApplyResults
 	for i, resourceDef := range resourceDefs {
 		var applier applier
@@ -165,34 +273,90 @@ func (a *applyController) applyDefinitions(ctx context.Context, defDocs []string
 		switch resourceDef.Kind {
 		case def.KindACL:
 			applier = acl.NewApplier(a.cl, defDocs[i], acl.ApplierOptions{
-				DefinitionFormat:  a.opts.DefinitionFormat,
+				DefinitionFormat:  formats[i],
 				PropertyOverrides: a.opts.PropertyOverrides,
 				DryRun:            a.opts.DryRun,
+				Roles:             a.aclRoles,
+				PrincipalTypes:    a.opts.ACLPrincipalTypes,
 			})
 		case def.KindBroker:
 			applier = broker.NewApplier(a.cl, defDocs[i], broker.ApplierOptions{
-				DefinitionFormat:  a.opts.DefinitionFormat,
+				DefinitionFormat:  formats[i],
+				PropertyOverrides: a.opts.PropertyOverrides,
+				DryRun:            a.opts.DryRun,
+			})
+		case def.KindBrokerLogger:
+			applier = brokerlogger.NewApplier(a.cl, defDocs[i], brokerlogger.ApplierOptions{
+				DefinitionFormat:  formats[i],
 				PropertyOverrides: a.opts.PropertyOverrides,
 				DryRun:            a.opts.DryRun,
 			})
 		case def.KindBrokers:
 			applier = brokers.NewApplier(a.cl, defDocs[i], brokers.ApplierOptions{
-				DefinitionFormat:  a.opts.DefinitionFormat,
+				DefinitionFormat:  formats[i],
+				PropertyOverrides: a.opts.PropertyOverrides,
+				DryRun:            a.opts.DryRun,
+			})
+		case def.KindClientMetrics:
+			applier = clientmetrics.NewApplier(a.cl, defDocs[i], clientmetrics.ApplierOptions{
+				DefinitionFormat:  formats[i],
+				PropertyOverrides: a.opts.PropertyOverrides,
+				DryRun:            a.opts.DryRun,
+			})
+		case def.KindFeatures:
+			applier = features.NewApplier(a.cl, defDocs[i], features.ApplierOptions{
+				DefinitionFormat:  formats[i],
+				PropertyOverrides: a.opts.PropertyOverrides,
+				DryRun:            a.opts.DryRun,
+				AcceptDowngrades:  a.opts.AcceptDowngrades,
+			})
+		case def.KindGroup:
+			applier = group.NewApplier(a.cl, defDocs[i], group.ApplierOptions{
+				DefinitionFormat:  formats[i],
 				PropertyOverrides: a.opts.PropertyOverrides,
 				DryRun:            a.opts.DryRun,
 			})
+		case def.KindGroups:
+			applier = groups.NewApplier(a.cl, defDocs[i], groups.ApplierOptions{
+				DefinitionFormat: formats[i],
+				DryRun:           a.opts.DryRun,
+			})
+		case def.KindPrincipalACL:
+			applier = principalacl.NewApplier(a.cl, defDocs[i], principalacl.ApplierOptions{
+				DefinitionFormat: formats[i],
+				DryRun:           a.opts.DryRun,
+				PrincipalTypes:   a.opts.ACLPrincipalTypes,
+			})
 		case def.KindTopic:
 			applier = topic.NewApplier(a.cl, defDocs[i], topic.ApplierOptions{
-				DefinitionFormat:  a.opts.DefinitionFormat,
+				DefinitionFormat:  formats[i],
 				PropertyOverrides: a.opts.PropertyOverrides,
 				DryRun:            a.opts.DryRun,
 				ReassAwaitTimeout: a.opts.ReassAwaitTimeout,
+				ReassMaxMoves:     a.opts.ReassMaxMoves,
 			})
 		}
 
-		res := applier.Execute(ctx)
-		results = append(results, res)
-		if res.GetErr() != nil && !a.opts.ContinueOnError {
+		// Reject acl definitions that would undo the ACL changes of another definition.
+		var result *res.ApplyResult
+		if err := a.deleteUndefinedACLs.add(resourceDef.Kind, defDocs[i], formats[i], a.aclRoles); err != nil {
+			result = &res.ApplyResult{Err: err.Error()}
+		} else {
+			result = applier.Execute(ctx)
+		}
+		if err := result.GetErr(); err != nil {
+			// Locate the error in the file of the definition.
+			location, msg := docs[i].ErrLocation(err)
+			result.Err = fmt.Sprintf("%s: %s", location, msg)
+			log.Error(fmt.Errorf(
+				"failed to apply definition at %s (lines %d-%d)",
+				location,
+				docs[i].StartLine,
+				docs[i].EndLine,
+			))
+		}
+		results = append(results, result)
+		if result.GetErr() != nil && !a.opts.ContinueOnError {
 			return results, nil
 		}
 	}
@@ -200,27 +364,31 @@ func (a *applyController) applyDefinitions(ctx context.Context, defDocs []string
 	return results, nil
 }
 
-func getResourceDefinitions(defDocs []string, format opt.DefinitionFormat) ([]def.ResourceDefinition, error) {
-	kinds := make([]def.ResourceDefinition, len(defDocs))
+func getResourceDefinitions(docs docparse.Docs, format opt.DefinitionFormat) ([]def.ResourceDefinition, error) {
+	kinds := make([]def.ResourceDefinition, len(docs))
 
-	for i, defDoc := range defDocs {
+	for i, doc := range docs {
 		var resourceDef def.ResourceDefinition
 
 		switch format {
 		case opt.YAMLFormat:
-			if err := yaml.Unmarshal([]byte(defDoc), &resourceDef); err != nil {
-				return nil, err
+			if err := yaml.Unmarshal([]byte(doc.Content), &resourceDef); err != nil {
+				return nil, doc.LocateErr(err)
 			}
 		case opt.JSONFormat:
-			if err := json.Unmarshal([]byte(defDoc), &resourceDef); err != nil {
-				return nil, err
+			if err := json.Unmarshal([]byte(doc.Content), &resourceDef); err != nil {
+				return nil, doc.LocateErr(err)
+			}
+		case opt.HCLFormat:
+			if err := def.UnmarshalHCL(doc.Content, &resourceDef); err != nil {
+				return nil, doc.LocateErr(err)
 			}
 		default:
 			return nil, fmt.Errorf("unsupported format")
 		}
 
 		if err := resourceDef.ValidateResource(); err != nil {
-			return nil, err
+			return nil, doc.LocateErr(err)
 		}
 
 		kinds[i] = resourceDef
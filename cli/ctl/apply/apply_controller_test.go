@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/peter-evans/kdef/cli/ctl/apply/docparse"
 	"github.com/peter-evans/kdef/cli/test/tutil"
 	"github.com/peter-evans/kdef/core/model/def"
 	"github.com/peter-evans/kdef/core/model/opt"
@@ -120,6 +121,44 @@ func Test_getResourceDefinitions(t *testing.T) {
 			want:    nil,
 			wantErr: "invalid definition apiVersion",
 		},
+		{
+			name: "Tests invalid kind (HCL)",
+			args: args{
+				defDocs: []string{"foo \"bar\" {\n  apiVersion = \"v1\"\n}"},
+				format:  opt.HCLFormat,
+			},
+			want:    nil,
+			wantErr: "invalid definition kind",
+		},
+		{
+			name: "Tests return of resource definitions (HCL)",
+			args: args{
+				defDocs: []string{
+					"acl \"topic_foo\" {\n  apiVersion = \"v1\"\n  metadata {\n    type                = \"topic\"\n    resourcePatternType = \"literal\"\n  }\n}",
+					"topic \"topic_foo\" {\n  apiVersion = \"v1\"\n}",
+				},
+				format: opt.HCLFormat,
+			},
+			want: []def.ResourceDefinition{
+				{
+					APIVersion: "v1",
+					Kind:       def.KindACL,
+					Metadata: def.ResourceMetadataDefinition{
+						Name:                "topic_foo",
+						Type:                "topic",
+						ResourcePatternType: "literal",
+					},
+				},
+				{
+					APIVersion: "v1",
+					Kind:       def.KindTopic,
+					Metadata: def.ResourceMetadataDefinition{
+						Name: "topic_foo",
+					},
+				},
+			},
+			wantErr: "",
+		},
 		{
 			name: "Tests return of resource definitions (JSON)",
 			args: args{
@@ -168,7 +207,11 @@ func Test_getResourceDefinitions(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getResourceDefinitions(tt.args.defDocs, tt.args.format)
+			docs := make(docparse.Docs, len(tt.args.defDocs))
+			for i, defDoc := range tt.args.defDocs {
+				docs[i] = docparse.Doc{Content: defDoc, Path: "defs.yml", StartLine: 1}
+			}
+			got, err := getResourceDefinitions(docs, tt.args.format)
 			if !tutil.ErrorContains(err, tt.wantErr) {
 				t.Errorf("getResourceDefinitions() error = %v, wantErr %v", err, tt.wantErr)
 				return
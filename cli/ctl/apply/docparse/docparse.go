@@ -3,50 +3,201 @@ package docparse
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"gopkg.in/yaml.v3"
 )
 
 // Format represents the format of the documents to be parsed.
 type Format int8
 
 // Supported formats.
+// Detect determines the format from the file extension, or the content if the extension is not recognised.
 const (
-	YAML Format = 1
-	JSON Format = 2
+	Detect Format = 0
+	YAML   Format = 1
+	JSON   Format = 2
+	HCL    Format = 3
 )
 
+// StdinPath is the path of documents parsed from stdin.
+const StdinPath = "stdin"
+
 var (
-	yamlDocSeparatorRegExp = regexp.MustCompile(`(?m)^---`)
-	yamlCommentRegExp      = regexp.MustCompile(`(?m)^([^#]*)#?.*$`)
+	yamlDocSeparatorRegExp = regexp.MustCompile(`^(---|\.\.\.)(\s|$)`)
+	errLineRegExp          = regexp.MustCompile(`(yaml|hcl): line (\d+): `)
+	hclBlockRegExp         = regexp.MustCompile(`^[A-Za-z][\w-]*\s+"[^"]*"\s*\{`)
+	jsonErrFieldRegExp     = regexp.MustCompile(`Go struct field [^.\s]+\.(\S+) of type`)
+	fieldRegExp            = regexp.MustCompile(`field "(\S+)"`)
 )
 
-// FromFile parses a file to a slice of separated documents.
-func FromFile(filepath string, format Format) ([]string, error) {
-	b, err := os.ReadFile(filepath)
-	if err != nil {
-		return nil, err
+// fieldPathErr is implemented by errors relating to a field of a document, such as definition validation errors.
+type fieldPathErr interface {
+	error
+	FieldPath() string
+}
+
+// Doc represents a document and its location.
+type Doc struct {
+	Content   string
+	Path      string
+	StartLine int
+	EndLine   int
+
+	node       *yaml.Node
+	fieldLines map[string]int
+}
+
+// Docs represents a slice of documents.
+type Docs []Doc
+
+// Contents returns the content of each document.
+func (d Docs) Contents() []string {
+	contents := make([]string, len(d))
+	for i, doc := range d {
+		contents[i] = doc.Content
 	}
+	return contents
+}
 
-	switch format {
-	case YAML:
-		return bytesToYAMLDocs(b), nil
-	case JSON:
-		docs, err := bytesToJSONDocs(b)
-		if err != nil {
-			return nil, err
+// Location returns the location of the start of the document as "path:line".
+func (d Doc) Location() string {
+	return fmt.Sprintf("%s:%d", d.Path, d.StartLine)
+}
+
+// FieldLine returns the line of a field in the document from its dot separated path.
+// Items of a sequence may be referenced by their index. For fields of sequence items that are not referenced by index,
+// the line of the field in the first item containing it is returned.
+func (d Doc) FieldLine(path string) (int, bool) {
+	if d.fieldLines != nil {
+		if line, ok := d.fieldLines[path]; ok {
+			return line, true
 		}
-		return docs, nil
-	default:
-		return nil, fmt.Errorf("unsupported format")
+		// Fall back to the line of the field without indexes, e.g. for items of list attributes.
+		var keys []string
+		for _, key := range strings.Split(path, ".") {
+			if _, err := strconv.Atoi(key); err != nil {
+				keys = append(keys, key)
+			}
+		}
+		line, ok := d.fieldLines[strings.Join(keys, ".")]
+		return line, ok
+	}
+	if d.node == nil {
+		return 0, false
 	}
+
+	node := d.node
+	for _, key := range strings.Split(path, ".") {
+		if node = childNode(node, key); node == nil {
+			return 0, false
+		}
+	}
+
+	return node.Line, true
 }
 
-// FromStdin parses stdin to a slice of separated documents.
-func FromStdin(format Format) ([]string, error) {
+// childNode finds the key node of a field in a mapping node, or in the items of a sequence node.
+// An integer key finds the item of a sequence node at that index.
+func childNode(node *yaml.Node, key string) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				// Return the value node, with the line of the key.
+				value := *node.Content[i+1]
+				value.Line = node.Content[i].Line
+				return &value
+			}
+		}
+	case yaml.SequenceNode:
+		if i, err := strconv.Atoi(key); err == nil {
+			if i >= 0 && i < len(node.Content) {
+				return node.Content[i]
+			}
+			return nil
+		}
+		for _, item := range node.Content {
+			if child := childNode(item, key); child != nil {
+				return child
+			}
+		}
+	}
+	return nil
+}
+
+// LocateErr prefixes an error relating to the document with its location.
+func (d Doc) LocateErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	location, msg := d.ErrLocation(err)
+	return fmt.Errorf("%s: %s", location, msg)
+}
+
+// ErrLocation returns the location of an error relating to the document as "path:line", and its message.
+// The line is that of the offending field if it can be determined, otherwise the start of the document.
+func (d Doc) ErrLocation(err error) (string, string) {
+	msg := err.Error()
+
+	// Validation errors contain the path of the offending field.
+	var fieldErr fieldPathErr
+	if errors.As(err, &fieldErr) {
+		if line, ok := d.FieldLine(fieldErr.FieldPath()); ok {
+			return fmt.Sprintf("%s:%d", d.Path, line), msg
+		}
+	}
+
+	// YAML and HCL errors contain a line relative to the start of the document.
+	if m := errLineRegExp.FindStringSubmatchIndex(msg); m != nil {
+		line, _ := strconv.Atoi(msg[m[4]:m[5]])
+		msg = msg[:m[0]] + msg[m[2]:m[3]] + ": " + msg[m[1]:]
+		return fmt.Sprintf("%s:%d", d.Path, d.StartLine+line-1), msg
+	}
+
+	// JSON type errors, unknown field errors and reference errors contain the path of the offending field.
+	for _, re := range []*regexp.Regexp{jsonErrFieldRegExp, fieldRegExp} {
+		if m := re.FindStringSubmatch(msg); m != nil {
+			if line, ok := d.FieldLine(m[1]); ok {
+				return fmt.Sprintf("%s:%d", d.Path, line), msg
+			}
+		}
+	}
+
+	return d.Location(), msg
+}
+
+// FromFile parses a file to a slice of separated documents and returns the format they were parsed with.
+func FromFile(path string, format Format) (Docs, Format, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, format, err
+	}
+
+	if format == Detect {
+		format = FormatFromExt(path)
+	}
+	if format == Detect {
+		format = SniffFormat(b)
+	}
+
+	docs, err := bytesToDocs(b, path, format)
+	return docs, format, err
+}
+
+// FromStdin parses stdin to a slice of separated documents and returns the format they were parsed with.
+func FromStdin(format Format) (Docs, Format, error) {
 	var b []byte
 	scanner := bufio.NewScanner(os.Stdin)
 	for scanner.Scan() {
@@ -54,38 +205,155 @@ func FromStdin(format Format) ([]string, error) {
 		b = append(b, "\n"...)
 	}
 	if err := scanner.Err(); err != nil {
-		return nil, err
+		return nil, format, err
+	}
+
+	if format == Detect {
+		format = SniffFormat(b)
+	}
+
+	docs, err := bytesToDocs(b, StdinPath, format)
+	return docs, format, err
+}
+
+// FormatFromExt determines the format of a file from its extension.
+// Detect is returned if the extension is not recognised.
+func FormatFromExt(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		return YAML
+	case ".json":
+		return JSON
+	case ".hcl":
+		return HCL
+	default:
+		return Detect
 	}
+}
+
+// SniffFormat determines the format of content from its first non-whitespace character.
+// Content starting with an object or array is JSON, content starting with a labelled block is HCL,
+// and all other content is YAML.
+func SniffFormat(b []byte) Format {
+	trimmed := strings.TrimSpace(string(b))
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		return JSON
+	}
+	for _, line := range strings.Split(trimmed, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		if hclBlockRegExp.MatchString(line) {
+			return HCL
+		}
+		break
+	}
+	return YAML
+}
+
+func bytesToDocs(b []byte, path string, format Format) (Docs, error) {
+	var docs Docs
+	var err error
 
 	switch format {
 	case YAML:
-		return bytesToYAMLDocs(b), nil
+		docs, err = bytesToYAMLDocs(b)
 	case JSON:
-		docs, err := bytesToJSONDocs(b)
-		if err != nil {
-			return nil, err
-		}
-		return docs, nil
+		docs, err = bytesToJSONDocs(b)
+	case HCL:
+		docs, err = bytesToHCLDocs(b)
 	default:
 		return nil, fmt.Errorf("unsupported format")
 	}
+
+	if err != nil {
+		return nil, Doc{Path: path, StartLine: 1}.LocateErr(err)
+	}
+
+	for i := range docs {
+		docs[i].Path = path
+	}
+
+	return docs, nil
 }
 
-func bytesToYAMLDocs(b []byte) []string {
-	cleanBytes := yamlCommentRegExp.ReplaceAll(b, []byte("$1"))
-	docs := yamlDocSeparatorRegExp.Split(string(cleanBytes), -1)
+// decodeYAMLStream decodes a stream of YAML documents to their nodes, ignoring empty documents.
+func decodeYAMLStream(b []byte) ([]*yaml.Node, error) {
+	var nodes []*yaml.Node
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	for {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		if len(doc.Content) == 0 {
+			continue
+		}
+		node := doc.Content[0]
+		if node.Kind == yaml.ScalarNode && node.Tag == "!!null" && len(node.Value) == 0 {
+			continue
+		}
+
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// endLine returns the last line of a node and its descendants.
+func endLine(node *yaml.Node) int {
+	line := node.Line
+	for _, child := range node.Content {
+		if l := endLine(child); l > line {
+			line = l
+		}
+	}
+	return line
+}
+
+func bytesToYAMLDocs(b []byte) (Docs, error) {
+	nodes, err := decodeYAMLStream(b)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(string(b), "\n")
+
+	docs := make(Docs, len(nodes))
+	for i, node := range nodes {
+		// A document spans from its first node to the line before the first node of the next document.
+		start := node.Line
+		end := len(lines)
+		if i+1 < len(nodes) {
+			end = nodes[i+1].Line - 1
+		}
+
+		// Exclude trailing blank lines, unindented comments and document markers.
+		// Indented lines are kept as they may be the content of a block scalar.
+		for end > start {
+			line := lines[end-1]
+			if len(strings.TrimSpace(line)) > 0 && !strings.HasPrefix(line, "#") && !yamlDocSeparatorRegExp.MatchString(line) {
+				break
+			}
+			end--
+		}
 
-	var yDocs []string
-	for _, doc := range docs {
-		doc = strings.TrimSpace(doc)
-		if len(doc) > 0 {
-			yDocs = append(yDocs, doc)
+		docs[i] = Doc{
+			Content:   strings.TrimSpace(strings.Join(lines[start-1:end], "\n")),
+			StartLine: start,
+			EndLine:   end,
+			node:      node,
 		}
 	}
-	return yDocs
+
+	return docs, nil
 }
 
-func bytesToJSONDocs(b []byte) ([]string, error) {
+func bytesToJSONDocs(b []byte) (Docs, error) {
 	var bi interface{}
 	if err := json.Unmarshal(b, &bi); err != nil {
 		return nil, err
@@ -101,14 +369,113 @@ func bytesToJSONDocs(b []byte) ([]string, error) {
 		return nil, fmt.Errorf("json document is invalid")
 	}
 
-	jDocs := make([]string, len(docs))
+	// JSON is a subset of YAML, so decode it as YAML to determine the location of each document.
+	var nodes []*yaml.Node
+	if rootNodes, err := decodeYAMLStream(b); err == nil && len(rootNodes) == 1 {
+		if rootNodes[0].Kind == yaml.SequenceNode {
+			nodes = rootNodes[0].Content
+		} else {
+			nodes = rootNodes
+		}
+	}
+
+	jDocs := make(Docs, len(docs))
 	for i, doc := range docs {
 		jb, err := json.Marshal(doc)
 		if err != nil {
 			return nil, err
 		}
-		jDocs[i] = string(jb)
+		jDocs[i] = Doc{Content: string(jb), StartLine: 1, EndLine: 1}
+		if len(nodes) == len(docs) {
+			jDocs[i].StartLine = nodes[i].Line
+			jDocs[i].EndLine = endLine(nodes[i])
+			jDocs[i].node = nodes[i]
+		}
 	}
 
 	return jDocs, nil
 }
+
+func bytesToHCLDocs(b []byte) (Docs, error) {
+	file, diags := hclsyntax.ParseConfig(b, "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, hclDiagsErr(diags)
+	}
+
+	body := file.Body.(*hclsyntax.Body)
+	if len(body.Attributes) > 0 {
+		return nil, fmt.Errorf("hcl: definitions must be labelled blocks")
+	}
+
+	lines := strings.Split(string(b), "\n")
+
+	// Each top-level block is a document, of a type that is the definition kind, labelled with the metadata name.
+	docs := make(Docs, len(body.Blocks))
+	for i, block := range body.Blocks {
+		r := block.Range()
+		fieldLines := map[string]int{"kind": block.TypeRange.Start.Line}
+		if len(block.LabelRanges) > 0 {
+			fieldLines["metadata.name"] = block.LabelRanges[0].Start.Line
+		}
+		hclFieldLines(block.Body, "", fieldLines)
+
+		docs[i] = Doc{
+			Content:    strings.Join(lines[r.Start.Line-1:r.End.Line], "\n"),
+			StartLine:  r.Start.Line,
+			EndLine:    r.End.Line,
+			fieldLines: fieldLines,
+		}
+	}
+
+	return docs, nil
+}
+
+// hclFieldLines records the lines of the attributes and blocks of an HCL body by their dot separated path.
+// Repeated blocks are also recorded by their index, e.g. "spec.acls.1.hosts". For paths without an index, the line
+// of the field in the first block containing it is recorded.
+func hclFieldLines(body *hclsyntax.Body, path string, fieldLines map[string]int) {
+	join := func(name string) string {
+		if len(path) == 0 {
+			return name
+		}
+		return path + "." + name
+	}
+
+	for name, attr := range body.Attributes {
+		if name == "ref" {
+			// References to fragments are attributes named "ref", as "$ref" is not a valid HCL identifier.
+			name = "$ref"
+		}
+		fieldLines[join(name)] = attr.NameRange.Start.Line
+	}
+	indexes := make(map[string]int)
+	for _, block := range body.Blocks {
+		if _, ok := fieldLines[join(block.Type)]; !ok {
+			fieldLines[join(block.Type)] = block.TypeRange.Start.Line
+		}
+		hclFieldLines(block.Body, join(block.Type), fieldLines)
+
+		indexPath := fmt.Sprintf("%s.%d", join(block.Type), indexes[block.Type])
+		indexes[block.Type]++
+		fieldLines[indexPath] = block.TypeRange.Start.Line
+		hclFieldLines(block.Body, indexPath, fieldLines)
+	}
+}
+
+// hclDiagsErr converts HCL diagnostics to an error containing the line of the first error.
+func hclDiagsErr(diags hcl.Diagnostics) error {
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagError {
+			continue
+		}
+		msg := diag.Summary
+		if len(diag.Detail) > 0 {
+			msg = fmt.Sprintf("%s; %s", msg, diag.Detail)
+		}
+		if diag.Subject != nil {
+			return fmt.Errorf("hcl: line %d: %s", diag.Subject.Start.Line, msg)
+		}
+		return fmt.Errorf("hcl: %s", msg)
+	}
+	return diags
+}
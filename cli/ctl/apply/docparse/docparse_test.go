@@ -2,8 +2,13 @@
 package docparse
 
 import (
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/opt"
 )
 
 func Test_bytesToYAMLDocs(t *testing.T) {
@@ -11,64 +16,116 @@ func Test_bytesToYAMLDocs(t *testing.T) {
 		bytes []byte
 	}
 	tests := []struct {
-		name string
-		args args
-		want []string
+		name    string
+		args    args
+		want    []string
+		wantErr string
 	}{
 		{
 			name: "Tests basic doc split",
 			args: args{
-				bytes: []byte("doc1\n---\ndoc2\n---\ndoc3"),
+				bytes: []byte("doc: 1\n---\ndoc: 2\n---\ndoc: 3"),
 			},
 			want: []string{
-				"doc1",
-				"doc2",
-				"doc3",
+				"doc: 1",
+				"doc: 2",
+				"doc: 3",
 			},
 		},
 		{
 			name: "Tests doc split with leading separator",
 			args: args{
-				bytes: []byte("---\ndoc1\n---\ndoc2\n---\ndoc3"),
+				bytes: []byte("---\ndoc: 1\n---\ndoc: 2\n---\ndoc: 3"),
 			},
 			want: []string{
-				"doc1",
-				"doc2",
-				"doc3",
+				"doc: 1",
+				"doc: 2",
+				"doc: 3",
 			},
 		},
 		{
 			name: "Tests doc split with unnecessary whitespace",
 			args: args{
-				bytes: []byte("doc1    \n\n---\n\tdoc2\n---\n\n  doc3"),
+				bytes: []byte("doc: 1    \n\n---\n\n  doc: 2\n---\n\n  doc: 3\n\n"),
+			},
+			want: []string{
+				"doc: 1",
+				"doc: 2",
+				"doc: 3",
+			},
+		},
+		{
+			name: "Tests doc split with YAML comments and empty docs",
+			args: args{
+				bytes: []byte("doc: 1 #foo\n---\n#bar\ndoc: 2\n---\n#baz\n---\ndoc: 3\n...\n"),
 			},
 			want: []string{
-				"doc1",
-				"doc2",
-				"doc3",
+				"doc: 1 #foo",
+				"doc: 2",
+				"doc: 3",
 			},
 		},
 		{
-			name: "Tests doc split with YAML comments",
+			name: "Tests values containing comment characters and block scalars containing separators",
 			args: args{
-				bytes: []byte("doc1 #foo\n---\n#bar\ndoc2\n---\n#baz\n---\ndoc3"),
+				bytes: []byte("doc: \"1#2\"\nprincipal: User:foo#bar\n---\ndoc: |\n  ---\n  # not a comment\n"),
 			},
 			want: []string{
-				"doc1",
-				"doc2",
-				"doc3",
+				"doc: \"1#2\"\nprincipal: User:foo#bar",
+				"doc: |\n  ---\n  # not a comment",
 			},
 		},
+		{
+			name: "Tests invalid YAML",
+			args: args{
+				bytes: []byte("doc: 1\n---\ndoc: [2\n"),
+			},
+			wantErr: "did not find expected ',' or ']'",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := bytesToYAMLDocs(tt.args.bytes); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("bytesToYAMLDocs() = %v, want %v", got, tt.want)
+			got, err := bytesToYAMLDocs(tt.args.bytes)
+			if (err == nil) != (tt.wantErr == "") || err != nil && !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("bytesToYAMLDocs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got.Contents(), tt.want) {
+				t.Errorf("bytesToYAMLDocs() = %q, want %q", got.Contents(), tt.want)
 			}
 		})
 	}
 }
 
+func Test_bytesToYAMLDocs_lines(t *testing.T) {
+	b := []byte("# Version 0\n---\napiVersion: v1\nkind: topic\n\n---\n# Version 1\napiVersion: v1\nkind: topic\nspec:\n  partitions: 3\n")
+
+	docs, err := bytesToYAMLDocs(b)
+	if err != nil {
+		t.Fatalf("bytesToYAMLDocs() error = %v", err)
+	}
+
+	type lines struct {
+		StartLine int
+		EndLine   int
+	}
+	var got []lines
+	for _, doc := range docs {
+		got = append(got, lines{doc.StartLine, doc.EndLine})
+	}
+	want := []lines{{3, 4}, {8, 11}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("bytesToYAMLDocs() lines = %v, want %v", got, want)
+	}
+
+	if line, ok := docs[1].FieldLine("spec.partitions"); !ok || line != 11 {
+		t.Errorf("Doc.FieldLine() = %v, %v, want 11, true", line, ok)
+	}
+}
+
 func Test_bytesToJSONDocs(t *testing.T) {
 	type args struct {
 		bytes []byte
@@ -108,9 +165,327 @@ func Test_bytesToJSONDocs(t *testing.T) {
 				t.Errorf("bytesToJSONDocs() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
+			if !reflect.DeepEqual(got.Contents(), tt.want) {
 				t.Errorf("bytesToJSONDocs() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func Test_bytesToHCLDocs(t *testing.T) {
+	b := []byte("# Version 0\ntopic \"foo\" {\n  apiVersion = \"v1\"\n}\n\ntopic \"bar\" {\n  apiVersion = \"v1\"\n  spec {\n    partitions = 3\n  }\n}\n")
+
+	docs, err := bytesToHCLDocs(b)
+	if err != nil {
+		t.Fatalf("bytesToHCLDocs() error = %v", err)
+	}
+
+	want := []string{
+		"topic \"foo\" {\n  apiVersion = \"v1\"\n}",
+		"topic \"bar\" {\n  apiVersion = \"v1\"\n  spec {\n    partitions = 3\n  }\n}",
+	}
+	if !reflect.DeepEqual(docs.Contents(), want) {
+		t.Errorf("bytesToHCLDocs() = %v, want %v", docs.Contents(), want)
+	}
+
+	if docs[1].StartLine != 6 || docs[1].EndLine != 11 {
+		t.Errorf("bytesToHCLDocs() lines = %d-%d, want 6-11", docs[1].StartLine, docs[1].EndLine)
+	}
+
+	if line, ok := docs[1].FieldLine("spec.partitions"); !ok || line != 9 {
+		t.Errorf("Doc.FieldLine() = %v, %v, want 9, true", line, ok)
+	}
+
+	refDocs, err := bytesToHCLDocs([]byte("topic \"foo\" {\n  spec {\n    ref = \"spec.yml\"\n  }\n}\n"))
+	if err != nil {
+		t.Fatalf("bytesToHCLDocs() error = %v", err)
+	}
+	if line, ok := refDocs[0].FieldLine("spec.$ref"); !ok || line != 3 {
+		t.Errorf("Doc.FieldLine() = %v, %v, want 3, true", line, ok)
+	}
+
+	if _, err := bytesToHCLDocs([]byte("apiVersion = \"v1\"\n")); err == nil {
+		t.Errorf("bytesToHCLDocs() expected an error for a top-level attribute")
+	}
+}
+
+func TestFormatFromExt(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want Format
+	}{
+		{
+			name: "Tests a .yml extension",
+			path: "topics/foo.yml",
+			want: YAML,
+		},
+		{
+			name: "Tests a .yaml extension",
+			path: "topics/foo.yaml",
+			want: YAML,
+		},
+		{
+			name: "Tests a .json extension",
+			path: "topics/foo.JSON",
+			want: JSON,
+		},
+		{
+			name: "Tests a .hcl extension",
+			path: "topics/foo.hcl",
+			want: HCL,
+		},
+		{
+			name: "Tests an unrecognised extension",
+			path: "topics/foo.txt",
+			want: Detect,
+		},
+		{
+			name: "Tests no extension",
+			path: "topics/foo",
+			want: Detect,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatFromExt(tt.path); got != tt.want {
+				t.Errorf("FormatFromExt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSniffFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		bytes []byte
+		want  Format
+	}{
+		{
+			name:  "Tests a JSON object",
+			bytes: []byte("\n  {\"name\": \"foo\"}"),
+			want:  JSON,
+		},
+		{
+			name:  "Tests an array of JSON objects",
+			bytes: []byte("[{\"name\": \"foo\"},{\"name\": \"bar\"}]"),
+			want:  JSON,
+		},
+		{
+			name:  "Tests YAML docs",
+			bytes: []byte("---\napiVersion: v1\nkind: topic\n"),
+			want:  YAML,
+		},
+		{
+			name:  "Tests YAML with a leading comment",
+			bytes: []byte("# {foo}\napiVersion: v1\n"),
+			want:  YAML,
+		},
+		{
+			name:  "Tests HCL blocks with a leading comment",
+			bytes: []byte("// topics\ntopic \"foo\" {\n  apiVersion = \"v1\"\n}\n"),
+			want:  HCL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SniffFormat(tt.bytes); got != tt.want {
+				t.Errorf("SniffFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoc_LocateErr(t *testing.T) {
+	docs, err := bytesToYAMLDocs([]byte("---\napiVersion: v1\n---\napiVersion: v1\nkind: topic\nspec:\n  partitions: foo\n"))
+	if err != nil {
+		t.Fatalf("bytesToYAMLDocs() error = %v", err)
+	}
+	doc := docs[1]
+	doc.Path = "topics/foo.yml"
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "Tests a YAML error with a line relative to the document",
+			err:  errors.New("error converting YAML to JSON: yaml: line 2: mapping values are not allowed in this context"),
+			want: "topics/foo.yml:5: error converting YAML to JSON: yaml: mapping values are not allowed in this context",
+		},
+		{
+			name: "Tests an HCL error with a line relative to the document",
+			err:  errors.New("hcl: line 3: Invalid expression"),
+			want: "topics/foo.yml:6: hcl: Invalid expression",
+		},
+		{
+			name: "Tests a JSON type error with a field path",
+			err:  errors.New("json: cannot unmarshal string into Go struct field TopicSpecDefinition.spec.partitions of type int"),
+			want: "topics/foo.yml:7: json: cannot unmarshal string into Go struct field TopicSpecDefinition.spec.partitions of type int",
+		},
+		{
+			name: "Tests an unknown field error with a field path",
+			err:  errors.New(`unknown field "spec.partitions"`),
+			want: `topics/foo.yml:7: unknown field "spec.partitions"`,
+		},
+		{
+			name: "Tests a reference error with a field path",
+			err:  errors.New(`failed to resolve field "spec.partitions": failed to read fragment`),
+			want: `topics/foo.yml:7: failed to resolve field "spec.partitions": failed to read fragment`,
+		},
+		{
+			name: "Tests an error without a line or field",
+			err:  errors.New("metadata name must be supplied"),
+			want: "topics/foo.yml:4: metadata name must be supplied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := doc.LocateErr(tt.err); got.Error() != tt.want {
+				t.Errorf("Doc.LocateErr() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoc_LocateErr_validation(t *testing.T) {
+	yamlTopic := `apiVersion: v1
+kind: topic
+metadata:
+  name: foo
+spec:
+  configs:
+    retention.ms: "86400000"
+  partitions: 2
+  replicationFactor: 2
+  assignments:
+    - [1, 2]
+    - [3, 3]
+`
+	yamlACL := `apiVersion: v1
+kind: acl
+metadata:
+  name: foo
+  type: topic
+spec:
+  acls:
+    - principals: ["User:foo"]
+      hosts: ["*"]
+      operations: ["READ"]
+      permissionType: ALLOW
+    - principals: ["User:bar"]
+      hosts:
+        - "*"
+        - 10.0.0.0/8
+      operations: ["WRITE"]
+      permissionType: ALLOW
+`
+	jsonTopic := `{
+  "apiVersion": "v1",
+  "kind": "topic",
+  "metadata": {
+    "name": "foo"
+  },
+  "spec": {
+    "partitions": 0,
+    "replicationFactor": 2
+  }
+}`
+	hclACL := `acl "foo" {
+  apiVersion = "v1"
+  metadata {
+    type = "topic"
+  }
+  spec {
+    acls {
+      principals     = ["User:foo"]
+      hosts          = ["*"]
+      operations     = ["READ"]
+      permissionType = "ALLOW"
+    }
+    acls {
+      principals     = ["User:bar"]
+      hosts          = ["*"]
+      operations     = ["WRITE"]
+      permissionType = "DENIED"
+    }
+  }
+}`
+
+	tests := []struct {
+		name     string
+		content  string
+		format   Format
+		validate func(doc string) error
+		want     string
+	}{
+		{
+			name:    "Tests a YAML topic validation error in a sequence item",
+			content: yamlTopic,
+			format:  YAML,
+			validate: func(doc string) error {
+				topicDef, err := def.LoadTopicDefinition(doc, opt.YAMLFormat, nil)
+				if err != nil {
+					return err
+				}
+				return topicDef.Validate()
+			},
+			want: "defs.yml:12: a replica assignment cannot contain duplicate brokers",
+		},
+		{
+			name:    "Tests a YAML acl validation error in a nested sequence item",
+			content: yamlACL,
+			format:  YAML,
+			validate: func(doc string) error {
+				aclDef, err := def.LoadACLDefinition(doc, opt.YAMLFormat)
+				if err != nil {
+					return err
+				}
+				return aclDef.Validate()
+			},
+			want: `defs.yml:15: host "10.0.0.0/8" is a CIDR range but acls only match exact IP addresses or "*"`,
+		},
+		{
+			name:    "Tests a JSON topic validation error",
+			content: jsonTopic,
+			format:  JSON,
+			validate: func(doc string) error {
+				topicDef, err := def.LoadTopicDefinition(doc, opt.JSONFormat, nil)
+				if err != nil {
+					return err
+				}
+				return topicDef.Validate()
+			},
+			want: "defs.yml:8: partitions must be greater than 0",
+		},
+		{
+			name:    "Tests an HCL acl validation error in a repeated block",
+			content: hclACL,
+			format:  HCL,
+			validate: func(doc string) error {
+				aclDef, err := def.LoadACLDefinition(doc, opt.HCLFormat)
+				if err != nil {
+					return err
+				}
+				return aclDef.Validate()
+			},
+			want: `defs.yml:17: acl permission type must be one of "ALLOW|DENY"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs, err := bytesToDocs([]byte(tt.content), "defs.yml", tt.format)
+			if err != nil {
+				t.Fatalf("bytesToDocs() error = %v", err)
+			}
+			err = tt.validate(docs[0].Content)
+			if err == nil {
+				t.Fatalf("validate() expected an error")
+			}
+			if got := docs[0].LocateErr(err); got.Error() != tt.want {
+				t.Errorf("Doc.LocateErr() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Package balanceleaders implements the balance leaders controller.
+package balanceleaders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/operators/cluster"
+)
+
+// ControllerOptions represents options to configure a balance leaders controller.
+type ControllerOptions struct {
+	// Leader balancer options.
+	Match           string
+	Exclude         string
+	IncludeInternal bool
+	DryRun          bool
+}
+
+// NewBalanceLeadersController creates a new balance leaders controller.
+func NewBalanceLeadersController(
+	cl *client.Client,
+	opts ControllerOptions,
+) *balanceLeadersController { //revive:disable-line:unexported-return
+	return &balanceLeadersController{
+		cl:   cl,
+		opts: opts,
+	}
+}
+
+type balanceLeadersController struct {
+	cl   *client.Client
+	opts ControllerOptions
+}
+
+// Execute implements the execution of the balance leaders controller.
+func (b *balanceLeadersController) Execute(ctx context.Context) error {
+	leaderBalancer := cluster.NewLeaderBalancer(b.cl, cluster.LeaderBalancerOptions{
+		Match:           b.opts.Match,
+		Exclude:         b.opts.Exclude,
+		IncludeInternal: b.opts.IncludeInternal,
+		DryRun:          b.opts.DryRun,
+	})
+
+	if err := leaderBalancer.Execute(ctx); err != nil {
+		log.Error(err)
+		return fmt.Errorf("balance leaders completed with errors")
+	}
+
+	return nil
+}
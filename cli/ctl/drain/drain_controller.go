@@ -0,0 +1,56 @@
+// Package drain implements the drain controller.
+package drain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/operators/broker"
+)
+
+// ControllerOptions represents options to configure a drain controller.
+type ControllerOptions struct {
+	// Drainer options.
+	ReassMaxMoves     int
+	ReassAwaitTimeout int
+	ThrottleRate      int64
+	DryRun            bool
+}
+
+// NewDrainController creates a new drain controller.
+func NewDrainController(
+	cl *client.Client,
+	brokerID int32,
+	opts ControllerOptions,
+) *drainController { //revive:disable-line:unexported-return
+	return &drainController{
+		cl:       cl,
+		brokerID: brokerID,
+		opts:     opts,
+	}
+}
+
+type drainController struct {
+	cl       *client.Client
+	brokerID int32
+	opts     ControllerOptions
+}
+
+// Execute implements the execution of the drain controller.
+func (d *drainController) Execute(ctx context.Context) error {
+	drainer := broker.NewDrainer(d.cl, d.brokerID, broker.DrainerOptions{
+		ReassMaxMoves:     d.opts.ReassMaxMoves,
+		ReassAwaitTimeout: d.opts.ReassAwaitTimeout,
+		ThrottleRate:      d.opts.ThrottleRate,
+		DryRun:            d.opts.DryRun,
+	})
+
+	if err := drainer.Execute(ctx); err != nil {
+		log.Error(err)
+		return fmt.Errorf("drain completed with errors")
+	}
+
+	return nil
+}
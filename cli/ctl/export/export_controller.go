@@ -8,16 +8,23 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	"github.com/peter-evans/kdef/cli/log"
 	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/helpers/fragments"
+	"github.com/peter-evans/kdef/core/helpers/reassignments"
 	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/meta"
 	"github.com/peter-evans/kdef/core/model/opt"
 	"github.com/peter-evans/kdef/core/model/res"
 	"github.com/peter-evans/kdef/core/operators/acl"
 	"github.com/peter-evans/kdef/core/operators/broker"
 	"github.com/peter-evans/kdef/core/operators/brokers"
+	"github.com/peter-evans/kdef/core/operators/clientmetrics"
+	"github.com/peter-evans/kdef/core/operators/features"
+	"github.com/peter-evans/kdef/core/operators/group"
 	"github.com/peter-evans/kdef/core/operators/topic"
 )
 
@@ -34,6 +41,8 @@ type ControllerOptions struct {
 	// ExporterOptions for topic definitions.
 	TopicIncludeInternal bool
 	TopicAssignments     opt.Assignments
+	TopicLogDirs         bool
+	TopicReassPlanFile   string
 
 	// ExporterOptions for acl definitions.
 	ACLResourceType string
@@ -43,6 +52,7 @@ type ControllerOptions struct {
 	DefinitionFormat opt.DefinitionFormat
 	OutputDir        string
 	Overwrite        bool
+	ConfigsFragment  string
 }
 
 // NewExportController creates a new export controller.
@@ -77,6 +87,18 @@ func (e *exportController) Execute(ctx context.Context) error {
 
 	log.Infof("Exporting %d %s definition(s)...", len(results), e.kind)
 
+	if len(e.opts.TopicReassPlanFile) > 0 {
+		if err := e.writeReassPlanFile(results); err != nil {
+			return err
+		}
+	}
+
+	if len(e.opts.ConfigsFragment) > 0 {
+		if err := e.factorConfigsFragment(results); err != nil {
+			return err
+		}
+	}
+
 	stdout := len(e.opts.OutputDir) == 0
 	if stdout && e.opts.DefinitionFormat == opt.JSONFormat {
 		defDocBytes, err := getDefDocBytes(results.Defs(), e.opts.DefinitionFormat)
@@ -86,7 +108,7 @@ func (e *exportController) Execute(ctx context.Context) error {
 		// Ignores --quiet.
 		fmt.Print(string(defDocBytes))
 	} else {
-		for _, result := range results {
+		for i, result := range results {
 			defDocBytes, err := getDefDocBytes(result.Def, e.opts.DefinitionFormat)
 			if err != nil {
 				return err
@@ -94,13 +116,17 @@ func (e *exportController) Execute(ctx context.Context) error {
 
 			if stdout {
 				// Ignores --quiet.
-				fmt.Printf("---\n%s", string(defDocBytes))
+				if e.opts.DefinitionFormat == opt.HCLFormat {
+					// Separate the labelled blocks of HCL definitions with a blank line.
+					if i > 0 {
+						fmt.Println()
+					}
+					fmt.Print(string(defDocBytes))
+				} else {
+					fmt.Printf("---\n%s", string(defDocBytes))
+				}
 			} else {
-				outputPath := filepath.Join(
-					e.opts.OutputDir,
-					result.Type,
-					fmt.Sprintf("%s.%s", result.ID, e.opts.DefinitionFormat.Ext()),
-				)
+				outputPath := e.outputPath(result)
 
 				dirPath := filepath.Dir(outputPath)
 				if err := os.MkdirAll(dirPath, 0o755); err != nil {
@@ -125,6 +151,133 @@ func (e *exportController) Execute(ctx context.Context) error {
 	return nil
 }
 
+// outputPath returns the path of the definition file of an export result.
+func (e *exportController) outputPath(result res.ExportResult) string {
+	return filepath.Join(
+		e.opts.OutputDir,
+		result.Type,
+		fmt.Sprintf("%s.%s", result.ID, e.opts.DefinitionFormat.Ext()),
+	)
+}
+
+// factorConfigsFragment writes the configs common to exported definitions to a fragment file,
+// and replaces them in each definition with a reference to the fragment.
+func (e *exportController) factorConfigsFragment(results res.ExportResults) error {
+	var configs []def.ConfigsMap
+	for _, result := range results {
+		if c := definitionConfigs(result.Def); c != nil {
+			configs = append(configs, c)
+		}
+	}
+
+	common := fragments.CommonConfigs(configs)
+	if len(common) == 0 {
+		log.Infof("No configs common to exported %s definitions to write to a fragment", e.kind)
+		return nil
+	}
+
+	// The fragment path is relative to the output directory when exporting to files.
+	fragmentPath := e.opts.ConfigsFragment
+	stdout := len(e.opts.OutputDir) == 0
+	if !stdout && !filepath.IsAbs(fragmentPath) {
+		fragmentPath = filepath.Join(e.opts.OutputDir, fragmentPath)
+	}
+
+	if err := e.writeConfigsFragment(fragmentPath, common); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		c := definitionConfigs(result.Def)
+		if c == nil {
+			continue
+		}
+
+		ref := fragmentPath
+		if !stdout {
+			// References are relative to the definition file.
+			var err error
+			if ref, err = filepath.Rel(filepath.Dir(e.outputPath(result)), fragmentPath); err != nil {
+				return err
+			}
+		}
+		ref = filepath.ToSlash(ref)
+
+		for key := range common {
+			delete(c, key)
+		}
+		c[fragments.RefKey] = &ref
+	}
+
+	return nil
+}
+
+// writeConfigsFragment writes configs to a YAML or JSON fragment file, determined by the file extension.
+func (e *exportController) writeConfigsFragment(path string, configs def.ConfigsMap) error {
+	dirPath := filepath.Dir(path)
+	if err := os.MkdirAll(dirPath, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory path %q: %v", dirPath, err)
+	}
+
+	// Definitions reference the configs written here, so an existing fragment cannot be skipped.
+	if !e.opts.Overwrite {
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("configs fragment file %q already exists; set \"overwrite\" to replace it", path)
+		}
+	}
+
+	format := opt.YAMLFormat
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		format = opt.JSONFormat
+	}
+	b, err := getDefDocBytes(configs, format)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Writing configs fragment file %q with %d config(s)", path, len(configs))
+	return os.WriteFile(path, b, 0o666)
+}
+
+// definitionConfigs returns the configs of a definition, or nil if the definition has no configs.
+func definitionConfigs(defDoc interface{}) def.ConfigsMap {
+	switch d := defDoc.(type) {
+	case def.BrokerDefinition:
+		return d.Spec.Configs
+	case def.BrokersDefinition:
+		return d.Spec.Configs
+	case def.ClientMetricsDefinition:
+		return d.Spec.Configs
+	case def.GroupDefinition:
+		return d.Spec.Configs
+	case def.TopicDefinition:
+		return d.Spec.Configs
+	default:
+		return nil
+	}
+}
+
+// writeReassPlanFile writes the current partition assignments of exported topics to a reassignment plan file.
+func (e *exportController) writeReassPlanFile(results res.ExportResults) error {
+	var assignments meta.TopicPartitionAssignments
+	for _, result := range results {
+		if data, ok := result.Data.(res.TopicExportResultData); ok {
+			assignments = append(assignments, data.PartitionAssignments...)
+		}
+	}
+
+	log.Infof(
+		"Writing reassignment plan file %q with %d partition assignment(s)",
+		e.opts.TopicReassPlanFile,
+		len(assignments),
+	)
+	if err := reassignments.WritePlanFile(e.opts.TopicReassPlanFile, meta.NewReassignmentPlan(assignments)); err != nil {
+		return fmt.Errorf("failed to write reassignment plan file: %v", err)
+	}
+
+	return nil
+}
+
 func (e *exportController) exportResources(ctx context.Context) (res.ExportResults, error) {
 	var exporter exporter
 	switch e.kind {
@@ -139,12 +292,19 @@ func (e *exportController) exportResources(ctx context.Context) (res.ExportResul
 		exporter = broker.NewExporter(e.cl)
 	case def.KindBrokers:
 		exporter = brokers.NewExporter(e.cl)
+	case def.KindClientMetrics:
+		exporter = clientmetrics.NewExporter(e.cl)
+	case def.KindFeatures:
+		exporter = features.NewExporter(e.cl)
+	case def.KindGroup:
+		exporter = group.NewExporter(e.cl)
 	case def.KindTopic:
 		exporter = topic.NewExporter(e.cl, topic.ExporterOptions{
 			Match:           e.opts.Match,
 			Exclude:         e.opts.Exclude,
 			IncludeInternal: e.opts.TopicIncludeInternal,
 			Assignments:     e.opts.TopicAssignments,
+			LogDirs:         e.opts.TopicLogDirs,
 		})
 	}
 
@@ -156,16 +316,18 @@ func (e *exportController) exportResources(ctx context.Context) (res.ExportResul
 	return results, nil
 }
 
-func getDefDocBytes(def interface{}, format opt.DefinitionFormat) ([]byte, error) {
+func getDefDocBytes(defDoc interface{}, format opt.DefinitionFormat) ([]byte, error) {
 	var b []byte
 	var err error
 
 	switch format {
 	case opt.YAMLFormat:
-		b, err = yaml.Marshal(def)
+		b, err = yaml.Marshal(defDoc)
 	case opt.JSONFormat:
-		b, err = json.MarshalIndent(def, "", "  ")
+		b, err = json.MarshalIndent(defDoc, "", "  ")
 		b = append(b, "\n"...)
+	case opt.HCLFormat:
+		b, err = def.MarshalHCL(defDoc)
 	default:
 		return nil, fmt.Errorf("unsupported format")
 	}
@@ -0,0 +1,115 @@
+// Package reassignments implements the reassignments controller.
+package reassignments
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/helpers/reassignments"
+	"github.com/peter-evans/kdef/core/model/res"
+	opreassignments "github.com/peter-evans/kdef/core/operators/reassignments"
+)
+
+// Operations supported by the reassignments controller.
+const (
+	OperationList    = "list"
+	OperationCancel  = "cancel"
+	OperationExecute = "execute"
+)
+
+type operator interface {
+	Execute(ctx context.Context) *res.ReassignmentsResult
+}
+
+// ControllerOptions represents options to configure a reassignments controller.
+type ControllerOptions struct {
+	// Operator options.
+	Match   string
+	Exclude string
+	DryRun  bool
+
+	// Operator options for executing a reassignment plan.
+	PlanFile          string
+	ReassMaxMoves     int
+	ReassAwaitTimeout int
+	ThrottleRate      int64
+
+	// Reassignments controller specific options.
+	JSONOutput bool
+}
+
+// NewReassignmentsController creates a new reassignments controller.
+func NewReassignmentsController(
+	cl *client.Client,
+	opts ControllerOptions,
+	operation string,
+) *reassignmentsController { //revive:disable-line:unexported-return
+	return &reassignmentsController{
+		cl:        cl,
+		opts:      opts,
+		operation: operation,
+	}
+}
+
+type reassignmentsController struct {
+	cl        *client.Client
+	opts      ControllerOptions
+	operation string
+}
+
+// Execute implements the execution of the reassignments controller.
+func (r *reassignmentsController) Execute(ctx context.Context) error {
+	var operator operator
+	switch r.operation {
+	case OperationList:
+		operator = opreassignments.NewLister(r.cl, opreassignments.ListerOptions{
+			Match:   r.opts.Match,
+			Exclude: r.opts.Exclude,
+		})
+	case OperationCancel:
+		operator = opreassignments.NewCanceller(r.cl, opreassignments.CancellerOptions{
+			Match:   r.opts.Match,
+			Exclude: r.opts.Exclude,
+			DryRun:  r.opts.DryRun,
+		})
+	case OperationExecute:
+		log.Infof("Reading reassignment plan from file %q", r.opts.PlanFile)
+		plan, err := reassignments.ReadPlanFile(r.opts.PlanFile)
+		if err != nil {
+			return err
+		}
+		operator = opreassignments.NewImporter(r.cl, plan, opreassignments.ImporterOptions{
+			ReassMaxMoves:     r.opts.ReassMaxMoves,
+			ReassAwaitTimeout: r.opts.ReassAwaitTimeout,
+			ThrottleRate:      r.opts.ThrottleRate,
+			DryRun:            r.opts.DryRun,
+		})
+	default:
+		return fmt.Errorf("unsupported operation %q", r.operation)
+	}
+
+	result := operator.Execute(ctx)
+
+	if r.opts.JSONOutput {
+		out, err := result.JSON()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", out)
+	} else if r.operation == OperationList && result.GetErr() == nil {
+		if len(result.PartitionReassignments) == 0 {
+			log.Infof("No in-progress partition reassignments found")
+		} else {
+			// Ignores --quiet.
+			reassignments.Display(result.PartitionReassignments, true)
+		}
+	}
+
+	if result.GetErr() != nil {
+		return fmt.Errorf("%s completed with errors", r.operation)
+	}
+
+	return nil
+}
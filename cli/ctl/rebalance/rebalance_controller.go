@@ -0,0 +1,59 @@
+// Package rebalance implements the rebalance controller.
+package rebalance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/operators/cluster"
+)
+
+// ControllerOptions represents options to configure a rebalance controller.
+type ControllerOptions struct {
+	// Rebalancer options.
+	Match             string
+	Exclude           string
+	IncludeInternal   bool
+	ReassMaxMoves     int
+	ReassAwaitTimeout int
+	ThrottleRate      int64
+	DryRun            bool
+}
+
+// NewRebalanceController creates a new rebalance controller.
+func NewRebalanceController(
+	cl *client.Client,
+	opts ControllerOptions,
+) *rebalanceController { //revive:disable-line:unexported-return
+	return &rebalanceController{
+		cl:   cl,
+		opts: opts,
+	}
+}
+
+type rebalanceController struct {
+	cl   *client.Client
+	opts ControllerOptions
+}
+
+// Execute implements the execution of the rebalance controller.
+func (r *rebalanceController) Execute(ctx context.Context) error {
+	rebalancer := cluster.NewRebalancer(r.cl, cluster.RebalancerOptions{
+		Match:             r.opts.Match,
+		Exclude:           r.opts.Exclude,
+		IncludeInternal:   r.opts.IncludeInternal,
+		ReassMaxMoves:     r.opts.ReassMaxMoves,
+		ReassAwaitTimeout: r.opts.ReassAwaitTimeout,
+		ThrottleRate:      r.opts.ThrottleRate,
+		DryRun:            r.opts.DryRun,
+	})
+
+	if err := rebalancer.Execute(ctx); err != nil {
+		log.Error(err)
+		return fmt.Errorf("rebalance completed with errors")
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+// Package schema implements the schema controller.
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/model/def"
+)
+
+// definitionsSchemaFile is the file name of the schema matching any definition.
+const definitionsSchemaFile = "kdef.schema.json"
+
+// ControllerOptions represents options to configure a schema controller.
+type ControllerOptions struct {
+	Kind       string
+	APIVersion string
+	OutputDir  string
+	Overwrite  bool
+}
+
+// NewSchemaController creates a new schema controller.
+func NewSchemaController(
+	opts ControllerOptions,
+) *schemaController { //revive:disable-line:unexported-return
+	return &schemaController{
+		opts: opts,
+	}
+}
+
+type schemaController struct {
+	opts ControllerOptions
+}
+
+// Execute implements the execution of the schema controller.
+func (s *schemaController) Execute() error {
+	if len(s.opts.OutputDir) == 0 {
+		var schema *def.JSONSchema
+		var err error
+		if len(s.opts.Kind) > 0 {
+			schema, err = def.NewJSONSchema(s.opts.Kind, s.opts.APIVersion)
+		} else {
+			schema, err = def.NewDefinitionsJSONSchema()
+		}
+		if err != nil {
+			return err
+		}
+
+		b, err := getSchemaBytes(schema)
+		if err != nil {
+			return err
+		}
+		// Ignores --quiet.
+		fmt.Print(string(b))
+		return nil
+	}
+
+	if err := os.MkdirAll(s.opts.OutputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory path %q: %v", s.opts.OutputDir, err)
+	}
+
+	if len(s.opts.Kind) > 0 {
+		return s.writeKindSchemaFile(s.opts.Kind, s.opts.APIVersion)
+	}
+
+	for _, kind := range def.DefinitionKinds() {
+		for _, apiVersion := range def.DefinitionKindVersions(kind) {
+			if err := s.writeKindSchemaFile(kind, apiVersion); err != nil {
+				return err
+			}
+		}
+	}
+
+	schema, err := def.NewDefinitionsJSONSchema()
+	if err != nil {
+		return err
+	}
+	return s.writeSchemaFile(filepath.Join(s.opts.OutputDir, definitionsSchemaFile), schema)
+}
+
+// writeKindSchemaFile writes the schema of a definition kind and apiVersion to the output directory.
+func (s *schemaController) writeKindSchemaFile(kind string, apiVersion string) error {
+	schema, err := def.NewJSONSchema(kind, apiVersion)
+	if err != nil {
+		return err
+	}
+	outputPath := filepath.Join(s.opts.OutputDir, fmt.Sprintf("%s.%s.schema.json", kind, apiVersion))
+	return s.writeSchemaFile(outputPath, schema)
+}
+
+// writeSchemaFile writes a schema to a file.
+func (s *schemaController) writeSchemaFile(outputPath string, schema *def.JSONSchema) error {
+	if !s.opts.Overwrite {
+		if _, err := os.Stat(outputPath); !errors.Is(err, os.ErrNotExist) {
+			log.Infof("Skipping overwrite of existing file %q", outputPath)
+			return nil
+		}
+	}
+
+	b, err := getSchemaBytes(schema)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Writing schema file %q", outputPath)
+	return os.WriteFile(outputPath, b, 0o666)
+}
+
+func getSchemaBytes(schema *def.JSONSchema) ([]byte, error) {
+	b, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, "\n"...), nil
+}
@@ -0,0 +1,72 @@
+// Package uncleanelect implements the unclean elect controller.
+package uncleanelect
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/operators/cluster"
+)
+
+// ControllerOptions represents options to configure an unclean elect controller.
+type ControllerOptions struct {
+	// Unclean elector options.
+	Match           string
+	Exclude         string
+	IncludeInternal bool
+	Partitions      []int32
+	DryRun          bool
+
+	// Unclean elect controller specific options.
+	AcceptDataLoss bool
+	JSONOutput     bool
+}
+
+// NewUncleanElectController creates a new unclean elect controller.
+func NewUncleanElectController(
+	cl *client.Client,
+	opts ControllerOptions,
+) *uncleanElectController { //revive:disable-line:unexported-return
+	return &uncleanElectController{
+		cl:   cl,
+		opts: opts,
+	}
+}
+
+type uncleanElectController struct {
+	cl   *client.Client
+	opts ControllerOptions
+}
+
+// Execute implements the execution of the unclean elect controller.
+func (u *uncleanElectController) Execute(ctx context.Context) error {
+	if !u.opts.DryRun && !u.opts.AcceptDataLoss {
+		// Should never reach here due to command validation.
+		return fmt.Errorf("unclean leader election requires acceptance of data loss")
+	}
+
+	elector := cluster.NewUncleanElector(u.cl, cluster.UncleanElectorOptions{
+		Match:           u.opts.Match,
+		Exclude:         u.opts.Exclude,
+		IncludeInternal: u.opts.IncludeInternal,
+		Partitions:      u.opts.Partitions,
+		DryRun:          u.opts.DryRun,
+	})
+
+	result := elector.Execute(ctx)
+
+	if u.opts.JSONOutput {
+		out, err := result.JSON()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", out)
+	}
+
+	if result.GetErr() != nil {
+		return fmt.Errorf("unclean election completed with errors")
+	}
+
+	return nil
+}
@@ -2,6 +2,10 @@
 package acls
 
 import (
+	"fmt"
+	"os"
+	"sort"
+
 	"github.com/peter-evans/kdef/core/model/def"
 	"github.com/peter-evans/kdef/core/util/str"
 )
@@ -132,3 +136,36 @@ func tryMergeGroups(a def.ACLEntryGroup, b def.ACLEntryGroup) def.ACLEntryGroups
 
 	return def.ACLEntryGroups{a, b}
 }
+
+// ReadRolesFile reads a file of user-defined roles and merges them with built-in roles.
+func ReadRolesFile(path string) (def.ACLRoles, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := def.LoadACLRoles(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("invalid acl roles file %q: %v", path, err)
+	}
+
+	return roles, nil
+}
+
+// SortedResources returns the resources of ACL entry groups sorted by type, pattern type and name.
+func SortedResources(resourceACLs map[def.ACLResource]def.ACLEntryGroups) []def.ACLResource {
+	resources := make([]def.ACLResource, 0, len(resourceACLs))
+	for resource := range resourceACLs {
+		resources = append(resources, resource)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Type != resources[j].Type {
+			return resources[i].Type < resources[j].Type
+		}
+		if resources[i].PatternType != resources[j].PatternType {
+			return resources[i].PatternType < resources[j].PatternType
+		}
+		return resources[i].Name < resources[j].Name
+	})
+	return resources
+}
@@ -2,16 +2,81 @@
 package assignments
 
 import (
+	"cmp"
 	"sort"
 
 	"github.com/peter-evans/kdef/core/util/i32"
 )
 
+// ClusterUse represents the use of brokers across the cluster, weighting the selection of brokers.
+type ClusterUse struct {
+	// ReplicaCounts is the number of partition replicas per broker.
+	// It breaks ties between brokers with the same number of replicas in the topic and the same disk use.
+	ReplicaCounts map[int32]int
+	// DiskUse is the disk use in bytes per broker, where less is preferred.
+	// If set, it breaks ties between brokers with the same number of replicas in the topic.
+	DiskUse map[int32]int64
+	// PartitionSizes is the size in bytes of each partition of the topic.
+	// Disk use is adjusted by the size of a partition as its replicas move between brokers.
+	PartitionSizes map[int32]int64
+}
+
+// add adds a replica of a partition to the use of a broker.
+func (c *ClusterUse) add(partition int, brokerID int32) {
+	if c == nil {
+		return
+	}
+	if c.DiskUse != nil {
+		c.DiskUse[brokerID] += c.PartitionSizes[int32(partition)]
+	}
+	if c.ReplicaCounts != nil {
+		c.ReplicaCounts[brokerID]++
+	}
+}
+
+// remove removes a replica of a partition from the use of a broker.
+func (c *ClusterUse) remove(partition int, brokerID int32) {
+	if c == nil {
+		return
+	}
+	if c.DiskUse != nil {
+		c.DiskUse[brokerID] -= c.PartitionSizes[int32(partition)]
+	}
+	if c.ReplicaCounts != nil {
+		c.ReplicaCounts[brokerID]--
+	}
+}
+
+// move moves a replica of a partition from the use of one broker to another.
+func (c *ClusterUse) move(partition int, fromBrokerID int32, toBrokerID int32) {
+	c.remove(partition, fromBrokerID)
+	c.add(partition, toBrokerID)
+}
+
+// compareDiskUse compares the disk use of two brokers, returning 0 if disk use is not set.
+func (c *ClusterUse) compareDiskUse(a int32, b int32) int {
+	if c == nil || c.DiskUse == nil {
+		return 0
+	}
+	return cmp.Compare(c.DiskUse[a], c.DiskUse[b])
+}
+
+// withoutPartitionSizes returns the cluster use for partitions that hold no data.
+func (c *ClusterUse) withoutPartitionSizes() *ClusterUse {
+	if c == nil {
+		return nil
+	}
+	return &ClusterUse{
+		ReplicaCounts: c.ReplicaCounts,
+		DiskUse:       c.DiskUse,
+	}
+}
+
 // AlterReplicationFactor alters the replication factor and returns the new assignments.
 func AlterReplicationFactor(
 	assignments [][]int32,
 	targetRepFactor int,
-	clusterReplicaCounts map[int32]int,
+	clusterUse *ClusterUse,
 	brokers []int32,
 ) [][]int32 {
 	currentRepFactor := len(assignments[0])
@@ -24,7 +89,7 @@ func AlterReplicationFactor(
 			assignments,
 			targetRepFactor,
 			replicaCounts,
-			clusterReplicaCounts,
+			clusterUse,
 		)
 	case targetRepFactor > currentRepFactor:
 		return increaseReplicationFactor(
@@ -32,7 +97,7 @@ func AlterReplicationFactor(
 			targetRepFactor,
 			leaderCounts,
 			replicaCounts,
-			clusterReplicaCounts,
+			clusterUse,
 			brokers,
 		)
 	default:
@@ -44,25 +109,28 @@ func decreaseReplicationFactor(
 	assignments [][]int32,
 	targetRepFactor int,
 	replicaCounts map[int32]int,
-	clusterReplicaCounts map[int32]int,
+	clusterUse *ClusterUse,
 ) [][]int32 {
 	// Find the broker with the most replicas of any partition.
 	selectBrokerToRemove := func(
 		replicas []int32,
 		brokerCounts map[int32]int,
-		clusterReplicaCounts map[int32]int,
+		clusterUse *ClusterUse,
 	) int32 {
 		// Create a copy to prevent the original slice being sorted.
 		sortedBrokers := append([]int32{}, replicas...)
-		if clusterReplicaCounts != nil {
-			// Sort based on broker frequency in the topic, break ties with broker frequency
-			// in the cluster, and finally break ties with index.
+		if clusterUse != nil {
+			// Sort based on broker frequency in the topic, break ties with disk use in the cluster if set,
+			// then broker use in the cluster, and finally break ties with index.
 			sort.Slice(sortedBrokers, func(i, j int) bool {
-				return brokerCounts[sortedBrokers[i]] < brokerCounts[sortedBrokers[j]] ||
-					(brokerCounts[sortedBrokers[i]] == brokerCounts[sortedBrokers[j]] &&
-						clusterReplicaCounts[sortedBrokers[i]] < clusterReplicaCounts[sortedBrokers[j]]) ||
-					(brokerCounts[sortedBrokers[i]] == brokerCounts[sortedBrokers[j]] &&
-						clusterReplicaCounts[sortedBrokers[i]] == clusterReplicaCounts[sortedBrokers[j]] && i < j)
+				if brokerCounts[sortedBrokers[i]] != brokerCounts[sortedBrokers[j]] {
+					return brokerCounts[sortedBrokers[i]] < brokerCounts[sortedBrokers[j]]
+				}
+				if c := clusterUse.compareDiskUse(sortedBrokers[i], sortedBrokers[j]); c != 0 {
+					return c < 0
+				}
+				return clusterUse.ReplicaCounts[sortedBrokers[i]] < clusterUse.ReplicaCounts[sortedBrokers[j]] ||
+					(clusterUse.ReplicaCounts[sortedBrokers[i]] == clusterUse.ReplicaCounts[sortedBrokers[j]] && i < j)
 			})
 		} else {
 			// Sort based on broker frequency in the topic, breaking ties with index.
@@ -79,7 +147,7 @@ func decreaseReplicationFactor(
 	for len(newAssignments[0]) > targetRepFactor {
 		for partition, replicas := range newAssignments {
 			// Select the broker ID to remove.
-			selectedBrokerID := selectBrokerToRemove(replicas, replicaCounts, clusterReplicaCounts)
+			selectedBrokerID := selectBrokerToRemove(replicas, replicaCounts, clusterUse)
 
 			// Create the modified replica set of broker IDs.
 			modifiedReplicas := make([]int32, len(replicas)-1)
@@ -94,9 +162,7 @@ func decreaseReplicationFactor(
 
 			newAssignments[partition] = modifiedReplicas
 			replicaCounts[selectedBrokerID]--
-			if clusterReplicaCounts != nil {
-				clusterReplicaCounts[selectedBrokerID]--
-			}
+			clusterUse.remove(partition, selectedBrokerID)
 		}
 	}
 
@@ -108,7 +174,7 @@ func increaseReplicationFactor(
 	targetRepFactor int,
 	leaderCounts map[int32]int,
 	replicaCounts map[int32]int,
-	clusterReplicaCounts map[int32]int,
+	clusterUse *ClusterUse,
 	brokers []int32,
 ) [][]int32 {
 	newAssignments := Copy(assignments)
@@ -140,7 +206,7 @@ func increaseReplicationFactor(
 			}
 
 			// Select the broker ID to add.
-			selectedBrokerID := selectBroker(unusedBrokers, brokerCounts, clusterReplicaCounts, lastUsedBroker)
+			selectedBrokerID := selectBroker(unusedBrokers, brokerCounts, clusterUse, lastUsedBroker)
 
 			// Create the modified replica set of broker IDs.
 			modifiedReplicas := make([]int32, len(replicas)+1)
@@ -152,9 +218,7 @@ func increaseReplicationFactor(
 			if isLeader {
 				leaderCounts[selectedBrokerID]++
 			}
-			if clusterReplicaCounts != nil {
-				clusterReplicaCounts[selectedBrokerID]++
-			}
+			clusterUse.add(partition, selectedBrokerID)
 		}
 	}
 
@@ -166,7 +230,7 @@ func AddPartitions(
 	assignments [][]int32,
 	targetPartitions int,
 	targetRepFactor int,
-	clusterReplicaCounts map[int32]int,
+	clusterUse *ClusterUse,
 	brokers []int32,
 ) [][]int32 {
 	partitionsToAdd := targetPartitions - len(assignments)
@@ -179,7 +243,8 @@ func AddPartitions(
 		targetRepFactor,
 		leaderCounts,
 		replicaCounts,
-		clusterReplicaCounts,
+		// New partitions hold no data.
+		clusterUse.withoutPartitionSizes(),
 		brokers,
 	)
 
@@ -191,7 +256,7 @@ func SyncRackConstraints(
 	assignments [][]int32,
 	rackConstraints [][]string,
 	brokersByRack map[string][]int32,
-	clusterReplicaCounts map[int32]int,
+	clusterUse *ClusterUse,
 ) [][]int32 {
 	// Modify assignments by the target replication factor.
 	targetRepFactor := len(rackConstraints[0])
@@ -238,7 +303,7 @@ func SyncRackConstraints(
 				}
 
 				// Select the broker ID to add.
-				selectedBrokerID := selectBroker(unusedRackBrokers, brokerCounts, clusterReplicaCounts, lastUsedBroker)
+				selectedBrokerID := selectBroker(unusedRackBrokers, brokerCounts, clusterUse, lastUsedBroker)
 
 				// Replace the broker.
 				newAssignments[partition][replica] = selectedBrokerID
@@ -248,10 +313,7 @@ func SyncRackConstraints(
 			if isLeader {
 				leaderCounts[newAssignments[partition][replica]]++
 			}
-			if clusterReplicaCounts != nil {
-				clusterReplicaCounts[currentBrokerID]--
-				clusterReplicaCounts[newAssignments[partition][replica]]++
-			}
+			clusterUse.move(partition, currentBrokerID, newAssignments[partition][replica])
 		}
 	}
 
@@ -261,7 +323,7 @@ func SyncRackConstraints(
 // Rebalance checks partition assignments are balanced, updating if necessary.
 func Rebalance(
 	assignments [][]int32,
-	clusterReplicaCounts map[int32]int,
+	clusterUse *ClusterUse,
 	brokers []int32,
 ) [][]int32 {
 	leaderCounts := make(map[int32]int)
@@ -301,7 +363,7 @@ func Rebalance(
 			}
 
 			// Select the broker ID to add.
-			selectedBrokerID := selectBroker(brokerPool, brokerCounts, clusterReplicaCounts, lastUsedBroker)
+			selectedBrokerID := selectBroker(brokerPool, brokerCounts, clusterUse, lastUsedBroker)
 
 			// Replace if the selected broker's count is at least 1 less than the current broker's count.
 			// OR if the current follower replica is now the same as the leader of the partition.
@@ -316,10 +378,7 @@ func Rebalance(
 			if isLeader {
 				leaderCounts[newAssignments[partition][replica]]++
 			}
-			if clusterReplicaCounts != nil {
-				clusterReplicaCounts[currentBrokerID]--
-				clusterReplicaCounts[newAssignments[partition][replica]]++
-			}
+			clusterUse.move(partition, currentBrokerID, newAssignments[partition][replica])
 		}
 	}
 
@@ -330,7 +389,7 @@ func Rebalance(
 func RebalanceWithRackConstraints(
 	assignments [][]int32,
 	rackConstraints [][]string,
-	clusterReplicaCounts map[int32]int,
+	clusterUse *ClusterUse,
 	brokersByRack map[string][]int32,
 ) [][]int32 {
 	leaderCounts := make(map[int32]int)
@@ -371,7 +430,7 @@ func RebalanceWithRackConstraints(
 			}
 
 			// Select the broker ID to add.
-			selectedBrokerID := selectBroker(brokerPool, brokerCounts, clusterReplicaCounts, lastUsedBroker)
+			selectedBrokerID := selectBroker(brokerPool, brokerCounts, clusterUse, lastUsedBroker)
 
 			// Replace if the selected broker's count is at least 1 less than the current broker's count.
 			// OR if the current follower replica is now the same as the leader of the partition.
@@ -386,16 +445,279 @@ func RebalanceWithRackConstraints(
 			if isLeader {
 				leaderCounts[newAssignments[partition][replica]]++
 			}
-			if clusterReplicaCounts != nil {
-				clusterReplicaCounts[currentBrokerID]--
-				clusterReplicaCounts[newAssignments[partition][replica]]++
+			clusterUse.move(partition, currentBrokerID, newAssignments[partition][replica])
+		}
+	}
+
+	return newAssignments
+}
+
+// RemoveBroker replaces all replicas of a broker and returns the new assignments.
+// Replacement brokers are selected from the same rack as the removed broker where possible,
+// falling back to any broker not already assigned to the partition.
+func RemoveBroker(
+	assignments [][]int32,
+	brokerID int32,
+	clusterUse *ClusterUse,
+	brokers []int32,
+	racksByBroker map[int32]string,
+) [][]int32 {
+	leaderCounts := leaderCounts(assignments)
+	replicaCounts := replicaCounts(assignments)
+
+	// Exclude the removed broker from selection.
+	candidateBrokers := i32.Diff(brokers, []int32{brokerID})
+
+	// Build a list of candidate brokers in the same rack as the removed broker.
+	var rackBrokers []int32
+	if rack := racksByBroker[brokerID]; len(rack) > 0 {
+		for _, b := range candidateBrokers {
+			if racksByBroker[b] == rack {
+				rackBrokers = append(rackBrokers, b)
+			}
+		}
+	}
+
+	newAssignments := Copy(assignments)
+	for partition, replicas := range newAssignments {
+		for replica, currentBrokerID := range replicas {
+			if currentBrokerID != brokerID {
+				continue
+			}
+			isLeader := replica == 0
+
+			// Find unused broker IDs for this partition, preferring the rack of the removed broker.
+			brokerPool := i32.Diff(rackBrokers, replicas)
+			if len(brokerPool) == 0 {
+				brokerPool = i32.Diff(candidateBrokers, replicas)
+			}
+
+			// Skip if no replacements are possible.
+			if len(brokerPool) == 0 {
+				continue
+			}
+
+			// Determine the last used broker ID for this partition.
+			var lastUsedBroker int32
+			if !isLeader {
+				lastUsedBroker = replicas[replica-1]
+			}
+			// If there are no unused brokers with an ID greater than the last used then reset to zero.
+			// This will cause round-robin placement to begin a new cycle.
+			if i32.Max(brokerPool) <= lastUsedBroker {
+				lastUsedBroker = 0
+			}
+
+			// If the chosen broker will be the preferred leader we use leader counts to make sure
+			// partition leaders are balanced across brokers.
+			brokerCounts := replicaCounts
+			if isLeader {
+				brokerCounts = leaderCounts
+			}
+
+			// Select the broker ID to add.
+			selectedBrokerID := selectBroker(brokerPool, brokerCounts, clusterUse, lastUsedBroker)
+
+			// Replace the broker.
+			newAssignments[partition][replica] = selectedBrokerID
+
+			// Update counts.
+			replicaCounts[currentBrokerID]--
+			replicaCounts[selectedBrokerID]++
+			if isLeader {
+				leaderCounts[currentBrokerID]--
+				leaderCounts[selectedBrokerID]++
 			}
+			clusterUse.move(partition, currentBrokerID, selectedBrokerID)
 		}
 	}
 
 	return newAssignments
 }
 
+// RackSpreadConstraints returns rack constraints that spread the replicas of each partition across as many
+// distinct racks as possible. Racks of existing replicas are retained unless fixViolations is set and
+// retaining the rack would violate the spread.
+func RackSpreadConstraints(
+	assignments [][]int32,
+	targetPartitions int,
+	targetRepFactor int,
+	brokersByRack map[string][]int32,
+	fixViolations bool,
+) [][]string {
+	racks := sortedRacks(brokersByRack)
+	racksByBroker := racksByBroker(brokersByRack)
+	maxRackReplicas := (targetRepFactor + len(racks) - 1) / len(racks)
+
+	// The counts start from zero to make the constraints more predictable.
+	leaderCounts := make(map[string]int)
+	replicaCounts := make(map[string]int)
+
+	rackConstraints := make([][]string, targetPartitions)
+	for partition := range rackConstraints {
+		constraints := make([]string, targetRepFactor)
+		partitionCounts := make(map[string]int)
+
+		// Retain the racks of existing replicas.
+		if partition < len(assignments) {
+			for replica, brokerID := range assignments[partition] {
+				if replica >= targetRepFactor {
+					break
+				}
+				rack, ok := racksByBroker[brokerID]
+				if !ok {
+					continue
+				}
+				if fixViolations && partitionCounts[rack] >= maxRackReplicas {
+					continue
+				}
+				constraints[replica] = rack
+				partitionCounts[rack]++
+			}
+		}
+
+		// Populate missing racks.
+		for replica := range constraints {
+			if len(constraints[replica]) > 0 {
+				continue
+			}
+
+			// If the chosen rack will be the preferred leader we use leader counts to make sure
+			// partition leaders are balanced across racks.
+			rackCounts := replicaCounts
+			if replica == 0 {
+				rackCounts = leaderCounts
+			}
+
+			rack := selectRack(racks, brokersByRack, partitionCounts, rackCounts, partition+replica)
+			constraints[replica] = rack
+			partitionCounts[rack]++
+		}
+
+		// Update counts.
+		leaderCounts[constraints[0]]++
+		for _, rack := range constraints {
+			replicaCounts[rack]++
+		}
+
+		rackConstraints[partition] = constraints
+	}
+
+	return rackConstraints
+}
+
+// RackSpreadViolations returns the partitions with replicas not spread across as many distinct racks as possible.
+func RackSpreadViolations(
+	assignments [][]int32,
+	brokersByRack map[string][]int32,
+) []int32 {
+	racksByBroker := racksByBroker(brokersByRack)
+
+	var violations []int32
+	for partition, replicas := range assignments {
+		maxRackReplicas := (len(replicas) + len(brokersByRack) - 1) / len(brokersByRack)
+		partitionCounts := make(map[string]int)
+		for _, brokerID := range replicas {
+			rack, ok := racksByBroker[brokerID]
+			if !ok {
+				// Replicas on brokers without a rack are a violation.
+				partitionCounts[""] = maxRackReplicas + 1
+				break
+			}
+			partitionCounts[rack]++
+		}
+		for _, count := range partitionCounts {
+			if count > maxRackReplicas {
+				violations = append(violations, int32(partition))
+				break
+			}
+		}
+	}
+
+	return violations
+}
+
+// BalanceLeaders reorders replicas to evenly distribute preferred leaders across brokers and racks,
+// and returns the new assignments. Replicas are only reordered, so no data is moved.
+// The preferred leader of a partition can only be changed to one of its eligible leaders, such as in-sync
+// replicas. Nil eligible leaders for a partition allows any of its replicas.
+// Fixed leader counts are the leaders per broker of partitions that are not being balanced.
+func BalanceLeaders(
+	assignments [][]int32,
+	eligibleLeaders [][]int32,
+	fixedLeaderCounts map[int32]int,
+	racksByBroker map[int32]string,
+) [][]int32 {
+	leaderCounts := make(map[int32]int)
+	rackLeaderCounts := make(map[string]int)
+	addLeader := func(brokerID int32, n int) {
+		leaderCounts[brokerID] += n
+		if rack := racksByBroker[brokerID]; len(rack) > 0 {
+			rackLeaderCounts[rack] += n
+		}
+	}
+	for brokerID, count := range fixedLeaderCounts {
+		addLeader(brokerID, count)
+	}
+
+	leaders := make([]int32, len(assignments))
+	for partition, replicas := range assignments {
+		leaders[partition] = replicas[0]
+		addLeader(replicas[0], 1)
+	}
+
+	// Moving leadership from broker a to b improves the balance if b has fewer leaders after the move than
+	// a had before it. Ties between brokers are broken by improving the balance of leaders across racks.
+	improves := func(a int32, b int32) bool {
+		if leaderCounts[b]+1 < leaderCounts[a] {
+			return true
+		}
+		rackA, rackB := racksByBroker[a], racksByBroker[b]
+		return leaderCounts[b]+1 == leaderCounts[a] &&
+			len(rackA) > 0 && len(rackB) > 0 && rackA != rackB &&
+			rackLeaderCounts[rackB]+1 < rackLeaderCounts[rackA]
+	}
+
+	// Each leadership move strictly improves the balance, so the loop terminates.
+	for changed := true; changed; {
+		changed = false
+		for partition, replicas := range assignments {
+			candidates := replicas
+			if eligibleLeaders != nil && eligibleLeaders[partition] != nil {
+				candidates = eligibleLeaders[partition]
+			}
+
+			current := leaders[partition]
+			selected := current
+			for _, brokerID := range candidates {
+				if brokerID == current || !i32.Contains(brokerID, replicas) || !improves(current, brokerID) {
+					continue
+				}
+				if selected == current ||
+					leaderCounts[brokerID] < leaderCounts[selected] ||
+					(leaderCounts[brokerID] == leaderCounts[selected] &&
+						rackLeaderCounts[racksByBroker[brokerID]] < rackLeaderCounts[racksByBroker[selected]]) {
+					selected = brokerID
+				}
+			}
+
+			if selected != current {
+				addLeader(current, -1)
+				addLeader(selected, 1)
+				leaders[partition] = selected
+				changed = true
+			}
+		}
+	}
+
+	newAssignments := make([][]int32, len(assignments))
+	for partition, replicas := range assignments {
+		newAssignments[partition] = append([]int32{leaders[partition]}, i32.Diff(replicas, []int32{leaders[partition]})...)
+	}
+
+	return newAssignments
+}
+
 // Copy makes a copy of partition assignments.
 func Copy(assignments [][]int32) [][]int32 {
 	c := make([][]int32, len(assignments))
@@ -426,11 +748,11 @@ func leaderCounts(assignments [][]int32) map[int32]int {
 func selectBroker(
 	unusedBrokers []int32,
 	brokerCounts map[int32]int,
-	clusterReplicaCounts map[int32]int,
+	clusterUse *ClusterUse,
 	lastUsedBroker int32,
 ) int32 {
-	if clusterReplicaCounts != nil {
-		return selectByTopicClusterUse(unusedBrokers, brokerCounts, clusterReplicaCounts, lastUsedBroker)
+	if clusterUse != nil {
+		return selectByTopicClusterUse(unusedBrokers, brokerCounts, clusterUse, lastUsedBroker)
 	}
 	return selectByTopicUse(unusedBrokers, brokerCounts, lastUsedBroker)
 }
@@ -462,23 +784,82 @@ func selectByTopicUse(unusedBrokers []int32, brokerCounts map[int32]int, lastUse
 func selectByTopicClusterUse(
 	unusedBrokers []int32,
 	brokerCounts map[int32]int,
-	clusterReplicaCounts map[int32]int,
+	clusterUse *ClusterUse,
 	lastUsedBroker int32,
 ) int32 {
 	sort.Slice(unusedBrokers, func(i, j int) bool {
-		// Sort based on broker frequency in the topic, break ties with broker frequency
-		// in the cluster, and finally break ties with round-robin broker ID.
-		return brokerCounts[unusedBrokers[i]] < brokerCounts[unusedBrokers[j]] ||
-			(brokerCounts[unusedBrokers[i]] == brokerCounts[unusedBrokers[j]] &&
-				clusterReplicaCounts[unusedBrokers[i]] < clusterReplicaCounts[unusedBrokers[j]]) ||
-			(brokerCounts[unusedBrokers[i]] == brokerCounts[unusedBrokers[j]] &&
-				clusterReplicaCounts[unusedBrokers[i]] == clusterReplicaCounts[unusedBrokers[j]] &&
+		// Sort based on broker frequency in the topic, break ties with disk use in the cluster if set,
+		// then broker frequency in the cluster, and finally break ties with round-robin broker ID.
+		if brokerCounts[unusedBrokers[i]] != brokerCounts[unusedBrokers[j]] {
+			return brokerCounts[unusedBrokers[i]] < brokerCounts[unusedBrokers[j]]
+		}
+		if c := clusterUse.compareDiskUse(unusedBrokers[i], unusedBrokers[j]); c != 0 {
+			return c < 0
+		}
+		return clusterUse.ReplicaCounts[unusedBrokers[i]] < clusterUse.ReplicaCounts[unusedBrokers[j]] ||
+			(clusterUse.ReplicaCounts[unusedBrokers[i]] == clusterUse.ReplicaCounts[unusedBrokers[j]] &&
 				unusedBrokers[i] > lastUsedBroker && unusedBrokers[j] > lastUsedBroker &&
 				unusedBrokers[i] < unusedBrokers[j]) ||
-			(brokerCounts[unusedBrokers[i]] == brokerCounts[unusedBrokers[j]] &&
-				clusterReplicaCounts[unusedBrokers[i]] == clusterReplicaCounts[unusedBrokers[j]] &&
+			(clusterUse.ReplicaCounts[unusedBrokers[i]] == clusterUse.ReplicaCounts[unusedBrokers[j]] &&
 				(unusedBrokers[i] < lastUsedBroker || unusedBrokers[j] < lastUsedBroker) &&
 				unusedBrokers[i]-lastUsedBroker > unusedBrokers[j]-lastUsedBroker)
 	})
 	return unusedBrokers[0]
 }
+
+func sortedRacks(brokersByRack map[string][]int32) []string {
+	racks := make([]string, 0, len(brokersByRack))
+	for rack := range brokersByRack {
+		racks = append(racks, rack)
+	}
+	sort.Strings(racks)
+	return racks
+}
+
+func racksByBroker(brokersByRack map[string][]int32) map[int32]string {
+	racksByBroker := make(map[int32]string)
+	for rack, brokers := range brokersByRack {
+		for _, brokerID := range brokers {
+			racksByBroker[brokerID] = rack
+		}
+	}
+	return racksByBroker
+}
+
+func selectRack(
+	racks []string,
+	brokersByRack map[string][]int32,
+	partitionCounts map[string]int,
+	rackCounts map[string]int,
+	offset int,
+) string {
+	// Exclude racks without unused brokers for this partition.
+	var available []string
+	for _, rack := range racks {
+		if partitionCounts[rack] < len(brokersByRack[rack]) {
+			available = append(available, rack)
+		}
+	}
+
+	// Round-robin position of each rack, starting from the offset.
+	position := func(rack string) int {
+		for i, r := range racks {
+			if r == rack {
+				return (i - offset%len(racks) + len(racks)) % len(racks)
+			}
+		}
+		return 0
+	}
+
+	sort.Slice(available, func(i, j int) bool {
+		// Sort based on rack frequency in the partition, break ties with rack frequency
+		// in the topic, and finally break ties with round-robin rack position.
+		return partitionCounts[available[i]] < partitionCounts[available[j]] ||
+			(partitionCounts[available[i]] == partitionCounts[available[j]] &&
+				rackCounts[available[i]] < rackCounts[available[j]]) ||
+			(partitionCounts[available[i]] == partitionCounts[available[j]] &&
+				rackCounts[available[i]] == rackCounts[available[j]] &&
+				position(available[i]) < position(available[j]))
+	})
+	return available[0]
+}
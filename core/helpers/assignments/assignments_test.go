@@ -10,7 +10,7 @@ func TestAlterReplicationFactor(t *testing.T) {
 	type args struct {
 		assignments             [][]int32
 		targetReplicationFactor int
-		clusterReplicaCounts    map[int32]int
+		clusterUse              *ClusterUse
 		brokers                 []int32
 	}
 	tests := []struct {
@@ -116,11 +116,11 @@ func TestAlterReplicationFactor(t *testing.T) {
 					{3, 1, 2},
 				},
 				targetReplicationFactor: 2,
-				clusterReplicaCounts: map[int32]int{
+				clusterUse: &ClusterUse{ReplicaCounts: map[int32]int{
 					1: 0,
 					2: 1,
 					3: 0,
-				},
+				}},
 				brokers: []int32{1, 2, 3},
 			},
 			want: [][]int32{
@@ -206,11 +206,11 @@ func TestAlterReplicationFactor(t *testing.T) {
 					{3},
 				},
 				targetReplicationFactor: 2,
-				clusterReplicaCounts: map[int32]int{
+				clusterUse: &ClusterUse{ReplicaCounts: map[int32]int{
 					1: 0,
 					2: 1,
 					3: 0,
-				},
+				}},
 				brokers: []int32{1, 2, 3},
 			},
 			want: [][]int32{
@@ -219,13 +219,102 @@ func TestAlterReplicationFactor(t *testing.T) {
 				{3, 2},
 			},
 		},
+		{
+			name: "Tests increasing the replication factor breaking ties with disk use",
+			args: args{
+				assignments: [][]int32{
+					{1},
+					{2},
+				},
+				targetReplicationFactor: 2,
+				clusterUse: &ClusterUse{
+					DiskUse: map[int32]int64{
+						1: 100,
+						2: 100,
+						3: 10000,
+						4: 100,
+					},
+					PartitionSizes: map[int32]int64{
+						0: 50,
+						1: 50,
+					},
+				},
+				brokers: []int32{1, 2, 3, 4},
+			},
+			want: [][]int32{
+				{1, 4},
+				{2, 3},
+			},
+		},
+		{
+			name: "Tests increasing the replication factor adding partition sizes to disk use",
+			args: args{
+				assignments: [][]int32{
+					{1},
+					{1},
+					{1},
+					{1},
+				},
+				targetReplicationFactor: 2,
+				clusterUse: &ClusterUse{
+					DiskUse: map[int32]int64{
+						1: 0,
+						2: 100,
+						3: 150,
+					},
+					PartitionSizes: map[int32]int64{
+						0: 1000,
+						1: 10,
+						2: 10,
+						3: 10,
+					},
+				},
+				brokers: []int32{1, 2, 3},
+			},
+			want: [][]int32{
+				{1, 2},
+				{1, 3},
+				{1, 3},
+				{1, 2},
+			},
+		},
+		{
+			name: "Tests increasing the replication factor spreading replicas when partitions hold no data",
+			args: args{
+				assignments: [][]int32{
+					{1},
+					{2},
+					{3},
+					{1},
+					{2},
+					{3},
+				},
+				targetReplicationFactor: 2,
+				clusterUse: &ClusterUse{
+					DiskUse: map[int32]int64{
+						1: 0,
+						2: 0,
+						3: 0,
+					},
+				},
+				brokers: []int32{1, 2, 3},
+			},
+			want: [][]int32{
+				{1, 2},
+				{2, 3},
+				{3, 1},
+				{1, 2},
+				{2, 3},
+				{3, 1},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if got := AlterReplicationFactor(
 				tt.args.assignments,
 				tt.args.targetReplicationFactor,
-				tt.args.clusterReplicaCounts,
+				tt.args.clusterUse,
 				tt.args.brokers,
 			); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("AlterReplicationFactor() = %v, want %v", got, tt.want)
@@ -236,11 +325,11 @@ func TestAlterReplicationFactor(t *testing.T) {
 
 func TestAddPartitions(t *testing.T) {
 	type args struct {
-		assignments          [][]int32
-		targetPartitions     int
-		targetRepFactor      int
-		clusterReplicaCounts map[int32]int
-		brokers              []int32
+		assignments      [][]int32
+		targetPartitions int
+		targetRepFactor  int
+		clusterUse       *ClusterUse
+		brokers          []int32
 	}
 	tests := []struct {
 		name string
@@ -356,17 +445,96 @@ func TestAddPartitions(t *testing.T) {
 				},
 				targetPartitions: 2,
 				targetRepFactor:  3,
-				clusterReplicaCounts: map[int32]int{
+				clusterUse: &ClusterUse{ReplicaCounts: map[int32]int{
 					1: 0,
 					2: 1,
 					3: 0,
-				},
+				}},
 				brokers: []int32{1, 2, 3},
 			},
 			want: [][]int32{
 				{3, 1, 2},
 			},
 		},
+		{
+			name: "Tests creating partitions with cluster disk use",
+			args: args{
+				assignments:      [][]int32{},
+				targetPartitions: 6,
+				targetRepFactor:  1,
+				clusterUse: &ClusterUse{
+					DiskUse: map[int32]int64{
+						1: 0,
+						2: 500,
+						3: 1000,
+					},
+				},
+				brokers: []int32{1, 2, 3},
+			},
+			want: [][]int32{
+				{1},
+				{2},
+				{3},
+				{1},
+				{2},
+				{3},
+			},
+		},
+		{
+			name: "Tests creating replicated partitions with cluster disk use",
+			args: args{
+				assignments:      [][]int32{},
+				targetPartitions: 6,
+				targetRepFactor:  2,
+				clusterUse: &ClusterUse{
+					DiskUse: map[int32]int64{
+						1: 0,
+						2: 500,
+						3: 1000,
+					},
+				},
+				brokers: []int32{1, 2, 3},
+			},
+			want: [][]int32{
+				{1, 2},
+				{2, 1},
+				{3, 1},
+				{1, 3},
+				{2, 3},
+				{3, 2},
+			},
+		},
+		{
+			name: "Tests adding partitions with cluster disk use",
+			args: args{
+				assignments: [][]int32{
+					{1, 2},
+					{2, 3},
+					{3, 1},
+				},
+				targetPartitions: 6,
+				targetRepFactor:  2,
+				clusterUse: &ClusterUse{
+					DiskUse: map[int32]int64{
+						1: 1000,
+						2: 0,
+						3: 500,
+						4: 0,
+					},
+					PartitionSizes: map[int32]int64{
+						0: 100,
+						1: 100,
+						2: 100,
+					},
+				},
+				brokers: []int32{1, 2, 3, 4},
+			},
+			want: [][]int32{
+				{4, 3},
+				{2, 4},
+				{4, 1},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -374,7 +542,7 @@ func TestAddPartitions(t *testing.T) {
 				tt.args.assignments,
 				tt.args.targetPartitions,
 				tt.args.targetRepFactor,
-				tt.args.clusterReplicaCounts,
+				tt.args.clusterUse,
 				tt.args.brokers,
 			); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("AddPartitions() = %v, want %v", got, tt.want)
@@ -385,10 +553,10 @@ func TestAddPartitions(t *testing.T) {
 
 func TestSyncRackAssignments(t *testing.T) {
 	type args struct {
-		assignments          [][]int32
-		rackConstraints      [][]string
-		brokersByRack        map[string][]int32
-		clusterReplicaCounts map[int32]int
+		assignments     [][]int32
+		rackConstraints [][]string
+		brokersByRack   map[string][]int32
+		clusterUse      *ClusterUse
 	}
 	tests := []struct {
 		name string
@@ -612,14 +780,14 @@ func TestSyncRackAssignments(t *testing.T) {
 					"zone-a": {1, 2, 3},
 					"zone-b": {4, 5, 6},
 				},
-				clusterReplicaCounts: map[int32]int{
+				clusterUse: &ClusterUse{ReplicaCounts: map[int32]int{
 					1: 1,
 					2: 0,
 					3: 0,
 					4: 0,
 					5: 0,
 					6: 0,
-				},
+				}},
 			},
 			want: [][]int32{
 				{1, 4, 2},
@@ -659,7 +827,7 @@ func TestSyncRackAssignments(t *testing.T) {
 				tt.args.assignments,
 				tt.args.rackConstraints,
 				tt.args.brokersByRack,
-				tt.args.clusterReplicaCounts,
+				tt.args.clusterUse,
 			); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("SyncRackAssignments() = %v, want %v", got, tt.want)
 			}
@@ -669,9 +837,9 @@ func TestSyncRackAssignments(t *testing.T) {
 
 func TestRebalance(t *testing.T) {
 	type args struct {
-		assignments          [][]int32
-		clusterReplicaCounts map[int32]int
-		brokers              []int32
+		assignments [][]int32
+		clusterUse  *ClusterUse
+		brokers     []int32
 	}
 	tests := []struct {
 		name string
@@ -686,8 +854,8 @@ func TestRebalance(t *testing.T) {
 					{2, 3, 1},
 					{3, 1, 2},
 				},
-				clusterReplicaCounts: nil,
-				brokers:              []int32{1, 2, 3},
+				clusterUse: nil,
+				brokers:    []int32{1, 2, 3},
 			},
 			want: [][]int32{
 				{1, 2, 3},
@@ -703,8 +871,8 @@ func TestRebalance(t *testing.T) {
 					{2, 3, 1},
 					{2, 1, 3},
 				},
-				clusterReplicaCounts: nil,
-				brokers:              []int32{1, 2, 3},
+				clusterUse: nil,
+				brokers:    []int32{1, 2, 3},
 			},
 			want: [][]int32{
 				{1, 2, 3},
@@ -720,8 +888,8 @@ func TestRebalance(t *testing.T) {
 					{2, 3, 1},
 					{3, 1, 2},
 				},
-				clusterReplicaCounts: nil,
-				brokers:              []int32{1, 2, 3, 4},
+				clusterUse: nil,
+				brokers:    []int32{1, 2, 3, 4},
 			},
 			want: [][]int32{
 				{1, 2, 3},
@@ -737,8 +905,8 @@ func TestRebalance(t *testing.T) {
 					{1, 3, 2},
 					{3, 1, 2},
 				},
-				clusterReplicaCounts: nil,
-				brokers:              []int32{1, 2, 3, 4},
+				clusterUse: nil,
+				brokers:    []int32{1, 2, 3, 4},
 			},
 			want: [][]int32{
 				{1, 2, 3},
@@ -754,12 +922,12 @@ func TestRebalance(t *testing.T) {
 					{1, 3, 2},
 					{3, 1, 2},
 				},
-				clusterReplicaCounts: map[int32]int{
+				clusterUse: &ClusterUse{ReplicaCounts: map[int32]int{
 					1: 0,
 					2: 1,
 					3: 0,
 					4: 0,
-				},
+				}},
 				brokers: []int32{1, 2, 3, 4},
 			},
 			want: [][]int32{
@@ -776,8 +944,8 @@ func TestRebalance(t *testing.T) {
 					{2, 4, 1},
 					{3, 4, 2},
 				},
-				clusterReplicaCounts: nil,
-				brokers:              []int32{1, 2, 3, 4},
+				clusterUse: nil,
+				brokers:    []int32{1, 2, 3, 4},
 			},
 			want: [][]int32{
 				{1, 2, 3},
@@ -788,7 +956,7 @@ func TestRebalance(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := Rebalance(tt.args.assignments, tt.args.clusterReplicaCounts, tt.args.brokers); !reflect.DeepEqual(got, tt.want) {
+			if got := Rebalance(tt.args.assignments, tt.args.clusterUse, tt.args.brokers); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Rebalance() = %v, want %v", got, tt.want)
 			}
 		})
@@ -797,10 +965,10 @@ func TestRebalance(t *testing.T) {
 
 func TestRebalanceWithRackConstraints(t *testing.T) {
 	type args struct {
-		assignments          [][]int32
-		rackConstraints      [][]string
-		clusterReplicaCounts map[int32]int
-		brokersByRack        map[string][]int32
+		assignments     [][]int32
+		rackConstraints [][]string
+		clusterUse      *ClusterUse
+		brokersByRack   map[string][]int32
 	}
 	tests := []struct {
 		name string
@@ -820,7 +988,7 @@ func TestRebalanceWithRackConstraints(t *testing.T) {
 					{"zone-b", "zone-b", "zone-a"},
 					{"zone-b", "zone-a", "zone-b"},
 				},
-				clusterReplicaCounts: nil,
+				clusterUse: nil,
 				brokersByRack: map[string][]int32{
 					"zone-a": {1},
 					"zone-b": {2, 3},
@@ -845,7 +1013,7 @@ func TestRebalanceWithRackConstraints(t *testing.T) {
 					{"zone-b", "zone-b", "zone-a"},
 					{"zone-b", "zone-a", "zone-b"},
 				},
-				clusterReplicaCounts: nil,
+				clusterUse: nil,
 				brokersByRack: map[string][]int32{
 					"zone-a": {1},
 					"zone-b": {2, 3},
@@ -870,7 +1038,7 @@ func TestRebalanceWithRackConstraints(t *testing.T) {
 					{"zone-b", "zone-c", "zone-a"},
 					{"zone-c", "zone-a", "zone-b"},
 				},
-				clusterReplicaCounts: nil,
+				clusterUse: nil,
 				brokersByRack: map[string][]int32{
 					"zone-a": {1, 4},
 					"zone-b": {2, 5},
@@ -902,7 +1070,7 @@ func TestRebalanceWithRackConstraints(t *testing.T) {
 					{"zone-b", "zone-c", "zone-a"},
 					{"zone-c", "zone-a", "zone-b"},
 				},
-				clusterReplicaCounts: nil,
+				clusterUse: nil,
 				brokersByRack: map[string][]int32{
 					"zone-a": {1, 4},
 					"zone-b": {2, 5},
@@ -933,7 +1101,7 @@ func TestRebalanceWithRackConstraints(t *testing.T) {
 					{"zone-c", "zone-a", "zone-b"},
 					{"zone-a", "zone-b", "zone-c"},
 				},
-				clusterReplicaCounts: map[int32]int{
+				clusterUse: &ClusterUse{ReplicaCounts: map[int32]int{
 					1: 0,
 					2: 0,
 					3: 0,
@@ -943,7 +1111,7 @@ func TestRebalanceWithRackConstraints(t *testing.T) {
 					7: 0,
 					8: 0,
 					9: 0,
-				},
+				}},
 				brokersByRack: map[string][]int32{
 					"zone-a": {1, 4, 7},
 					"zone-b": {2, 5, 8},
@@ -976,7 +1144,7 @@ func TestRebalanceWithRackConstraints(t *testing.T) {
 					{"zone-b", "zone-c", "zone-a"},
 					{"zone-c", "zone-a", "zone-b"},
 				},
-				clusterReplicaCounts: nil,
+				clusterUse: nil,
 				brokersByRack: map[string][]int32{
 					"zone-a": {1, 4},
 					"zone-b": {2, 5},
@@ -995,9 +1163,421 @@ func TestRebalanceWithRackConstraints(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := RebalanceWithRackConstraints(tt.args.assignments, tt.args.rackConstraints, tt.args.clusterReplicaCounts, tt.args.brokersByRack); !reflect.DeepEqual(got, tt.want) {
+			if got := RebalanceWithRackConstraints(tt.args.assignments, tt.args.rackConstraints, tt.args.clusterUse, tt.args.brokersByRack); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("RebalanceWithRackConstraints() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestRemoveBroker(t *testing.T) {
+	type args struct {
+		assignments   [][]int32
+		brokerID      int32
+		clusterUse    *ClusterUse
+		brokers       []int32
+		racksByBroker map[int32]string
+	}
+	tests := []struct {
+		name string
+		args args
+		want [][]int32
+	}{
+		{
+			name: "Tests broker not assigned",
+			args: args{
+				assignments: [][]int32{
+					{1, 2},
+					{2, 3},
+					{3, 1},
+				},
+				brokerID:      4,
+				clusterUse:    nil,
+				brokers:       []int32{1, 2, 3, 4},
+				racksByBroker: map[int32]string{},
+			},
+			want: [][]int32{
+				{1, 2},
+				{2, 3},
+				{3, 1},
+			},
+		},
+		{
+			name: "Tests removing a broker without racks",
+			args: args{
+				assignments: [][]int32{
+					{1, 2},
+					{2, 3},
+					{3, 1},
+				},
+				brokerID:      3,
+				clusterUse:    nil,
+				brokers:       []int32{1, 2, 3, 4},
+				racksByBroker: map[int32]string{},
+			},
+			want: [][]int32{
+				{1, 2},
+				{2, 4},
+				{4, 1},
+			},
+		},
+		{
+			name: "Tests removing a broker with cluster replica counts",
+			args: args{
+				assignments: [][]int32{
+					{1, 2},
+					{2, 1},
+				},
+				brokerID: 2,
+				clusterUse: &ClusterUse{ReplicaCounts: map[int32]int{
+					1: 2,
+					2: 2,
+					3: 5,
+					4: 1,
+				}},
+				brokers:       []int32{1, 2, 3, 4},
+				racksByBroker: map[int32]string{},
+			},
+			want: [][]int32{
+				{1, 4},
+				{4, 1},
+			},
+		},
+		{
+			name: "Tests removing a broker within the same rack",
+			args: args{
+				assignments: [][]int32{
+					{1, 2},
+					{2, 1},
+					{1, 2},
+				},
+				brokerID:   2,
+				clusterUse: nil,
+				brokers:    []int32{1, 2, 3, 4},
+				racksByBroker: map[int32]string{
+					1: "zone-a",
+					2: "zone-b",
+					3: "zone-a",
+					4: "zone-b",
+				},
+			},
+			want: [][]int32{
+				{1, 4},
+				{4, 1},
+				{1, 4},
+			},
+		},
+		{
+			name: "Tests removing the only broker in a rack",
+			args: args{
+				assignments: [][]int32{
+					{1, 2},
+					{2, 1},
+				},
+				brokerID:   2,
+				clusterUse: nil,
+				brokers:    []int32{1, 2, 3},
+				racksByBroker: map[int32]string{
+					1: "zone-a",
+					2: "zone-b",
+					3: "zone-a",
+				},
+			},
+			want: [][]int32{
+				{1, 3},
+				{3, 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RemoveBroker(tt.args.assignments, tt.args.brokerID, tt.args.clusterUse, tt.args.brokers, tt.args.racksByBroker); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RemoveBroker() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRackSpreadConstraints(t *testing.T) {
+	type args struct {
+		assignments      [][]int32
+		targetPartitions int
+		targetRepFactor  int
+		brokersByRack    map[string][]int32
+		fixViolations    bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want [][]string
+	}{
+		{
+			name: "Tests spreading new partitions across racks",
+			args: args{
+				assignments:      [][]int32{},
+				targetPartitions: 3,
+				targetRepFactor:  2,
+				brokersByRack: map[string][]int32{
+					"zone-a": {1},
+					"zone-b": {2},
+					"zone-c": {3},
+				},
+				fixViolations: false,
+			},
+			want: [][]string{
+				{"zone-a", "zone-b"},
+				{"zone-b", "zone-c"},
+				{"zone-c", "zone-a"},
+			},
+		},
+		{
+			name: "Tests retaining racks of existing replicas that violate the spread",
+			args: args{
+				assignments: [][]int32{
+					{1, 4},
+				},
+				targetPartitions: 1,
+				targetRepFactor:  2,
+				brokersByRack: map[string][]int32{
+					"zone-a": {1, 4},
+					"zone-b": {2},
+					"zone-c": {3},
+				},
+				fixViolations: false,
+			},
+			want: [][]string{
+				{"zone-a", "zone-a"},
+			},
+		},
+		{
+			name: "Tests fixing racks of existing replicas that violate the spread",
+			args: args{
+				assignments: [][]int32{
+					{1, 4},
+				},
+				targetPartitions: 1,
+				targetRepFactor:  2,
+				brokersByRack: map[string][]int32{
+					"zone-a": {1, 4},
+					"zone-b": {2},
+					"zone-c": {3},
+				},
+				fixViolations: true,
+			},
+			want: [][]string{
+				{"zone-a", "zone-b"},
+			},
+		},
+		{
+			name: "Tests increasing the replication factor and adding a partition",
+			args: args{
+				assignments: [][]int32{
+					{1},
+					{2},
+				},
+				targetPartitions: 3,
+				targetRepFactor:  2,
+				brokersByRack: map[string][]int32{
+					"zone-a": {1},
+					"zone-b": {2},
+					"zone-c": {3},
+				},
+				fixViolations: false,
+			},
+			want: [][]string{
+				{"zone-a", "zone-b"},
+				{"zone-b", "zone-c"},
+				{"zone-c", "zone-a"},
+			},
+		},
+		{
+			name: "Tests replication factor greater than the number of racks",
+			args: args{
+				assignments:      [][]int32{},
+				targetPartitions: 2,
+				targetRepFactor:  3,
+				brokersByRack: map[string][]int32{
+					"zone-a": {1, 3},
+					"zone-b": {2, 4},
+				},
+				fixViolations: false,
+			},
+			want: [][]string{
+				{"zone-a", "zone-b", "zone-a"},
+				{"zone-b", "zone-a", "zone-b"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RackSpreadConstraints(tt.args.assignments, tt.args.targetPartitions, tt.args.targetRepFactor, tt.args.brokersByRack, tt.args.fixViolations); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RackSpreadConstraints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRackSpreadViolations(t *testing.T) {
+	type args struct {
+		assignments   [][]int32
+		brokersByRack map[string][]int32
+	}
+	tests := []struct {
+		name string
+		args args
+		want []int32
+	}{
+		{
+			name: "Tests no violations",
+			args: args{
+				assignments: [][]int32{
+					{1, 2},
+					{2, 3},
+					{3, 1},
+				},
+				brokersByRack: map[string][]int32{
+					"zone-a": {1},
+					"zone-b": {2},
+					"zone-c": {3},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "Tests replicas in the same rack",
+			args: args{
+				assignments: [][]int32{
+					{1, 2},
+					{1, 4},
+					{3},
+				},
+				brokersByRack: map[string][]int32{
+					"zone-a": {1, 4},
+					"zone-b": {2},
+					"zone-c": {3},
+				},
+			},
+			want: []int32{1},
+		},
+		{
+			name: "Tests replicas on brokers without a rack",
+			args: args{
+				assignments: [][]int32{
+					{5, 1},
+				},
+				brokersByRack: map[string][]int32{
+					"zone-a": {1},
+					"zone-b": {2},
+				},
+			},
+			want: []int32{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RackSpreadViolations(tt.args.assignments, tt.args.brokersByRack); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RackSpreadViolations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBalanceLeaders(t *testing.T) {
+	type args struct {
+		assignments       [][]int32
+		eligibleLeaders   [][]int32
+		fixedLeaderCounts map[int32]int
+		racksByBroker     map[int32]string
+	}
+	tests := []struct {
+		name string
+		args args
+		want [][]int32
+	}{
+		{
+			name: "Tests balancing of preferred leaders",
+			args: args{
+				assignments: [][]int32{
+					{1, 2},
+					{1, 3},
+					{1, 2},
+					{1, 3},
+				},
+				eligibleLeaders:   nil,
+				fixedLeaderCounts: nil,
+				racksByBroker:     nil,
+			},
+			want: [][]int32{
+				{2, 1},
+				{3, 1},
+				{1, 2},
+				{1, 3},
+			},
+		},
+		{
+			name: "Tests leaders restricted to eligible leaders",
+			args: args{
+				assignments: [][]int32{
+					{1, 2},
+					{1, 2},
+				},
+				eligibleLeaders: [][]int32{
+					{1},
+					nil,
+				},
+				fixedLeaderCounts: nil,
+				racksByBroker:     nil,
+			},
+			want: [][]int32{
+				{1, 2},
+				{2, 1},
+			},
+		},
+		{
+			name: "Tests fixed leader counts",
+			args: args{
+				assignments: [][]int32{
+					{1, 2},
+				},
+				eligibleLeaders:   nil,
+				fixedLeaderCounts: map[int32]int{2: 3},
+				racksByBroker:     nil,
+			},
+			want: [][]int32{
+				{1, 2},
+			},
+		},
+		{
+			name: "Tests balancing of preferred leaders across racks",
+			args: args{
+				assignments: [][]int32{
+					{1, 3},
+					{2, 1},
+				},
+				eligibleLeaders:   nil,
+				fixedLeaderCounts: nil,
+				racksByBroker: map[int32]string{
+					1: "zone-a",
+					2: "zone-a",
+					3: "zone-b",
+				},
+			},
+			want: [][]int32{
+				{3, 1},
+				{2, 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BalanceLeaders(
+				tt.args.assignments,
+				tt.args.eligibleLeaders,
+				tt.args.fixedLeaderCounts,
+				tt.args.racksByBroker,
+			); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BalanceLeaders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Package authorizer implements helper functions simulating the authorization of requests by Kafka ACLs.
+package authorizer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/peter-evans/kdef/core/model/meta"
+)
+
+const (
+	wildcardResource  = "*"
+	wildcardPrincipal = "User:*"
+	wildcardHost      = "*"
+	operationAll      = "ALL"
+	permissionAllow   = "ALLOW"
+	permissionDeny    = "DENY"
+	patternLiteral    = "literal"
+	patternPrefixed   = "prefixed"
+)
+
+// impliedOperations are the operations that imply an operation when allowed.
+// Denied operations do not imply other operations.
+var impliedOperations = map[string][]string{
+	"DESCRIBE":         {"READ", "WRITE", "DELETE", "ALTER"},
+	"DESCRIBE_CONFIGS": {"ALTER_CONFIGS"},
+}
+
+// Authorize determines if ACLs authorize a request following the semantics of Kafka's standard authorizer.
+// DENY ACLs take precedence over ALLOW ACLs. If no ACLs exist for the resource, the request is allowed
+// only if allowIfNoACLs is set, which is equivalent to the broker config "allow.everyone.if.no.acl.found".
+func Authorize(bindings meta.ACLBindings, req meta.ACLCheckRequest, allowIfNoACLs bool) meta.ACLCheckDecision {
+	var resourceACLs meta.ACLBindings
+	for _, binding := range bindings {
+		if matchesResource(binding, req) {
+			resourceACLs = append(resourceACLs, binding)
+		}
+	}
+
+	if len(resourceACLs) == 0 {
+		if allowIfNoACLs {
+			return meta.ACLCheckDecision{
+				Allowed: true,
+				Reason:  "no ACLs found for the resource and allow if no ACLs is enabled",
+			}
+		}
+		return meta.ACLCheckDecision{
+			Allowed: false,
+			Reason:  "no ACLs found for the resource",
+		}
+	}
+
+	var denyACLs, allowACLs meta.ACLBindings
+	for _, binding := range resourceACLs {
+		if !matchesPrincipal(binding, req) || !matchesHost(binding, req) {
+			continue
+		}
+		switch binding.PermissionType {
+		case permissionDeny:
+			if binding.Operation == req.Operation || binding.Operation == operationAll {
+				denyACLs = append(denyACLs, binding)
+			}
+		case permissionAllow:
+			if allowsOperation(binding.Operation, req.Operation) {
+				allowACLs = append(allowACLs, binding)
+			}
+		}
+	}
+
+	if len(denyACLs) > 0 {
+		denyACLs.Sort()
+		return meta.ACLCheckDecision{
+			Allowed:      false,
+			Reason:       "denied by DENY ACLs, which take precedence over ALLOW ACLs",
+			MatchingACLs: denyACLs,
+		}
+	}
+
+	if len(allowACLs) > 0 {
+		allowACLs.Sort()
+		return meta.ACLCheckDecision{
+			Allowed:      true,
+			Reason:       "allowed by ALLOW ACLs",
+			MatchingACLs: allowACLs,
+		}
+	}
+
+	return meta.ACLCheckDecision{
+		Allowed: false,
+		Reason:  "no ALLOW ACLs match the principal, host and operation",
+	}
+}
+
+// matchesResource determines if an ACL binding applies to the resource of a request.
+func matchesResource(binding meta.ACLBinding, req meta.ACLCheckRequest) bool {
+	if binding.ResourceType != req.ResourceType {
+		return false
+	}
+	switch binding.ResourcePatternType {
+	case patternLiteral:
+		return binding.ResourceName == req.ResourceName || binding.ResourceName == wildcardResource
+	case patternPrefixed:
+		return strings.HasPrefix(req.ResourceName, binding.ResourceName)
+	default:
+		return false
+	}
+}
+
+// matchesPrincipal determines if an ACL binding applies to the principal of a request.
+func matchesPrincipal(binding meta.ACLBinding, req meta.ACLCheckRequest) bool {
+	return binding.Principal == req.Principal || binding.Principal == wildcardPrincipal
+}
+
+// matchesHost determines if an ACL binding applies to the host of a request.
+func matchesHost(binding meta.ACLBinding, req meta.ACLCheckRequest) bool {
+	return binding.Host == req.Host || binding.Host == wildcardHost
+}
+
+// allowsOperation determines if allowing an ACL operation allows the requested operation.
+func allowsOperation(aclOperation string, operation string) bool {
+	if aclOperation == operation || aclOperation == operationAll {
+		return true
+	}
+	for _, implied := range impliedOperations[operation] {
+		if aclOperation == implied {
+			return true
+		}
+	}
+	return false
+}
+
+// Display displays an authorization decision and the ACLs that caused it.
+func Display(req meta.ACLCheckRequest, decision meta.ACLCheckDecision) {
+	result := "DENIED"
+	if decision.Allowed {
+		result = "ALLOWED"
+	}
+	fmt.Printf(
+		"%s: principal %q, host %q, operation %s on %s %q (%s)\n",
+		result,
+		req.Principal,
+		req.Host,
+		req.Operation,
+		req.ResourceType,
+		req.ResourceName,
+		decision.Reason,
+	)
+
+	if len(decision.MatchingACLs) == 0 {
+		return
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Resource Type", "Resource Name", "Pattern Type", "Principal", "Host", "Operation", "Permission"})
+	for _, acl := range decision.MatchingACLs {
+		t.AppendRow(table.Row{
+			acl.ResourceType,
+			acl.ResourceName,
+			acl.ResourcePatternType,
+			acl.Principal,
+			acl.Host,
+			acl.Operation,
+			acl.PermissionType,
+		})
+	}
+	t.SetStyle(table.StyleLight)
+	t.Render()
+}
@@ -0,0 +1,176 @@
+// Package authorizer implements helper functions simulating the authorization of requests by Kafka ACLs.
+package authorizer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter-evans/kdef/core/model/meta"
+)
+
+var testBindings = meta.ACLBindings{
+	{
+		ResourceType:        "topic",
+		ResourceName:        "store.events.",
+		ResourcePatternType: "prefixed",
+		Principal:           "User:foo",
+		Host:                "*",
+		Operation:           "READ",
+		PermissionType:      "ALLOW",
+	},
+	{
+		ResourceType:        "topic",
+		ResourceName:        "store.events.order-created",
+		ResourcePatternType: "literal",
+		Principal:           "User:foo",
+		Host:                "10.0.0.2",
+		Operation:           "ALL",
+		PermissionType:      "DENY",
+	},
+	{
+		ResourceType:        "topic",
+		ResourceName:        "*",
+		ResourcePatternType: "literal",
+		Principal:           "User:*",
+		Host:                "*",
+		Operation:           "DESCRIBE_CONFIGS",
+		PermissionType:      "ALLOW",
+	},
+	{
+		ResourceType:        "topic",
+		ResourceName:        "store.commands",
+		ResourcePatternType: "literal",
+		Principal:           "User:bar",
+		Host:                "*",
+		Operation:           "WRITE",
+		PermissionType:      "ALLOW",
+	},
+	{
+		ResourceType:        "group",
+		ResourceName:        "store.order-service",
+		ResourcePatternType: "literal",
+		Principal:           "User:foo",
+		Host:                "*",
+		Operation:           "READ",
+		PermissionType:      "DENY",
+	},
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           meta.ACLCheckRequest
+		allowIfNoACLs bool
+		wantAllowed   bool
+		wantMatching  meta.ACLBindings
+	}{
+		{
+			name: "Tests allowing by a prefixed ACL",
+			req: meta.ACLCheckRequest{
+				Principal:    "User:foo",
+				Host:         "10.0.0.1",
+				Operation:    "READ",
+				ResourceType: "topic",
+				ResourceName: "store.events.order-created",
+			},
+			wantAllowed:  true,
+			wantMatching: meta.ACLBindings{testBindings[0]},
+		},
+		{
+			name: "Tests DENY precedence over ALLOW",
+			req: meta.ACLCheckRequest{
+				Principal:    "User:foo",
+				Host:         "10.0.0.2",
+				Operation:    "READ",
+				ResourceType: "topic",
+				ResourceName: "store.events.order-created",
+			},
+			wantAllowed:  false,
+			wantMatching: meta.ACLBindings{testBindings[1]},
+		},
+		{
+			name: "Tests DESCRIBE implied by READ",
+			req: meta.ACLCheckRequest{
+				Principal:    "User:foo",
+				Host:         "10.0.0.1",
+				Operation:    "DESCRIBE",
+				ResourceType: "topic",
+				ResourceName: "store.events.order-updated",
+			},
+			wantAllowed:  true,
+			wantMatching: meta.ACLBindings{testBindings[0]},
+		},
+		{
+			name: "Tests a wildcard resource and principal",
+			req: meta.ACLCheckRequest{
+				Principal:    "User:baz",
+				Host:         "10.0.0.1",
+				Operation:    "DESCRIBE_CONFIGS",
+				ResourceType: "topic",
+				ResourceName: "store.commands",
+			},
+			wantAllowed:  true,
+			wantMatching: meta.ACLBindings{testBindings[2]},
+		},
+		{
+			name: "Tests an operation that is not allowed",
+			req: meta.ACLCheckRequest{
+				Principal:    "User:foo",
+				Host:         "10.0.0.1",
+				Operation:    "WRITE",
+				ResourceType: "topic",
+				ResourceName: "store.events.order-created",
+			},
+			wantAllowed:  false,
+			wantMatching: nil,
+		},
+		{
+			name: "Tests a DENY ACL does not imply other operations",
+			req: meta.ACLCheckRequest{
+				Principal:    "User:foo",
+				Host:         "10.0.0.1",
+				Operation:    "DESCRIBE",
+				ResourceType: "group",
+				ResourceName: "store.order-service",
+			},
+			wantAllowed:  false,
+			wantMatching: nil,
+		},
+		{
+			name: "Tests no ACLs for the resource",
+			req: meta.ACLCheckRequest{
+				Principal:    "User:foo",
+				Host:         "10.0.0.1",
+				Operation:    "READ",
+				ResourceType: "group",
+				ResourceName: "store.picker",
+			},
+			wantAllowed:  false,
+			wantMatching: nil,
+		},
+		{
+			name: "Tests no ACLs for the resource with allow if no ACLs",
+			req: meta.ACLCheckRequest{
+				Principal:    "User:foo",
+				Host:         "10.0.0.1",
+				Operation:    "READ",
+				ResourceType: "group",
+				ResourceName: "store.picker",
+			},
+			allowIfNoACLs: true,
+			wantAllowed:   true,
+			wantMatching:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Authorize(testBindings, tt.req, tt.allowIfNoACLs)
+			if got.Allowed != tt.wantAllowed {
+				t.Errorf("Authorize().Allowed = %v, want %v (%s)", got.Allowed, tt.wantAllowed, got.Reason)
+			}
+			if !reflect.DeepEqual(got.MatchingACLs, tt.wantMatching) {
+				t.Errorf("Authorize().MatchingACLs = %v, want %v", got.MatchingACLs, tt.wantMatching)
+			}
+		})
+	}
+}
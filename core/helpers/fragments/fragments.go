@@ -0,0 +1,267 @@
+// Package fragments implements helper functions for definition fragments.
+package fragments
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/opt"
+)
+
+// RefKey is the key of object properties referencing fragment files.
+const RefKey = "$ref"
+
+// ResolveRefs resolves the references to fragment files in a definition document.
+// References are resolved relative to a directory, normally that of the file containing the definition.
+// If the document contains references, it is returned as YAML with references resolved and the YAML format.
+// YAML documents are unmarshalled with the types of the definition, so unquoted numeric configs remain valid.
+// Otherwise, the document and format are returned unchanged.
+func ResolveRefs(defDoc string, format opt.DefinitionFormat, dir string) (string, opt.DefinitionFormat, error) {
+	b, err := def.DefinitionToJSON(defDoc, format)
+	if err != nil {
+		return defDoc, format, err
+	}
+
+	doc, err := unmarshalJSON(b)
+	if err != nil {
+		return defDoc, format, err
+	}
+	if !hasRefs(doc) {
+		return defDoc, format, nil
+	}
+
+	resolved, err := resolve(doc, dir, "", nil)
+	if err != nil {
+		return defDoc, format, err
+	}
+
+	yb, err := yaml.Marshal(resolved)
+	if err != nil {
+		return defDoc, format, err
+	}
+
+	return string(yb), opt.YAMLFormat, nil
+}
+
+// unmarshalJSON unmarshals a JSON document, keeping numbers as their literal values.
+func unmarshalJSON(b []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var doc interface{}
+	if err := d.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// CommonConfigs returns the configs that are common to all configs, having the same key and value.
+// Configs are only common to two or more configs.
+func CommonConfigs(configs []def.ConfigsMap) def.ConfigsMap {
+	if len(configs) < 2 {
+		return nil
+	}
+
+	common := def.ConfigsMap{}
+	for key, value := range configs[0] {
+		isCommon := true
+		for _, c := range configs[1:] {
+			other, ok := c[key]
+			if !ok || !equalConfigValues(value, other) {
+				isCommon = false
+				break
+			}
+		}
+		if isCommon {
+			common[key] = value
+		}
+	}
+
+	return common
+}
+
+// equalConfigValues determines if config values are equal.
+func equalConfigValues(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// hasRefs determines if an unmarshalled document contains references.
+func hasRefs(doc interface{}) bool {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		if _, ok := v[RefKey]; ok {
+			return true
+		}
+		for _, value := range v {
+			if hasRefs(value) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if hasRefs(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolve resolves the references of an unmarshalled document at a property path.
+// The paths of the fragment files being resolved are tracked to detect circular references.
+func resolve(doc interface{}, dir string, path string, resolving []string) (interface{}, error) {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		obj := map[string]interface{}{}
+		for _, key := range sortedKeys(v) {
+			if key == RefKey {
+				continue
+			}
+			value, err := resolve(v[key], dir, joinPath(path, key), resolving)
+			if err != nil {
+				return nil, err
+			}
+			obj[key] = value
+		}
+
+		ref, ok := v[RefKey]
+		if !ok {
+			return obj, nil
+		}
+
+		refPath := joinPath(path, RefKey)
+		refs, err := refPaths(ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve field %q: %v", refPath, err)
+		}
+
+		// Fragments are defaults that properties of the referencing object override.
+		// Where there are multiple references, later fragments override earlier fragments.
+		merged := map[string]interface{}{}
+		for _, ref := range refs {
+			fragment, err := readFragment(ref, dir, resolving)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve field %q: %v", refPath, err)
+			}
+			merged = merge(merged, fragment)
+		}
+
+		return merge(merged, obj), nil
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			value, err := resolve(item, dir, path, resolving)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = value
+		}
+		return items, nil
+	default:
+		return doc, nil
+	}
+}
+
+// refPaths returns the fragment file paths of a reference, which is either a path or an array of paths.
+func refPaths(ref interface{}) ([]string, error) {
+	switch v := ref.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		paths := make([]string, len(v))
+		for i, item := range v {
+			path, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("reference must be a path or an array of paths")
+			}
+			paths[i] = path
+		}
+		return paths, nil
+	default:
+		return nil, fmt.Errorf("reference must be a path or an array of paths")
+	}
+}
+
+// readFragment reads a YAML or JSON fragment file, resolving its own references relative to the fragment file.
+func readFragment(path string, dir string, resolving []string) (map[string]interface{}, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	for _, p := range resolving {
+		if p == path {
+			return nil, fmt.Errorf("circular reference to fragment %q", path)
+		}
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fragment: %v", err)
+	}
+
+	jb, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fragment %q: %v", path, err)
+	}
+	doc, err := unmarshalJSON(jb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fragment %q: %v", path, err)
+	}
+
+	resolved, err := resolve(doc, filepath.Dir(path), "", append(resolving, path))
+	if err != nil {
+		return nil, fmt.Errorf("fragment %q: %v", path, err)
+	}
+
+	fragment, ok := resolved.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("fragment %q must be an object", path)
+	}
+
+	return fragment, nil
+}
+
+// merge merges the properties of an object over those of a base object.
+// Nested objects are merged, and all other values are replaced.
+func merge(base map[string]interface{}, obj map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range obj {
+		baseObj, baseOk := merged[key].(map[string]interface{})
+		valueObj, valueOk := value.(map[string]interface{})
+		if baseOk && valueOk {
+			merged[key] = merge(baseObj, valueObj)
+		} else {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// joinPath joins a property name to a dot separated property path.
+func joinPath(path string, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+	return strings.Join([]string{path, name}, ".")
+}
+
+// sortedKeys returns the sorted keys of an unmarshalled object.
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,216 @@
+// Package fragments implements helper functions for definition fragments.
+package fragments
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/opt"
+	"github.com/peter-evans/kdef/core/test/tutil"
+)
+
+func TestResolveRefs(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"fragments/configs.yml":  "retention.ms: \"86400000\"\ncleanup.policy: delete\n",
+		"fragments/compact.json": "{\"cleanup.policy\": \"compact\"}",
+		"fragments/spec.yml":     "replicationFactor: 3\nconfigs:\n  $ref: configs.yml\n",
+		"fragments/cycle.yml":    "$ref: cycle.yml\n",
+		"fragments/list.yml":     "- foo\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type args struct {
+		defDoc string
+		format opt.DefinitionFormat
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       string
+		wantFormat opt.DefinitionFormat
+		wantErr    string
+	}{
+		{
+			name: "Tests a definition without references",
+			args: args{
+				defDoc: "apiVersion: v1\nkind: topic\nmetadata:\n  name: foo\n",
+				format: opt.YAMLFormat,
+			},
+			want:       "apiVersion: v1\nkind: topic\nmetadata:\n  name: foo\n",
+			wantFormat: opt.YAMLFormat,
+		},
+		{
+			name: "Tests a configs fragment with an overridden config",
+			args: args{
+				defDoc: "apiVersion: v1\nkind: topic\nmetadata:\n  name: foo\nspec:\n  configs:\n    $ref: fragments/configs.yml\n    retention.ms: \"1000\"\n",
+				format: opt.YAMLFormat,
+			},
+			want:       "apiVersion: v1\nkind: topic\nmetadata:\n  name: foo\nspec:\n  configs:\n    cleanup.policy: delete\n    retention.ms: \"1000\"\n",
+			wantFormat: opt.YAMLFormat,
+		},
+		{
+			name: "Tests multiple fragments where later fragments override earlier fragments",
+			args: args{
+				defDoc: `{"apiVersion":"v1","kind":"topic","metadata":{"name":"foo"},"spec":{"configs":{"$ref":["fragments/configs.yml","fragments/compact.json"]}}}`,
+				format: opt.JSONFormat,
+			},
+			want:       "apiVersion: v1\nkind: topic\nmetadata:\n  name: foo\nspec:\n  configs:\n    cleanup.policy: compact\n    retention.ms: \"86400000\"\n",
+			wantFormat: opt.YAMLFormat,
+		},
+		{
+			name: "Tests a spec defaults fragment with a nested reference relative to the fragment",
+			args: args{
+				defDoc: "apiVersion: v1\nkind: topic\nmetadata:\n  name: foo\nspec:\n  $ref: fragments/spec.yml\n  partitions: 3\n  configs:\n    retention.ms: \"1000\"\n",
+				format: opt.YAMLFormat,
+			},
+			want:       "apiVersion: v1\nkind: topic\nmetadata:\n  name: foo\nspec:\n  configs:\n    cleanup.policy: delete\n    retention.ms: \"1000\"\n  partitions: 3\n  replicationFactor: 3\n",
+			wantFormat: opt.YAMLFormat,
+		},
+		{
+			name: "Tests a reference in an HCL definition",
+			args: args{
+				defDoc: "topic \"foo\" {\n  apiVersion = \"v1\"\n  spec {\n    ref        = \"fragments/spec.yml\"\n    partitions = 3\n  }\n}",
+				format: opt.HCLFormat,
+			},
+			want:       "apiVersion: v1\nkind: topic\nmetadata:\n  name: foo\nspec:\n  configs:\n    cleanup.policy: delete\n    retention.ms: \"86400000\"\n  partitions: 3\n  replicationFactor: 3\n",
+			wantFormat: opt.YAMLFormat,
+		},
+		{
+			name: "Tests a missing fragment",
+			args: args{
+				defDoc: "apiVersion: v1\nkind: topic\nmetadata:\n  name: foo\nspec:\n  configs:\n    $ref: fragments/missing.yml\n",
+				format: opt.YAMLFormat,
+			},
+			wantErr: "failed to resolve field \"spec.configs.$ref\": failed to read fragment",
+		},
+		{
+			name: "Tests an invalid reference",
+			args: args{
+				defDoc: "apiVersion: v1\nkind: topic\nmetadata:\n  name: foo\nspec:\n  configs:\n    $ref: 1\n",
+				format: opt.YAMLFormat,
+			},
+			wantErr: "failed to resolve field \"spec.configs.$ref\": reference must be a path or an array of paths",
+		},
+		{
+			name: "Tests a fragment that is not an object",
+			args: args{
+				defDoc: "apiVersion: v1\nkind: topic\nmetadata:\n  name: foo\nspec:\n  $ref: fragments/list.yml\n",
+				format: opt.YAMLFormat,
+			},
+			wantErr: "must be an object",
+		},
+		{
+			name: "Tests a circular reference",
+			args: args{
+				defDoc: "apiVersion: v1\nkind: topic\nmetadata:\n  name: foo\nspec:\n  $ref: fragments/cycle.yml\n",
+				format: opt.YAMLFormat,
+			},
+			wantErr: "circular reference to fragment",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotFormat, err := ResolveRefs(tt.args.defDoc, tt.args.format, dir)
+			if !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("ResolveRefs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if gotFormat != tt.wantFormat {
+				t.Errorf("ResolveRefs() format = %v, want %v", gotFormat, tt.wantFormat)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveRefs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRefs_numericConfigs(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(
+		filepath.Join(dir, "configs.yml"),
+		[]byte("retention.ms: 86400000\nretention.bytes: -1\nmax.message.bytes: 1048588\n"),
+		0o666,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	docs := map[string]string{
+		"without references": "apiVersion: v1\nkind: topic\nmetadata:\n  name: foo\nspec:\n  partitions: 3\n  configs:\n    " +
+			"retention.ms: 86400000\n    retention.bytes: -1\n    max.message.bytes: 2097152\n",
+		"with references": "apiVersion: v1\nkind: topic\nmetadata:\n  name: foo\nspec:\n  partitions: 3\n  configs:\n    " +
+			"$ref: configs.yml\n    max.message.bytes: 2097152\n",
+	}
+	for name, doc := range docs {
+		t.Run("Tests numeric configs of a definition "+name, func(t *testing.T) {
+			resolved, format, err := ResolveRefs(doc, opt.YAMLFormat, dir)
+			if err != nil {
+				t.Fatalf("ResolveRefs() error = %v", err)
+			}
+			topicDef, err := def.LoadTopicDefinition(resolved, format, nil)
+			if err != nil {
+				t.Fatalf("LoadTopicDefinition() error = %v", err)
+			}
+			want := map[string]string{
+				"retention.ms":      "86400000",
+				"retention.bytes":   "-1",
+				"max.message.bytes": "2097152",
+			}
+			got := make(map[string]string)
+			for k, v := range topicDef.Spec.Configs {
+				got[k] = *v
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("LoadTopicDefinition() configs = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCommonConfigs(t *testing.T) {
+	foo := "foo"
+	bar := "bar"
+
+	tests := []struct {
+		name    string
+		configs []def.ConfigsMap
+		want    def.ConfigsMap
+	}{
+		{
+			name:    "Tests a single definition's configs",
+			configs: []def.ConfigsMap{{"a": &foo}},
+			want:    nil,
+		},
+		{
+			name: "Tests configs with common keys and values",
+			configs: []def.ConfigsMap{
+				{"a": &foo, "b": &foo, "c": nil, "d": &foo},
+				{"a": &foo, "b": &bar, "c": nil},
+				{"a": &foo, "b": &foo, "c": nil, "d": &foo},
+			},
+			want: def.ConfigsMap{"a": &foo, "c": nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CommonConfigs(tt.configs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CommonConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
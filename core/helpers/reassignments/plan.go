@@ -0,0 +1,39 @@
+// Package reassignments implements helper functions for partition reassignment operations.
+package reassignments
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/peter-evans/kdef/core/model/meta"
+)
+
+// ReadPlanFile reads and validates a reassignment plan file.
+func ReadPlanFile(path string) (meta.ReassignmentPlan, error) {
+	var plan meta.ReassignmentPlan
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return plan, err
+	}
+	if err := json.Unmarshal(b, &plan); err != nil {
+		return plan, fmt.Errorf("failed to parse reassignment plan file %q: %v", path, err)
+	}
+	if err := plan.Validate(); err != nil {
+		return plan, fmt.Errorf("invalid reassignment plan file %q: %v", path, err)
+	}
+
+	return plan, nil
+}
+
+// WritePlanFile writes a reassignment plan file.
+func WritePlanFile(path string, plan meta.ReassignmentPlan) error {
+	b, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, "\n"...)
+
+	return os.WriteFile(path, b, 0o666)
+}
@@ -0,0 +1,79 @@
+// Package reassignments implements helper functions for partition reassignment operations.
+package reassignments
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/peter-evans/kdef/core/model/meta"
+)
+
+func TestPlanFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	plan := meta.NewReassignmentPlan(testMoves)
+
+	if err := WritePlanFile(path, plan); err != nil {
+		t.Fatalf("WritePlanFile() error = %v", err)
+	}
+
+	got, err := ReadPlanFile(path)
+	if err != nil {
+		t.Fatalf("ReadPlanFile() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, plan) {
+		t.Errorf("ReadPlanFile() = %v, want %v", got, plan)
+	}
+}
+
+func TestReadPlanFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    meta.TopicPartitionAssignments
+		wantErr bool
+	}{
+		{
+			name: "Test kafka-reassign-partitions format",
+			content: `{"version":1,"partitions":[` +
+				`{"topic":"foo","partition":1,"replicas":[2,4],"log_dirs":["any","any"]}]}`,
+			want: meta.TopicPartitionAssignments{
+				{Topic: "foo", Partition: 1, Replicas: []int32{2, 4}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Test invalid JSON",
+			content: `{"version":1,"partitions":[`,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Test invalid plan",
+			content: `{"version":2,"partitions":[]}`,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "plan.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ReadPlanFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadPlanFile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if assignments := got.Assignments(); !reflect.DeepEqual(assignments, tt.want) {
+				t.Errorf("ReadPlanFile() assignments = %v, want %v", assignments, tt.want)
+			}
+		})
+	}
+}
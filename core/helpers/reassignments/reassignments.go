@@ -0,0 +1,256 @@
+// Package reassignments implements helper functions for partition reassignment operations.
+package reassignments
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/peter-evans/kdef/core/util/i32"
+)
+
+// Display displays partition reassignments in a table.
+// The topic column may be omitted when displaying the reassignments of a single topic.
+func Display(reassignments meta.PartitionReassignments, includeTopic bool) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	header := table.Row{"Partition", "Replicas", "Adding Replicas", "Removing Replicas"}
+	if includeTopic {
+		header = append(table.Row{"Topic"}, header...)
+	}
+	t.AppendHeader(header)
+	for _, r := range reassignments {
+		row := table.Row{
+			fmt.Sprint(r.Partition),
+			fmt.Sprint(r.Replicas),
+			fmt.Sprint(r.AddingReplicas),
+			fmt.Sprint(r.RemovingReplicas),
+		}
+		if includeTopic {
+			row = append(table.Row{r.Topic}, row...)
+		}
+		t.AppendRow(row)
+	}
+	t.SetStyle(table.StyleLight)
+	t.Render()
+}
+
+// DisplayMoves displays partition moves in a table alongside the current replicas.
+func DisplayMoves(current meta.TopicPartitionAssignments, moves meta.TopicPartitionAssignments) {
+	currentReplicas := replicasByPartition(current)
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Topic", "Partition", "Replicas", "New Replicas"})
+	for _, m := range moves {
+		t.AppendRow(table.Row{
+			m.Topic,
+			fmt.Sprint(m.Partition),
+			fmt.Sprint(currentReplicas[m.Topic][m.Partition]),
+			fmt.Sprint(m.Replicas),
+		})
+	}
+	t.SetStyle(table.StyleLight)
+	t.Render()
+}
+
+// DisplayLogDirMoves displays moves of partition replicas between log directories in a table.
+func DisplayLogDirMoves(current meta.ReplicaLogDirs, moves meta.ReplicaLogDirs) {
+	currentDirs := make(map[string]string)
+	for _, c := range current {
+		if !c.IsFuture {
+			currentDirs[fmt.Sprintf("%s:%d:%d", c.Topic, c.Partition, c.Broker)] = c.Dir
+		}
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Topic", "Partition", "Broker", "Log Dir", "New Log Dir"})
+	for _, m := range moves {
+		t.AppendRow(table.Row{
+			m.Topic,
+			fmt.Sprint(m.Partition),
+			fmt.Sprint(m.Broker),
+			currentDirs[fmt.Sprintf("%s:%d:%d", m.Topic, m.Partition, m.Broker)],
+			m.Dir,
+		})
+	}
+	t.SetStyle(table.StyleLight)
+	t.Render()
+}
+
+// DisplayLeaderCounts displays the number of partition leaders per broker before and after an operation.
+func DisplayLeaderCounts(brokers meta.Brokers, before map[int32]int, after map[int32]int) {
+	sorted := append(meta.Brokers{}, brokers...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Broker", "Rack", "Leaders", "New Leaders"})
+	for _, b := range sorted {
+		t.AppendRow(table.Row{
+			fmt.Sprint(b.ID),
+			b.Rack,
+			fmt.Sprint(before[b.ID]),
+			fmt.Sprint(after[b.ID]),
+		})
+	}
+	t.SetStyle(table.StyleLight)
+	t.Render()
+}
+
+// Cancellations returns the assignments that cancel partition reassignments.
+func Cancellations(reassignments meta.PartitionReassignments) meta.TopicPartitionAssignments {
+	cancellations := make(meta.TopicPartitionAssignments, len(reassignments))
+	for i, r := range reassignments {
+		// Null replicas cancels an in-progress reassignment, reverting to the original replicas.
+		cancellations[i] = meta.TopicPartitionAssignment{
+			Topic:     r.Topic,
+			Partition: r.Partition,
+			Replicas:  nil,
+		}
+	}
+	return cancellations
+}
+
+// ReplicationThrottles returns the replicas to throttle while moving partitions.
+// Leader replicas are the current replicas of moving partitions.
+// Follower replicas are the replicas that moving partitions are being added to.
+func ReplicationThrottles(current meta.TopicPartitionAssignments, moves meta.TopicPartitionAssignments) meta.ReplicationThrottles {
+	currentReplicas := replicasByPartition(current)
+
+	var throttles meta.ReplicationThrottles
+	topicIndexes := make(map[string]int)
+	for _, m := range moves {
+		i, ok := topicIndexes[m.Topic]
+		if !ok {
+			throttles = append(throttles, meta.ReplicationThrottle{Topic: m.Topic})
+			i = len(throttles) - 1
+			topicIndexes[m.Topic] = i
+		}
+
+		replicas := currentReplicas[m.Topic][m.Partition]
+		for _, brokerID := range replicas {
+			throttles[i].LeaderReplicas = append(
+				throttles[i].LeaderReplicas,
+				fmt.Sprintf("%d:%d", m.Partition, brokerID),
+			)
+		}
+		for _, brokerID := range i32.Diff(m.Replicas, replicas) {
+			throttles[i].FollowerReplicas = append(
+				throttles[i].FollowerReplicas,
+				fmt.Sprintf("%d:%d", m.Partition, brokerID),
+			)
+		}
+	}
+
+	return throttles
+}
+
+// Brokers returns the sorted IDs of brokers involved in moving partitions.
+func Brokers(current meta.TopicPartitionAssignments, moves meta.TopicPartitionAssignments) []int32 {
+	moving := make(map[string]map[int32]bool)
+	for _, m := range moves {
+		if _, ok := moving[m.Topic]; !ok {
+			moving[m.Topic] = make(map[int32]bool)
+		}
+		moving[m.Topic][m.Partition] = true
+	}
+
+	var brokers []int32
+	add := func(replicas []int32) {
+		for _, brokerID := range replicas {
+			if !i32.Contains(brokerID, brokers) {
+				brokers = append(brokers, brokerID)
+			}
+		}
+	}
+	for _, c := range current {
+		if moving[c.Topic][c.Partition] {
+			add(c.Replicas)
+		}
+	}
+	for _, m := range moves {
+		add(m.Replicas)
+	}
+
+	sort.Slice(brokers, func(i, j int) bool {
+		return brokers[i] < brokers[j]
+	})
+
+	return brokers
+}
+
+// Moves returns the assignments that change the replicas of current partition assignments.
+// An error is returned if an assignment targets a partition that does not exist.
+func Moves(
+	current meta.TopicPartitionAssignments,
+	assignments meta.TopicPartitionAssignments,
+) (meta.TopicPartitionAssignments, error) {
+	currentReplicas := replicasByPartition(current)
+
+	var moves meta.TopicPartitionAssignments
+	for _, a := range assignments {
+		replicas, ok := currentReplicas[a.Topic][a.Partition]
+		if !ok {
+			return nil, fmt.Errorf("partition %d of topic %q does not exist", a.Partition, a.Topic)
+		}
+		if cmp.Equal(replicas, a.Replicas) {
+			continue
+		}
+		moves = append(moves, a)
+	}
+
+	return moves, nil
+}
+
+// DataMovement returns the number of replicas added by partition moves and the estimated bytes to copy.
+// Partitions without a known size are assumed to be empty.
+func DataMovement(
+	current meta.TopicPartitionAssignments,
+	moves meta.TopicPartitionAssignments,
+	partitionSizes map[string]map[int32]int64,
+) (int, int64) {
+	currentReplicas := replicasByPartition(current)
+
+	var replicas int
+	var bytes int64
+	for _, m := range moves {
+		added := len(i32.Diff(m.Replicas, currentReplicas[m.Topic][m.Partition]))
+		replicas += added
+		bytes += int64(added) * partitionSizes[m.Topic][m.Partition]
+	}
+
+	return replicas, bytes
+}
+
+// replicasByPartition returns the replicas of partition assignments by topic and partition.
+func replicasByPartition(assignments meta.TopicPartitionAssignments) map[string]map[int32][]int32 {
+	replicas := make(map[string]map[int32][]int32)
+	for _, a := range assignments {
+		if _, ok := replicas[a.Topic]; !ok {
+			replicas[a.Topic] = make(map[int32][]int32)
+		}
+		replicas[a.Topic][a.Partition] = a.Replicas
+	}
+	return replicas
+}
+
+// FormatBytes formats a number of bytes in human-readable binary units.
+func FormatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
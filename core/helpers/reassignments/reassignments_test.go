@@ -0,0 +1,260 @@
+// Package reassignments implements helper functions for partition reassignment operations.
+package reassignments
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter-evans/kdef/core/model/meta"
+)
+
+func TestCancellations(t *testing.T) {
+	tests := []struct {
+		name          string
+		reassignments meta.PartitionReassignments
+		want          meta.TopicPartitionAssignments
+	}{
+		{
+			name:          "Test no reassignments",
+			reassignments: meta.PartitionReassignments{},
+			want:          meta.TopicPartitionAssignments{},
+		},
+		{
+			name: "Test cancellation of reassignments with null replicas",
+			reassignments: meta.PartitionReassignments{
+				{
+					Topic:            "foo",
+					Partition:        0,
+					Replicas:         []int32{1, 2, 3},
+					AddingReplicas:   []int32{3},
+					RemovingReplicas: []int32{1},
+				},
+				{
+					Topic:            "bar",
+					Partition:        2,
+					Replicas:         []int32{2, 3, 4},
+					AddingReplicas:   []int32{4},
+					RemovingReplicas: []int32{2},
+				},
+			},
+			want: meta.TopicPartitionAssignments{
+				{Topic: "foo", Partition: 0, Replicas: nil},
+				{Topic: "bar", Partition: 2, Replicas: nil},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Cancellations(tt.reassignments); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Cancellations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var (
+	testCurrentAssignments = meta.TopicPartitionAssignments{
+		{Topic: "foo", Partition: 0, Replicas: []int32{1, 2}},
+		{Topic: "foo", Partition: 1, Replicas: []int32{2, 3}},
+		{Topic: "bar", Partition: 0, Replicas: []int32{3, 1}},
+	}
+	testMoves = meta.TopicPartitionAssignments{
+		{Topic: "foo", Partition: 1, Replicas: []int32{2, 4}},
+		{Topic: "bar", Partition: 0, Replicas: []int32{4, 5}},
+	}
+)
+
+func TestReplicationThrottles(t *testing.T) {
+	tests := []struct {
+		name    string
+		current meta.TopicPartitionAssignments
+		moves   meta.TopicPartitionAssignments
+		want    meta.ReplicationThrottles
+	}{
+		{
+			name:    "Test no moves",
+			current: testCurrentAssignments,
+			moves:   nil,
+			want:    nil,
+		},
+		{
+			name:    "Test throttled replicas of moving partitions",
+			current: testCurrentAssignments,
+			moves:   testMoves,
+			want: meta.ReplicationThrottles{
+				{
+					Topic:            "foo",
+					LeaderReplicas:   []string{"1:2", "1:3"},
+					FollowerReplicas: []string{"1:4"},
+				},
+				{
+					Topic:            "bar",
+					LeaderReplicas:   []string{"0:3", "0:1"},
+					FollowerReplicas: []string{"0:4", "0:5"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReplicationThrottles(tt.current, tt.moves); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReplicationThrottles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBrokers(t *testing.T) {
+	tests := []struct {
+		name    string
+		current meta.TopicPartitionAssignments
+		moves   meta.TopicPartitionAssignments
+		want    []int32
+	}{
+		{
+			name:    "Test no moves",
+			current: testCurrentAssignments,
+			moves:   nil,
+			want:    nil,
+		},
+		{
+			name:    "Test brokers of moving partitions",
+			current: testCurrentAssignments,
+			moves:   testMoves,
+			want:    []int32{1, 2, 3, 4, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Brokers(tt.current, tt.moves); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Brokers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoves(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     meta.TopicPartitionAssignments
+		assignments meta.TopicPartitionAssignments
+		want        meta.TopicPartitionAssignments
+		wantErr     bool
+	}{
+		{
+			name:        "Test unchanged assignments",
+			current:     testCurrentAssignments,
+			assignments: testCurrentAssignments,
+			want:        nil,
+			wantErr:     false,
+		},
+		{
+			name:    "Test changed assignments",
+			current: testCurrentAssignments,
+			assignments: meta.TopicPartitionAssignments{
+				{Topic: "foo", Partition: 0, Replicas: []int32{1, 2}},
+				{Topic: "foo", Partition: 1, Replicas: []int32{2, 4}},
+				{Topic: "bar", Partition: 0, Replicas: []int32{1, 3}},
+			},
+			want: meta.TopicPartitionAssignments{
+				{Topic: "foo", Partition: 1, Replicas: []int32{2, 4}},
+				{Topic: "bar", Partition: 0, Replicas: []int32{1, 3}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Test non-existent partition",
+			current: testCurrentAssignments,
+			assignments: meta.TopicPartitionAssignments{
+				{Topic: "foo", Partition: 2, Replicas: []int32{1, 2}},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Moves(tt.current, tt.assignments)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Moves() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Moves() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataMovement(t *testing.T) {
+	tests := []struct {
+		name           string
+		current        meta.TopicPartitionAssignments
+		moves          meta.TopicPartitionAssignments
+		partitionSizes map[string]map[int32]int64
+		wantReplicas   int
+		wantBytes      int64
+	}{
+		{
+			name:           "Test no moves",
+			current:        testCurrentAssignments,
+			moves:          nil,
+			partitionSizes: map[string]map[int32]int64{},
+			wantReplicas:   0,
+			wantBytes:      0,
+		},
+		{
+			name:    "Test replicas added by moves",
+			current: testCurrentAssignments,
+			moves:   testMoves,
+			partitionSizes: map[string]map[int32]int64{
+				"foo": {0: 10, 1: 100},
+				"bar": {0: 1000},
+			},
+			wantReplicas: 3,
+			wantBytes:    2100,
+		},
+		{
+			name:    "Test reordered replicas",
+			current: testCurrentAssignments,
+			moves: meta.TopicPartitionAssignments{
+				{Topic: "foo", Partition: 0, Replicas: []int32{2, 1}},
+			},
+			partitionSizes: map[string]map[int32]int64{
+				"foo": {0: 10},
+			},
+			wantReplicas: 0,
+			wantBytes:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotReplicas, gotBytes := DataMovement(tt.current, tt.moves, tt.partitionSizes)
+			if gotReplicas != tt.wantReplicas {
+				t.Errorf("DataMovement() replicas = %v, want %v", gotReplicas, tt.wantReplicas)
+			}
+			if gotBytes != tt.wantBytes {
+				t.Errorf("DataMovement() bytes = %v, want %v", gotBytes, tt.wantBytes)
+			}
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		name  string
+		bytes int64
+		want  string
+	}{
+		{name: "Test bytes", bytes: 512, want: "512 B"},
+		{name: "Test kibibytes", bytes: 1536, want: "1.5 KiB"},
+		{name: "Test gibibytes", bytes: 5 * 1024 * 1024 * 1024, want: "5.0 GiB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatBytes(tt.bytes); got != tt.want {
+				t.Errorf("FormatBytes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -77,6 +77,22 @@ func describeAllResourceACLs(
 	return describeACLs(ctx, cl, req)
 }
 
+// describePrincipalACLs executes a request to describe ACLs of a specific principal for all resources (Kafka 2.0.0+).
+func describePrincipalACLs(
+	ctx context.Context,
+	cl *client.Client,
+	principal string,
+) ([]ResourceACLs, error) {
+	req := kmsg.NewDescribeACLsRequest()
+	req.ResourceType = kmsg.ACLResourceTypeAny
+	req.Principal = &principal
+	req.Operation = kmsg.ACLOperationAny
+	req.PermissionType = kmsg.ACLPermissionTypeAny
+	req.ResourcePatternType = kmsg.ACLResourcePatternTypeAny
+
+	return describeACLs(ctx, cl, req)
+}
+
 // describeACLs executes a request to describe resource ACLs (Kafka 0.11.0+).
 func describeACLs(
 	ctx context.Context,
@@ -4,10 +4,13 @@ package kafka
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/peter-evans/kdef/cli/log"
 	"github.com/peter-evans/kdef/core/client"
 	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/meta"
 	"github.com/twmb/franz-go/pkg/kerr"
 	"github.com/twmb/franz-go/pkg/kmsg"
 )
@@ -18,6 +21,14 @@ const (
 	DeleteConfigOperation int8 = 1
 )
 
+// Replication throttle config keys.
+const (
+	leaderReplicationThrottledRate       = "leader.replication.throttled.rate"
+	followerReplicationThrottledRate     = "follower.replication.throttled.rate"
+	leaderReplicationThrottledReplicas   = "leader.replication.throttled.replicas"
+	followerReplicationThrottledReplicas = "follower.replication.throttled.replicas"
+)
+
 // ConfigOperation represents an alter config operation.
 type ConfigOperation struct {
 	Name  string
@@ -174,6 +185,119 @@ func describeTopicConfigs(
 	return resourceConfigs, nil
 }
 
+// describeClientMetricsConfigs executes a request to describe client metrics subscription configs (Kafka 3.7.0+).
+func describeClientMetricsConfigs(
+	ctx context.Context,
+	cl *client.Client,
+	name string,
+) (def.Configs, error) {
+	req := kmsg.NewDescribeConfigsRequest()
+
+	res := kmsg.NewDescribeConfigsRequestResource()
+	res.ResourceType = kmsg.ConfigResourceTypeClientMetrics
+	res.ResourceName = name
+	req.Resources = append(req.Resources, res)
+
+	resp, err := describeConfigs(ctx, cl, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newConfigs(resp[0].Configs), nil
+}
+
+// listClientMetricsResources executes a request to list client metrics subscriptions (Kafka 3.7.0+).
+func listClientMetricsResources(
+	ctx context.Context,
+	cl *client.Client,
+) ([]string, error) {
+	req := kmsg.NewListConfigResourcesRequest()
+	req.ResourceTypes = []int8{int8(kmsg.ConfigResourceTypeClientMetrics)}
+
+	kresp, err := cl.Client.Request(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	resp := kresp.(*kmsg.ListConfigResourcesResponse)
+
+	if err := kerr.ErrorForCode(resp.ErrorCode); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, resource := range resp.ConfigResources {
+		// Version 0 only lists client metrics resources.
+		if resp.Version >= 1 && resource.Type != int8(kmsg.ConfigResourceTypeClientMetrics) {
+			continue
+		}
+		names = append(names, resource.Name)
+	}
+
+	return names, nil
+}
+
+// describeGroupConfigs executes a request to describe group configs (Kafka 4.0.0+).
+func describeGroupConfigs(
+	ctx context.Context,
+	cl *client.Client,
+	groups []string,
+) ([]ResourceConfigs, error) {
+	req := kmsg.NewDescribeConfigsRequest()
+
+	for _, group := range groups {
+		res := kmsg.NewDescribeConfigsRequestResource()
+		res.ResourceType = kmsg.ConfigResourceTypeGroupConfig
+		res.ResourceName = group
+		req.Resources = append(req.Resources, res)
+	}
+
+	resp, err := describeConfigs(ctx, cl, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceConfigs := make([]ResourceConfigs, len(resp))
+	for i, resource := range resp {
+		resourceConfigs[i] = ResourceConfigs{
+			ResourceName: resource.ResourceName,
+			Configs:      newConfigs(resource.Configs),
+		}
+	}
+
+	return resourceConfigs, nil
+}
+
+// describeBrokerLoggerConfigs executes a request to describe the logger levels of brokers (Kafka 2.4.0+).
+func describeBrokerLoggerConfigs(
+	ctx context.Context,
+	cl *client.Client,
+	brokerIDs []string,
+) ([]ResourceConfigs, error) {
+	req := kmsg.NewDescribeConfigsRequest()
+
+	for _, brokerID := range brokerIDs {
+		res := kmsg.NewDescribeConfigsRequestResource()
+		res.ResourceType = kmsg.ConfigResourceTypeBrokerLogger
+		res.ResourceName = brokerID
+		req.Resources = append(req.Resources, res)
+	}
+
+	resp, err := describeConfigs(ctx, cl, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceConfigs := make([]ResourceConfigs, len(resp))
+	for i, resource := range resp {
+		resourceConfigs[i] = ResourceConfigs{
+			ResourceName: resource.ResourceName,
+			Configs:      newConfigs(resource.Configs),
+		}
+	}
+
+	return resourceConfigs, nil
+}
+
 func newConfigs(configsResp []kmsg.DescribeConfigsResponseResourceConfig) def.Configs {
 	var configs def.Configs
 	for _, c := range configsResp {
@@ -271,6 +395,48 @@ func alterTopicConfigs(
 	)
 }
 
+// alterClientMetricsConfigs executes a request to perform a non-incremental alter client metrics configs (Kafka 3.7.0+).
+func alterClientMetricsConfigs(
+	ctx context.Context,
+	cl *client.Client,
+	name string,
+	configOps ConfigOperations,
+	validateOnly bool,
+) error {
+	reqR := kmsg.NewAlterConfigsRequestResource()
+	reqR.ResourceType = kmsg.ConfigResourceTypeClientMetrics
+	reqR.ResourceName = name
+	reqR.Configs = buildAlterConfigsResourceConfig(configOps)
+
+	return alterConfigs(
+		ctx,
+		cl,
+		[]kmsg.AlterConfigsRequestResource{reqR},
+		validateOnly,
+	)
+}
+
+// alterGroupConfigs executes a request to perform a non-incremental alter group configs (Kafka 4.0.0+).
+func alterGroupConfigs(
+	ctx context.Context,
+	cl *client.Client,
+	group string,
+	configOps ConfigOperations,
+	validateOnly bool,
+) error {
+	reqR := kmsg.NewAlterConfigsRequestResource()
+	reqR.ResourceType = kmsg.ConfigResourceTypeGroupConfig
+	reqR.ResourceName = group
+	reqR.Configs = buildAlterConfigsResourceConfig(configOps)
+
+	return alterConfigs(
+		ctx,
+		cl,
+		[]kmsg.AlterConfigsRequestResource{reqR},
+		validateOnly,
+	)
+}
+
 func buildAlterConfigsResourceConfig(
 	configOps ConfigOperations,
 ) []kmsg.AlterConfigsRequestResourceConfig {
@@ -362,6 +528,142 @@ func incrementalAlterTopicConfigs(
 	)
 }
 
+// incrementalAlterClientMetricsConfigs executes a request to perform an incremental alter client metrics configs (Kafka 3.7.0+).
+func incrementalAlterClientMetricsConfigs(
+	ctx context.Context,
+	cl *client.Client,
+	name string,
+	configOps ConfigOperations,
+	validateOnly bool,
+) error {
+	reqR := kmsg.NewIncrementalAlterConfigsRequestResource()
+	reqR.ResourceType = kmsg.ConfigResourceTypeClientMetrics
+	reqR.ResourceName = name
+	reqR.Configs = buildIncrementalAlterConfigsResourceConfig(configOps)
+
+	return incrementalAlterConfigs(
+		ctx,
+		cl,
+		[]kmsg.IncrementalAlterConfigsRequestResource{reqR},
+		validateOnly,
+	)
+}
+
+// incrementalAlterGroupConfigs executes a request to perform an incremental alter group configs (Kafka 4.0.0+).
+func incrementalAlterGroupConfigs(
+	ctx context.Context,
+	cl *client.Client,
+	group string,
+	configOps ConfigOperations,
+	validateOnly bool,
+) error {
+	reqR := kmsg.NewIncrementalAlterConfigsRequestResource()
+	reqR.ResourceType = kmsg.ConfigResourceTypeGroupConfig
+	reqR.ResourceName = group
+	reqR.Configs = buildIncrementalAlterConfigsResourceConfig(configOps)
+
+	return incrementalAlterConfigs(
+		ctx,
+		cl,
+		[]kmsg.IncrementalAlterConfigsRequestResource{reqR},
+		validateOnly,
+	)
+}
+
+// incrementalAlterBrokerLoggerConfigs executes a request to perform an incremental alter of broker logger levels (Kafka 2.4.0+).
+func incrementalAlterBrokerLoggerConfigs(
+	ctx context.Context,
+	cl *client.Client,
+	brokerOps map[string]ConfigOperations,
+	validateOnly bool,
+) error {
+	var resources []kmsg.IncrementalAlterConfigsRequestResource
+	for brokerID, configOps := range brokerOps {
+		reqR := kmsg.NewIncrementalAlterConfigsRequestResource()
+		reqR.ResourceType = kmsg.ConfigResourceTypeBrokerLogger
+		reqR.ResourceName = brokerID
+		reqR.Configs = buildIncrementalAlterConfigsResourceConfig(configOps)
+		resources = append(resources, reqR)
+	}
+
+	return incrementalAlterConfigs(ctx, cl, resources, validateOnly)
+}
+
+// setReplicationThrottle executes a request to throttle the replication of reassigning partitions (Kafka 2.3.0+).
+func setReplicationThrottle(
+	ctx context.Context,
+	cl *client.Client,
+	rate int64,
+	brokers []int32,
+	throttles meta.ReplicationThrottles,
+) error {
+	rateValue := strconv.FormatInt(rate, 10)
+	brokerOps := ConfigOperations{
+		{Name: leaderReplicationThrottledRate, Value: &rateValue, Op: SetConfigOperation},
+		{Name: followerReplicationThrottledRate, Value: &rateValue, Op: SetConfigOperation},
+	}
+
+	var resources []kmsg.IncrementalAlterConfigsRequestResource
+	for _, broker := range brokers {
+		reqR := kmsg.NewIncrementalAlterConfigsRequestResource()
+		reqR.ResourceType = kmsg.ConfigResourceTypeBroker
+		reqR.ResourceName = strconv.Itoa(int(broker))
+		reqR.Configs = buildIncrementalAlterConfigsResourceConfig(brokerOps)
+		resources = append(resources, reqR)
+	}
+	for _, throttle := range throttles {
+		leaderReplicas := strings.Join(throttle.LeaderReplicas, ",")
+		followerReplicas := strings.Join(throttle.FollowerReplicas, ",")
+		topicOps := ConfigOperations{
+			{Name: leaderReplicationThrottledReplicas, Value: &leaderReplicas, Op: SetConfigOperation},
+			{Name: followerReplicationThrottledReplicas, Value: &followerReplicas, Op: SetConfigOperation},
+		}
+
+		reqR := kmsg.NewIncrementalAlterConfigsRequestResource()
+		reqR.ResourceType = kmsg.ConfigResourceTypeTopic
+		reqR.ResourceName = throttle.Topic
+		reqR.Configs = buildIncrementalAlterConfigsResourceConfig(topicOps)
+		resources = append(resources, reqR)
+	}
+
+	return incrementalAlterConfigs(ctx, cl, resources, false)
+}
+
+// removeReplicationThrottle executes a request to remove the replication throttle of brokers and topics (Kafka 2.3.0+).
+func removeReplicationThrottle(
+	ctx context.Context,
+	cl *client.Client,
+	brokers []int32,
+	topics []string,
+) error {
+	brokerOps := ConfigOperations{
+		{Name: leaderReplicationThrottledRate, Op: DeleteConfigOperation},
+		{Name: followerReplicationThrottledRate, Op: DeleteConfigOperation},
+	}
+	topicOps := ConfigOperations{
+		{Name: leaderReplicationThrottledReplicas, Op: DeleteConfigOperation},
+		{Name: followerReplicationThrottledReplicas, Op: DeleteConfigOperation},
+	}
+
+	var resources []kmsg.IncrementalAlterConfigsRequestResource
+	for _, broker := range brokers {
+		reqR := kmsg.NewIncrementalAlterConfigsRequestResource()
+		reqR.ResourceType = kmsg.ConfigResourceTypeBroker
+		reqR.ResourceName = strconv.Itoa(int(broker))
+		reqR.Configs = buildIncrementalAlterConfigsResourceConfig(brokerOps)
+		resources = append(resources, reqR)
+	}
+	for _, topic := range topics {
+		reqR := kmsg.NewIncrementalAlterConfigsRequestResource()
+		reqR.ResourceType = kmsg.ConfigResourceTypeTopic
+		reqR.ResourceName = topic
+		reqR.Configs = buildIncrementalAlterConfigsResourceConfig(topicOps)
+		resources = append(resources, reqR)
+	}
+
+	return incrementalAlterConfigs(ctx, cl, resources, false)
+}
+
 func buildIncrementalAlterConfigsResourceConfig(
 	configOps ConfigOperations,
 ) []kmsg.IncrementalAlterConfigsRequestResourceConfig {
@@ -393,8 +695,8 @@ func incrementalAlterConfigs(
 	}
 	resp := kresp.(*kmsg.IncrementalAlterConfigsResponse)
 
-	if len(resp.Resources) != 1 {
-		return fmt.Errorf("requested %d resource(s) but received %d", 1, len(resp.Resources))
+	if len(resp.Resources) != len(resources) {
+		return fmt.Errorf("requested %d resource(s) but received %d", len(resources), len(resp.Resources))
 	}
 
 	for _, resource := range resp.Resources {
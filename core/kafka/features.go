@@ -0,0 +1,132 @@
+// Package kafka implements the Kafka service handling requests and responses.
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// Feature update types.
+const (
+	upgradeFeatureUpdate       int8 = 1
+	safeDowngradeFeatureUpdate int8 = 2
+)
+
+// FeatureUpdate represents an update of the finalized version level of a feature.
+type FeatureUpdate struct {
+	Name      string
+	Level     int16
+	Downgrade bool
+}
+
+// FeatureUpdates represents a slice of FeatureUpdate.
+type FeatureUpdates []FeatureUpdate
+
+// Contains determines if the specified feature name exists.
+func (f FeatureUpdates) Contains(name string) bool {
+	for _, update := range f {
+		if update.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsDowngrade determines if a downgrade exists.
+func (f FeatureUpdates) ContainsDowngrade() bool {
+	for _, update := range f {
+		if update.Downgrade {
+			return true
+		}
+	}
+	return false
+}
+
+// describeFeatures executes api versions requests to describe the features of brokers (Kafka 2.7.0+).
+func describeFeatures(
+	ctx context.Context,
+	cl *client.Client,
+	brokers []int32,
+) (meta.Features, error) {
+	// Supported features are specific to each broker, so each broker is requested.
+	brokerFeatures := make([]meta.Features, len(brokers))
+	for i, broker := range brokers {
+		req := kmsg.NewApiVersionsRequest()
+		kresp, err := cl.Client.Broker(int(broker)).Request(ctx, &req)
+		if err != nil {
+			return nil, fmt.Errorf("broker %d: %v", broker, err)
+		}
+		resp := kresp.(*kmsg.ApiVersionsResponse)
+
+		if err := kerr.ErrorForCode(resp.ErrorCode); err != nil {
+			return nil, fmt.Errorf("broker %d: %v", broker, err)
+		}
+
+		finalized := make(map[string]int16)
+		for _, f := range resp.FinalizedFeatures {
+			finalized[f.Name] = f.MaxVersionLevel
+		}
+
+		for _, f := range resp.SupportedFeatures {
+			brokerFeatures[i] = append(brokerFeatures[i], meta.Feature{
+				Name:           f.Name,
+				MinVersion:     f.MinVersion,
+				MaxVersion:     f.MaxVersion,
+				FinalizedLevel: finalized[f.Name],
+			})
+		}
+	}
+
+	return meta.IntersectFeatures(brokerFeatures), nil
+}
+
+// updateFeatures executes a request to update the finalized version levels of features (Kafka 2.7.0+).
+func updateFeatures(
+	ctx context.Context,
+	cl *client.Client,
+	updates FeatureUpdates,
+) error {
+	req := kmsg.NewUpdateFeaturesRequest()
+	for _, update := range updates {
+		u := kmsg.NewUpdateFeaturesRequestFeatureUpdate()
+		u.Feature = update.Name
+		u.MaxVersionLevel = update.Level
+		u.AllowDowngrade = update.Downgrade
+		u.UpgradeType = upgradeFeatureUpdate
+		if update.Downgrade {
+			u.UpgradeType = safeDowngradeFeatureUpdate
+		}
+		req.FeatureUpdates = append(req.FeatureUpdates, u)
+	}
+
+	kresp, err := cl.Client.Request(ctx, &req)
+	if err != nil {
+		return err
+	}
+	resp := kresp.(*kmsg.UpdateFeaturesResponse)
+
+	if err := kerr.ErrorForCode(resp.ErrorCode); err != nil {
+		errMsg := err.Error()
+		if resp.ErrorMessage != nil {
+			errMsg = fmt.Sprintf("%s: %s", errMsg, *resp.ErrorMessage)
+		}
+		return fmt.Errorf("%s", errMsg)
+	}
+
+	for _, result := range resp.Results {
+		if err := kerr.ErrorForCode(result.ErrorCode); err != nil {
+			errMsg := err.Error()
+			if result.ErrorMessage != nil {
+				errMsg = fmt.Sprintf("%s: %s", errMsg, *result.ErrorMessage)
+			}
+			return fmt.Errorf("feature %q: %s", result.Feature, errMsg)
+		}
+	}
+
+	return nil
+}
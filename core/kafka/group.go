@@ -0,0 +1,213 @@
+// Package kafka implements the Kafka service handling requests and responses.
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// listGroups executes a request to list the groups of all brokers (Kafka 0.9.0+).
+func listGroups(
+	ctx context.Context,
+	cl *client.Client,
+) ([]string, error) {
+	req := kmsg.NewListGroupsRequest()
+
+	// The request is sharded to all brokers. Each response shard identifies the broker.
+	var groups []string
+	for _, shard := range cl.Client.RequestSharded(ctx, &req) {
+		if shard.Err != nil {
+			return nil, fmt.Errorf("broker %d: %v", shard.Meta.NodeID, shard.Err)
+		}
+		resp := shard.Resp.(*kmsg.ListGroupsResponse)
+
+		if err := kerr.ErrorForCode(resp.ErrorCode); err != nil {
+			return nil, fmt.Errorf("broker %d: %v", shard.Meta.NodeID, err)
+		}
+
+		for _, group := range resp.Groups {
+			groups = append(groups, group.Group)
+		}
+	}
+
+	sort.Strings(groups)
+
+	return groups, nil
+}
+
+// describeGroups executes a request to describe groups (Kafka 0.9.0+).
+func describeGroups(
+	ctx context.Context,
+	cl *client.Client,
+	groups []string,
+) (meta.Groups, error) {
+	req := kmsg.NewDescribeGroupsRequest()
+	req.Groups = groups
+
+	kresp, err := cl.Client.Request(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	resp := kresp.(*kmsg.DescribeGroupsResponse)
+
+	var described meta.Groups
+	for _, group := range resp.Groups {
+		// Groups deleted since being listed, and groups that are not classic groups, are not found.
+		if group.ErrorCode == kerr.GroupIDNotFound.Code {
+			continue
+		}
+		if err := kerr.ErrorForCode(group.ErrorCode); err != nil {
+			errMsg := err.Error()
+			if group.ErrorMessage != nil {
+				errMsg = fmt.Sprintf("%s: %s", errMsg, *group.ErrorMessage)
+			}
+			return nil, fmt.Errorf("group %q: %s", group.Group, errMsg)
+		}
+		described = append(described, meta.Group{
+			Group:        group.Group,
+			ProtocolType: group.ProtocolType,
+			State:        group.State,
+			Members:      len(group.Members),
+		})
+	}
+
+	return described, nil
+}
+
+// fetchGroupOffsets executes a request to fetch the committed offsets of a group (Kafka 0.10.2+).
+func fetchGroupOffsets(
+	ctx context.Context,
+	cl *client.Client,
+	group string,
+) (meta.GroupOffsets, error) {
+	req := kmsg.NewOffsetFetchRequest()
+	req.Group = group
+	// Nil topics fetches the offsets of all topics.
+	req.Topics = nil
+
+	kresp, err := cl.Client.Request(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	resp := kresp.(*kmsg.OffsetFetchResponse)
+
+	if err := kerr.ErrorForCode(resp.ErrorCode); err != nil {
+		return nil, fmt.Errorf("group %q: %v", group, err)
+	}
+
+	offsets := make(meta.GroupOffsets)
+	for _, topic := range resp.Topics {
+		for _, partition := range topic.Partitions {
+			if err := kerr.ErrorForCode(partition.ErrorCode); err != nil {
+				return nil, fmt.Errorf("group %q topic %q partition %d: %v", group, topic.Topic, partition.Partition, err)
+			}
+			if _, ok := offsets[topic.Topic]; !ok {
+				offsets[topic.Topic] = make(map[int32]int64)
+			}
+			offsets[topic.Topic][partition.Partition] = partition.Offset
+		}
+	}
+
+	return offsets, nil
+}
+
+// listOffsetsSince executes a request to list the offsets of the first records produced since a timestamp (Kafka 0.10.1+).
+func listOffsetsSince(
+	ctx context.Context,
+	cl *client.Client,
+	topics map[string][]int32,
+	timestamp int64,
+) (meta.GroupOffsets, error) {
+	req := kmsg.NewListOffsetsRequest()
+	for topic, partitions := range topics {
+		t := kmsg.NewListOffsetsRequestTopic()
+		t.Topic = topic
+		for _, partition := range partitions {
+			p := kmsg.NewListOffsetsRequestTopicPartition()
+			p.Partition = partition
+			p.Timestamp = timestamp
+			t.Partitions = append(t.Partitions, p)
+		}
+		req.Topics = append(req.Topics, t)
+	}
+
+	kresp, err := cl.Client.Request(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	resp := kresp.(*kmsg.ListOffsetsResponse)
+
+	offsets := make(meta.GroupOffsets)
+	for _, topic := range resp.Topics {
+		for _, partition := range topic.Partitions {
+			// Offsets of topics or partitions that no longer exist are ignored.
+			if partition.ErrorCode == kerr.UnknownTopicOrPartition.Code {
+				continue
+			}
+			if err := kerr.ErrorForCode(partition.ErrorCode); err != nil {
+				return nil, fmt.Errorf("topic %q partition %d: %v", topic.Topic, partition.Partition, err)
+			}
+			if _, ok := offsets[topic.Topic]; !ok {
+				offsets[topic.Topic] = make(map[int32]int64)
+			}
+			offsets[topic.Topic][partition.Partition] = partition.Offset
+		}
+	}
+
+	return offsets, nil
+}
+
+// deleteGroups executes a request to delete groups (Kafka 1.1.0+).
+func deleteGroups(
+	ctx context.Context,
+	cl *client.Client,
+	groups []string,
+) error {
+	req := kmsg.NewDeleteGroupsRequest()
+	req.Groups = groups
+
+	kresp, err := cl.Client.Request(ctx, &req)
+	if err != nil {
+		return err
+	}
+	resp := kresp.(*kmsg.DeleteGroupsResponse)
+
+	for _, group := range resp.Groups {
+		if err := kerr.ErrorForCode(group.ErrorCode); err != nil {
+			return fmt.Errorf("group %q: %v", group.Group, err)
+		}
+	}
+
+	return nil
+}
+
+// isGroupConfigSupported determines if the cluster supports group config resources (Kafka 4.0.0+).
+// Brokers that do not support the group config resource type reject describing its configs as an invalid request.
+func isGroupConfigSupported(ctx context.Context, cl *client.Client) (bool, error) {
+	req := kmsg.NewDescribeConfigsRequest()
+
+	res := kmsg.NewDescribeConfigsRequestResource()
+	res.ResourceType = kmsg.ConfigResourceTypeGroupConfig
+	// Configs of groups that do not exist are described with their default values.
+	res.ResourceName = "kdef-group-config-probe"
+	req.Resources = append(req.Resources, res)
+
+	kresp, err := cl.Client.Request(ctx, &req)
+	if err != nil {
+		return false, err
+	}
+	resp := kresp.(*kmsg.DescribeConfigsResponse)
+
+	if len(resp.Resources) == 0 {
+		return false, nil
+	}
+
+	// Other errors, e.g. authorization failures, are surfaced by the requests for group configs.
+	return resp.Resources[0].ErrorCode != kerr.InvalidRequest.Code, nil
+}
@@ -0,0 +1,133 @@
+// Package kafka implements the Kafka service handling requests and responses.
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// describeLogDirs executes a request to describe the log directories of all brokers (Kafka 1.0.0+).
+func describeLogDirs(
+	ctx context.Context,
+	cl *client.Client,
+) (meta.LogDirs, meta.ReplicaLogDirs, error) {
+	req := kmsg.NewDescribeLogDirsRequest()
+	// Nil topics describes all topics.
+	req.Topics = nil
+
+	// The request is sharded to all brokers. Each response shard identifies the broker.
+	var logDirs meta.LogDirs
+	var replicas meta.ReplicaLogDirs
+	for _, shard := range cl.Client.RequestSharded(ctx, &req) {
+		if shard.Err != nil {
+			return nil, nil, fmt.Errorf("broker %d: %v", shard.Meta.NodeID, shard.Err)
+		}
+		resp := shard.Resp.(*kmsg.DescribeLogDirsResponse)
+
+		if err := kerr.ErrorForCode(resp.ErrorCode); err != nil {
+			return nil, nil, fmt.Errorf("broker %d: %v", shard.Meta.NodeID, err)
+		}
+
+		for _, dir := range resp.Dirs {
+			if err := kerr.ErrorForCode(dir.ErrorCode); err != nil {
+				return nil, nil, fmt.Errorf("broker %d log directory %q: %v", shard.Meta.NodeID, dir.Dir, err)
+			}
+			logDirs = append(logDirs, meta.LogDir{
+				Broker:      shard.Meta.NodeID,
+				Dir:         dir.Dir,
+				TotalBytes:  dir.TotalBytes,
+				UsableBytes: dir.UsableBytes,
+			})
+			for _, topic := range dir.Topics {
+				for _, partition := range topic.Partitions {
+					replicas = append(replicas, meta.ReplicaLogDir{
+						Broker:    shard.Meta.NodeID,
+						Dir:       dir.Dir,
+						Topic:     topic.Topic,
+						Partition: partition.Partition,
+						Size:      partition.Size,
+						IsFuture:  partition.IsFuture,
+					})
+				}
+			}
+		}
+	}
+
+	return logDirs, replicas, nil
+}
+
+// alterReplicaLogDirs executes requests to move partition replicas between log directories (Kafka 1.1.0+).
+// A request is sent to each broker hosting replicas to move.
+func alterReplicaLogDirs(
+	ctx context.Context,
+	cl *client.Client,
+	moves meta.ReplicaLogDirs,
+) error {
+	var brokers []int32
+	reqs := make(map[int32]*kmsg.AlterReplicaLogDirsRequest)
+	for _, move := range moves {
+		req, ok := reqs[move.Broker]
+		if !ok {
+			r := kmsg.NewAlterReplicaLogDirsRequest()
+			req = &r
+			reqs[move.Broker] = req
+			brokers = append(brokers, move.Broker)
+		}
+
+		d := -1
+		for i, dir := range req.Dirs {
+			if dir.Dir == move.Dir {
+				d = i
+			}
+		}
+		if d < 0 {
+			dir := kmsg.NewAlterReplicaLogDirsRequestDir()
+			dir.Dir = move.Dir
+			req.Dirs = append(req.Dirs, dir)
+			d = len(req.Dirs) - 1
+		}
+
+		t := -1
+		for i, topic := range req.Dirs[d].Topics {
+			if topic.Topic == move.Topic {
+				t = i
+			}
+		}
+		if t < 0 {
+			topic := kmsg.NewAlterReplicaLogDirsRequestDirTopic()
+			topic.Topic = move.Topic
+			req.Dirs[d].Topics = append(req.Dirs[d].Topics, topic)
+			t = len(req.Dirs[d].Topics) - 1
+		}
+		req.Dirs[d].Topics[t].Partitions = append(req.Dirs[d].Topics[t].Partitions, move.Partition)
+	}
+
+	for _, brokerID := range brokers {
+		kresp, err := cl.Client.Broker(int(brokerID)).Request(ctx, reqs[brokerID])
+		if err != nil {
+			return fmt.Errorf("broker %d: %v", brokerID, err)
+		}
+		resp := kresp.(*kmsg.AlterReplicaLogDirsResponse)
+
+		for _, topic := range resp.Topics {
+			for _, partition := range topic.Partitions {
+				if err := kerr.ErrorForCode(partition.ErrorCode); err != nil {
+					return fmt.Errorf(
+						"broker %d partition %d of topic %q: %v",
+						brokerID,
+						partition.Partition,
+						topic.Topic,
+						err,
+					)
+				}
+			}
+		}
+	}
+
+	return nil
+}
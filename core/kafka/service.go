@@ -152,6 +152,171 @@ func (s *Service) AlterTopicConfigs(
 	return alterTopicConfigs(ctx, s.cl, topic, configOps, validateOnly)
 }
 
+// ListClientMetricsResources executes a request to list client metrics subscriptions (Kafka 3.7.0+).
+func (s *Service) ListClientMetricsResources(ctx context.Context) ([]string, error) {
+	return listClientMetricsResources(ctx, s.cl)
+}
+
+// DescribeClientMetricsConfigs executes a request to describe client metrics subscription configs (Kafka 3.7.0+).
+func (s *Service) DescribeClientMetricsConfigs(ctx context.Context, name string) (def.Configs, error) {
+	return describeClientMetricsConfigs(ctx, s.cl, name)
+}
+
+// AlterClientMetricsConfigs executes a request to alter client metrics subscription configs (Kafka 3.7.0+).
+func (s *Service) AlterClientMetricsConfigs(
+	ctx context.Context,
+	name string,
+	configOps ConfigOperations,
+	validateOnly bool,
+) error {
+	incrementalAlter, err := s.getIncrementalAlter(ctx)
+	if err != nil {
+		return err
+	}
+	if incrementalAlter {
+		return incrementalAlterClientMetricsConfigs(ctx, s.cl, name, configOps, validateOnly)
+	}
+	return alterClientMetricsConfigs(ctx, s.cl, name, configOps, validateOnly)
+}
+
+// IsGroupConfigSupported determines if the cluster supports group config resources (Kafka 4.0.0+).
+func (s *Service) IsGroupConfigSupported(ctx context.Context) (bool, error) {
+	return isGroupConfigSupported(ctx, s.cl)
+}
+
+// DescribeGroupConfigs executes a request to describe group configs (Kafka 4.0.0+).
+func (s *Service) DescribeGroupConfigs(ctx context.Context, groups []string) ([]ResourceConfigs, error) {
+	return describeGroupConfigs(ctx, s.cl, groups)
+}
+
+// AlterGroupConfigs executes a request to alter group configs (Kafka 4.0.0+).
+func (s *Service) AlterGroupConfigs(
+	ctx context.Context,
+	group string,
+	configOps ConfigOperations,
+	validateOnly bool,
+) error {
+	incrementalAlter, err := s.getIncrementalAlter(ctx)
+	if err != nil {
+		return err
+	}
+	if incrementalAlter {
+		return incrementalAlterGroupConfigs(ctx, s.cl, group, configOps, validateOnly)
+	}
+	return alterGroupConfigs(ctx, s.cl, group, configOps, validateOnly)
+}
+
+// DescribeBrokerLoggerConfigs executes a request to describe the logger levels of brokers (Kafka 2.4.0+).
+func (s *Service) DescribeBrokerLoggerConfigs(ctx context.Context, brokerIDs []string) ([]ResourceConfigs, error) {
+	return describeBrokerLoggerConfigs(ctx, s.cl, brokerIDs)
+}
+
+// AlterBrokerLoggerConfigs executes a request to alter the logger levels of brokers (Kafka 2.4.0+).
+func (s *Service) AlterBrokerLoggerConfigs(
+	ctx context.Context,
+	brokerOps map[string]ConfigOperations,
+	validateOnly bool,
+) error {
+	incrementalAlter, err := s.getIncrementalAlter(ctx)
+	if err != nil {
+		return err
+	}
+	if !incrementalAlter {
+		// Broker logger levels can only be altered incrementally.
+		return fmt.Errorf("altering broker logger levels requires incremental alter configs (Kafka 2.4.0+)")
+	}
+	return incrementalAlterBrokerLoggerConfigs(ctx, s.cl, brokerOps, validateOnly)
+}
+
+// SetReplicationThrottle executes a request to throttle the replication of reassigning partitions (Kafka 2.3.0+).
+func (s *Service) SetReplicationThrottle(
+	ctx context.Context,
+	rate int64,
+	brokers []int32,
+	throttles meta.ReplicationThrottles,
+) error {
+	incrementalAlter, err := s.getIncrementalAlter(ctx)
+	if err != nil {
+		return err
+	}
+	if !incrementalAlter {
+		// Non-incremental alter configs would overwrite existing dynamic configs.
+		return fmt.Errorf("replication throttling requires incremental alter configs (Kafka 2.3.0+)")
+	}
+	return setReplicationThrottle(ctx, s.cl, rate, brokers, throttles)
+}
+
+// RemoveReplicationThrottle executes a request to remove the replication throttle of brokers and topics (Kafka 2.3.0+).
+func (s *Service) RemoveReplicationThrottle(
+	ctx context.Context,
+	brokers []int32,
+	topics []string,
+) error {
+	incrementalAlter, err := s.getIncrementalAlter(ctx)
+	if err != nil {
+		return err
+	}
+	if !incrementalAlter {
+		return fmt.Errorf("replication throttling requires incremental alter configs (Kafka 2.3.0+)")
+	}
+	return removeReplicationThrottle(ctx, s.cl, brokers, topics)
+}
+
+// ========================= Features =========================
+
+// DescribeFeatures executes requests to describe the supported and finalized features of brokers (Kafka 2.7.0+).
+func (s *Service) DescribeFeatures(ctx context.Context, brokers []int32) (meta.Features, error) {
+	return describeFeatures(ctx, s.cl, brokers)
+}
+
+// UpdateFeatures executes a request to update the finalized version levels of features (Kafka 2.7.0+).
+func (s *Service) UpdateFeatures(ctx context.Context, updates FeatureUpdates) error {
+	return updateFeatures(ctx, s.cl, updates)
+}
+
+// ========================= Groups =========================
+
+// ListGroups executes a request to list the groups of all brokers (Kafka 0.9.0+).
+func (s *Service) ListGroups(ctx context.Context) ([]string, error) {
+	return listGroups(ctx, s.cl)
+}
+
+// DescribeGroups executes a request to describe groups (Kafka 0.9.0+).
+func (s *Service) DescribeGroups(ctx context.Context, groups []string) (meta.Groups, error) {
+	return describeGroups(ctx, s.cl, groups)
+}
+
+// FetchGroupOffsets executes a request to fetch the committed offsets of a group (Kafka 0.10.2+).
+func (s *Service) FetchGroupOffsets(ctx context.Context, group string) (meta.GroupOffsets, error) {
+	return fetchGroupOffsets(ctx, s.cl, group)
+}
+
+// ListOffsetsSince executes a request to list the offsets of the first records produced since a timestamp (Kafka 0.10.1+).
+func (s *Service) ListOffsetsSince(
+	ctx context.Context,
+	topics map[string][]int32,
+	timestamp int64,
+) (meta.GroupOffsets, error) {
+	return listOffsetsSince(ctx, s.cl, topics, timestamp)
+}
+
+// DeleteGroups executes a request to delete groups (Kafka 1.1.0+).
+func (s *Service) DeleteGroups(ctx context.Context, groups []string) error {
+	return deleteGroups(ctx, s.cl, groups)
+}
+
+// ========================= Log Dirs =========================
+
+// DescribeLogDirs executes a request to describe the log directories of all brokers (Kafka 1.0.0+).
+func (s *Service) DescribeLogDirs(ctx context.Context) (meta.LogDirs, meta.ReplicaLogDirs, error) {
+	return describeLogDirs(ctx, s.cl)
+}
+
+// AlterReplicaLogDirs executes requests to move partition replicas between log directories (Kafka 1.1.0+).
+func (s *Service) AlterReplicaLogDirs(ctx context.Context, moves meta.ReplicaLogDirs) error {
+	return alterReplicaLogDirs(ctx, s.cl, moves)
+}
+
 // ========================= Topic ============================
 
 // TryRequestTopic executes a request for the metadata of a topic that may or may not exist (Kafka 0.11.0+).
@@ -195,13 +360,17 @@ func (s *Service) ListPartitionReassignments(
 	return listPartitionReassignments(ctx, s.cl, topic, partitions)
 }
 
+// ListAllPartitionReassignments executes a request to list partition reassignments for all topics (Kafka 2.4.0+).
+func (s *Service) ListAllPartitionReassignments(ctx context.Context) (meta.PartitionReassignments, error) {
+	return listAllPartitionReassignments(ctx, s.cl)
+}
+
 // AlterPartitionAssignments executes a request to alter partition assignments (Kafka 2.4.0+).
 func (s *Service) AlterPartitionAssignments(
 	ctx context.Context,
-	topic string,
-	assignments def.PartitionAssignments,
+	assignments meta.TopicPartitionAssignments,
 ) error {
-	return alterPartitionAssignments(ctx, s.cl, topic, assignments)
+	return alterPartitionAssignments(ctx, s.cl, assignments)
 }
 
 // ElectLeaders executes a request to elect preferred partition leaders (Kafka 2.4.0+).
@@ -213,6 +382,14 @@ func (s *Service) ElectLeaders(
 	return electLeaders(ctx, s.cl, topic, partitions)
 }
 
+// ElectUncleanLeaders executes a request to elect partition leaders from out-of-sync replicas (Kafka 2.4.0+).
+func (s *Service) ElectUncleanLeaders(
+	ctx context.Context,
+	partitions map[string][]int32,
+) (meta.PartitionElections, error) {
+	return electUncleanLeaders(ctx, s.cl, partitions)
+}
+
 // ========================= ACL =============================
 
 // DescribeResourceACLs executes a request to describe ACLs of a specific resource (Kafka 0.11.0+).
@@ -233,6 +410,14 @@ func (s *Service) DescribeAllResourceACLs(
 	return describeAllResourceACLs(ctx, s.cl, resourceType)
 }
 
+// DescribePrincipalACLs executes a request to describe ACLs of a specific principal for all resources (Kafka 2.0.0+).
+func (s *Service) DescribePrincipalACLs(
+	ctx context.Context,
+	principal string,
+) ([]ResourceACLs, error) {
+	return describePrincipalACLs(ctx, s.cl, principal)
+}
+
 // CreateACLs executes a request to create ACLs (Kafka 0.11.0+).
 func (s *Service) CreateACLs(
 	ctx context.Context,
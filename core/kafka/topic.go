@@ -12,6 +12,12 @@ import (
 	"github.com/twmb/franz-go/pkg/kmsg"
 )
 
+// Leader election types.
+const (
+	preferredElection int8 = 0
+	uncleanElection   int8 = 1
+)
+
 // tryRequestTopic executes a request for the metadata of a topic that may or may not exist (Kafka 0.11.0+).
 func tryRequestTopic(
 	ctx context.Context,
@@ -173,23 +179,27 @@ func createPartitions(
 func alterPartitionAssignments(
 	ctx context.Context,
 	cl *client.Client,
-	topic string,
-	assignments def.PartitionAssignments,
+	assignments meta.TopicPartitionAssignments,
 ) error {
-	partitions := make([]kmsg.AlterPartitionAssignmentsRequestTopicPartition, len(assignments))
-	for i, replicas := range assignments {
-		partitions[i] = kmsg.AlterPartitionAssignmentsRequestTopicPartition{
-			Partition: int32(i),
-			Replicas:  replicas,
+	var topics []kmsg.AlterPartitionAssignmentsRequestTopic
+	topicIndexes := make(map[string]int)
+	for _, assignment := range assignments {
+		i, ok := topicIndexes[assignment.Topic]
+		if !ok {
+			t := kmsg.NewAlterPartitionAssignmentsRequestTopic()
+			t.Topic = assignment.Topic
+			topics = append(topics, t)
+			i = len(topics) - 1
+			topicIndexes[assignment.Topic] = i
 		}
+		p := kmsg.NewAlterPartitionAssignmentsRequestTopicPartition()
+		p.Partition = assignment.Partition
+		p.Replicas = assignment.Replicas
+		topics[i].Partitions = append(topics[i].Partitions, p)
 	}
 
-	t := kmsg.NewAlterPartitionAssignmentsRequestTopic()
-	t.Topic = topic
-	t.Partitions = partitions
-
 	req := kmsg.NewAlterPartitionAssignmentsRequest()
-	req.Topics = append(req.Topics, t)
+	req.Topics = topics
 	req.TimeoutMillis = cl.TimeoutMs()
 
 	kresp, err := cl.Client.Request(ctx, &req)
@@ -198,8 +208,8 @@ func alterPartitionAssignments(
 	}
 	resp := kresp.(*kmsg.AlterPartitionAssignmentsResponse)
 
-	if len(resp.Topics) != 1 {
-		return fmt.Errorf("requested %d topic(s) but received %d", 1, len(resp.Topics))
+	if len(resp.Topics) != len(topics) {
+		return fmt.Errorf("requested %d topic(s) but received %d", len(topics), len(resp.Topics))
 	}
 
 	if err := kerr.ErrorForCode(resp.ErrorCode); err != nil {
@@ -240,6 +250,28 @@ func listPartitionReassignments(
 	req.Topics = append(req.Topics, t)
 	req.TimeoutMillis = cl.TimeoutMs()
 
+	return requestPartitionReassignments(ctx, cl, req)
+}
+
+// listAllPartitionReassignments executes a request to list partition reassignments for all topics (Kafka 2.4.0+).
+func listAllPartitionReassignments(
+	ctx context.Context,
+	cl *client.Client,
+) (meta.PartitionReassignments, error) {
+	req := kmsg.NewListPartitionReassignmentsRequest()
+	// Null topics lists reassignments for all topics.
+	req.Topics = nil
+	req.TimeoutMillis = cl.TimeoutMs()
+
+	return requestPartitionReassignments(ctx, cl, req)
+}
+
+// requestPartitionReassignments executes a request to list partition reassignments (Kafka 2.4.0+).
+func requestPartitionReassignments(
+	ctx context.Context,
+	cl *client.Client,
+	req kmsg.ListPartitionReassignmentsRequest,
+) (meta.PartitionReassignments, error) {
 	kresp, err := cl.Client.Request(ctx, &req)
 	if err != nil {
 		return nil, err
@@ -255,9 +287,10 @@ func listPartitionReassignments(
 	}
 
 	var reassignments meta.PartitionReassignments
-	if len(resp.Topics) > 0 {
-		for _, p := range resp.Topics[0].Partitions {
+	for _, t := range resp.Topics {
+		for _, p := range t.Partitions {
 			reassignments = append(reassignments, meta.PartitionReassignment{
+				Topic:            t.Topic,
 				Partition:        p.Partition,
 				Replicas:         p.Replicas,
 				AddingReplicas:   p.AddingReplicas,
@@ -284,7 +317,7 @@ func electLeaders(
 
 	req := kmsg.NewElectLeadersRequest()
 	req.Topics = []kmsg.ElectLeadersRequestTopic{reqT}
-	req.ElectionType = 0
+	req.ElectionType = preferredElection
 	req.TimeoutMillis = cl.TimeoutMs()
 
 	kresp, err := cl.Client.Request(ctx, &req)
@@ -319,3 +352,58 @@ func electLeaders(
 
 	return nil
 }
+
+// electUncleanLeaders executes a request to elect partition leaders from out-of-sync replicas (Kafka 2.4.0+).
+// Election errors are returned per partition.
+func electUncleanLeaders(
+	ctx context.Context,
+	cl *client.Client,
+	partitions map[string][]int32,
+) (meta.PartitionElections, error) {
+	req := kmsg.NewElectLeadersRequest()
+	for topic, p := range partitions {
+		reqT := kmsg.NewElectLeadersRequestTopic()
+		reqT.Topic = topic
+		reqT.Partitions = p
+		req.Topics = append(req.Topics, reqT)
+	}
+	req.ElectionType = uncleanElection
+	req.TimeoutMillis = cl.TimeoutMs()
+
+	kresp, err := cl.Client.Request(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	resp := kresp.(*kmsg.ElectLeadersResponse)
+
+	if err := kerr.ErrorForCode(resp.ErrorCode); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Topics) != len(partitions) {
+		return nil, fmt.Errorf("requested %d topic(s) but received %d", len(partitions), len(resp.Topics))
+	}
+
+	var elections meta.PartitionElections
+	for _, topic := range resp.Topics {
+		for _, partition := range topic.Partitions {
+			election := meta.PartitionElection{
+				Topic:     topic.Topic,
+				Partition: partition.Partition,
+			}
+			// The partition already has a leader if an election is not needed.
+			if err := kerr.ErrorForCode(partition.ErrorCode); err != nil && partition.ErrorCode != kerr.ElectionNotNeeded.Code {
+				errMsg := err.Error()
+				if partition.ErrorMessage != nil {
+					errMsg = fmt.Sprintf("%s: %s", errMsg, *partition.ErrorMessage)
+				}
+				election.Err = errMsg
+			}
+			elections = append(elections, election)
+		}
+	}
+
+	elections.Sort()
+
+	return elections, nil
+}
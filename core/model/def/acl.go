@@ -2,13 +2,14 @@
 package def
 
 import (
-	"encoding/json"
 	"fmt"
+	"net"
+	"strconv"
 	"strings"
 
 	"github.com/bradfitz/slice" //nolint
-	"github.com/ghodss/yaml"
 	"github.com/gotidy/copy"
+	"github.com/peter-evans/kdef/core/model/meta"
 	"github.com/peter-evans/kdef/core/model/opt"
 	"github.com/peter-evans/kdef/core/util/str"
 )
@@ -43,8 +44,91 @@ var aclOperations = []string{
 	"IDEMPOTENT_WRITE",
 }
 
+// aclResourceTypeOperations are the operations valid for each resource type, excluding "ALL".
+var aclResourceTypeOperations = map[string][]string{
+	"topic": {
+		"READ",
+		"WRITE",
+		"CREATE",
+		"DELETE",
+		"ALTER",
+		"DESCRIBE",
+		"DESCRIBE_CONFIGS",
+		"ALTER_CONFIGS",
+	},
+	"group": {
+		"READ",
+		"DELETE",
+		"DESCRIBE",
+		"DESCRIBE_CONFIGS",
+		"ALTER_CONFIGS",
+	},
+	"cluster": {
+		"CREATE",
+		"ALTER",
+		"DESCRIBE",
+		"CLUSTER_ACTION",
+		"DESCRIBE_CONFIGS",
+		"ALTER_CONFIGS",
+		"IDEMPOTENT_WRITE",
+	},
+	"transactional_id": {
+		"WRITE",
+		"DESCRIBE",
+	},
+	"delegation_token": {
+		"DESCRIBE",
+	},
+}
+
 var aclPermissionTypes = []string{"ALLOW", "DENY"}
 
+// DefaultACLPrincipalTypes are the principal types allowed in definitions by default.
+var DefaultACLPrincipalTypes = []string{"User"}
+
+// validateACLPrincipal validates that a principal is in the format "Type:name".
+func validateACLPrincipal(principal string) error {
+	principalType, name, ok := strings.Cut(principal, ":")
+	if !ok || len(principalType) == 0 || len(name) == 0 {
+		return fmt.Errorf("principal %q must be in the format \"Type:name\"", principal)
+	}
+	return nil
+}
+
+// validateACLPrincipalType validates that the type of a principal is one of the allowed principal types.
+func validateACLPrincipalType(principal string, principalTypes []string) error {
+	principalType, _, _ := strings.Cut(principal, ":")
+	if !str.Contains(principalType, principalTypes) {
+		return fmt.Errorf("principal %q has type %q but principal type must be one of %q",
+			principal, principalType, strings.Join(principalTypes, "|"))
+	}
+	return nil
+}
+
+// validateACLHost validates that a host is an IP address or the wildcard host.
+func validateACLHost(host string) error {
+	if host == "*" || net.ParseIP(host) != nil {
+		return nil
+	}
+	if _, _, err := net.ParseCIDR(host); err == nil {
+		return fmt.Errorf("host %q is a CIDR range but acls only match exact IP addresses or \"*\"", host)
+	}
+	return fmt.Errorf("host %q must be an IP address or \"*\"", host)
+}
+
+// validateACLOperation validates that an operation is valid for a resource type.
+func validateACLOperation(operation string, resourceType string) error {
+	if !str.Contains(operation, aclOperations) {
+		return fmt.Errorf("acl operation %q must be one of %q", operation, strings.Join(aclOperations, "|"))
+	}
+	validOperations, ok := aclResourceTypeOperations[resourceType]
+	if operation != "ALL" && ok && !str.Contains(operation, validOperations) {
+		return fmt.Errorf("acl operation %q is not valid for resource type %q and must be one of %q",
+			operation, resourceType, strings.Join(append([]string{"ALL"}, validOperations...), "|"))
+	}
+	return nil
+}
+
 // ACLEntryGroup represents an ACL entry group.
 type ACLEntryGroup struct {
 	Principals     []string `json:"principals"`
@@ -56,26 +140,90 @@ type ACLEntryGroup struct {
 // ACLEntryGroups represents a slice of ACL entry groups.
 type ACLEntryGroups []ACLEntryGroup
 
+// validate validates an ACL entry group.
+// The paths of field errors are relative to the group.
+func (g ACLEntryGroup) validate() error {
+	if len(g.Principals) == 0 {
+		return fieldErrorf("principals", "principals are missing from acl entry group")
+	}
+	if len(g.Hosts) == 0 {
+		return fieldErrorf("hosts", "hosts are missing from acl entry group")
+	}
+	if len(g.Operations) == 0 {
+		return fieldErrorf("operations", "operations are missing from acl entry group")
+	}
+	for i, operation := range g.Operations {
+		if !str.Contains(operation, aclOperations) {
+			return fieldErrorf(
+				fmt.Sprintf("operations.%d", i),
+				"acl operation %q must be one of %q",
+				operation,
+				strings.Join(aclOperations, "|"),
+			)
+		}
+	}
+	if !str.Contains(g.PermissionType, aclPermissionTypes) {
+		return fieldErrorf("permissionType", "acl permission type must be one of %q", strings.Join(aclPermissionTypes, "|"))
+	}
+
+	return nil
+}
+
+// validateEntries validates the principals, hosts and operations of an ACL entry group for a resource type.
+// The paths of field errors are relative to the group.
+func (g ACLEntryGroup) validateEntries(resourceType string) error {
+	for i, principal := range g.Principals {
+		if err := validateACLPrincipal(principal); err != nil {
+			return nestFieldError(err, fmt.Sprintf("principals.%d", i), "")
+		}
+	}
+	for i, host := range g.Hosts {
+		if err := validateACLHost(host); err != nil {
+			return nestFieldError(err, fmt.Sprintf("hosts.%d", i), "")
+		}
+	}
+	for i, operation := range g.Operations {
+		if err := validateACLOperation(operation, resourceType); err != nil {
+			return nestFieldError(err, fmt.Sprintf("operations.%d", i), "")
+		}
+	}
+
+	return nil
+}
+
 // Validate validates ACL entry groups.
+// The paths of field errors are relative to the groups.
 func (a ACLEntryGroups) Validate() error {
-	for _, group := range a {
-		if len(group.Principals) == 0 {
-			return fmt.Errorf("principals are missing from acl entry group")
-		}
-		if len(group.Hosts) == 0 {
-			return fmt.Errorf("hosts are missing from acl entry group")
+	for i, group := range a {
+		if err := group.validate(); err != nil {
+			return nestFieldError(err, strconv.Itoa(i), "")
 		}
-		if len(group.Operations) == 0 {
-			return fmt.Errorf("operations are missing from acl entry group")
+	}
+
+	return nil
+}
+
+// ValidateEntries validates the principals, hosts and operations of ACL entry groups for a resource type.
+// The paths of field errors are relative to the groups.
+func (a ACLEntryGroups) ValidateEntries(resourceType string) error {
+	for i, group := range a {
+		if err := group.validateEntries(resourceType); err != nil {
+			return nestFieldError(err, strconv.Itoa(i), "")
 		}
-		for _, operation := range group.Operations {
-			if !str.Contains(operation, aclOperations) {
-				return fmt.Errorf("acl operation must be one of %q", strings.Join(aclOperations, "|"))
+	}
+
+	return nil
+}
+
+// ValidatePrincipalTypes validates that the principals of ACL entry groups have allowed principal types.
+// The paths of field errors are relative to the groups.
+func (a ACLEntryGroups) ValidatePrincipalTypes(principalTypes []string) error {
+	for i, group := range a {
+		for j, principal := range group.Principals {
+			if err := validateACLPrincipalType(principal, principalTypes); err != nil {
+				return nestFieldError(err, fmt.Sprintf("%d.principals.%d", i, j), "")
 			}
 		}
-		if !str.Contains(group.PermissionType, aclPermissionTypes) {
-			return fmt.Errorf("acl permission type must be one of %q", strings.Join(aclPermissionTypes, "|"))
-		}
 	}
 
 	return nil
@@ -120,6 +268,7 @@ func (a ACLEntryGroups) Sort() {
 // ACLSpecDefinition represents an ACL spec definition.
 type ACLSpecDefinition struct {
 	ACLs                ACLEntryGroups `json:"acls,omitempty"`
+	Roles               ACLRoleGroups  `json:"roles,omitempty"`
 	DeleteUndefinedACLs bool           `json:"deleteUndefinedAcls"`
 }
 
@@ -145,26 +294,55 @@ func (a ACLDefinition) Validate() error {
 	}
 
 	if len(a.Metadata.Type) == 0 {
-		return fmt.Errorf("metadata type must be supplied")
+		return fieldErrorf("metadata.type", "metadata type must be supplied")
 	}
 
 	if !str.Contains(a.Metadata.Type, aclResourceTypes) {
-		return fmt.Errorf("metadata type must be one of %q", strings.Join(aclResourceTypes, "|"))
+		return fieldErrorf("metadata.type", "metadata type must be one of %q", strings.Join(aclResourceTypes, "|"))
 	}
 
 	if a.Metadata.Type == "cluster" && a.Metadata.Name != "kafka-cluster" {
-		return fmt.Errorf("metadata name must be \"kafka-cluster\" when type is \"cluster\"")
+		return fieldErrorf("metadata.name", "metadata name must be \"kafka-cluster\" when type is \"cluster\"")
 	}
 
 	if len(a.Metadata.ResourcePatternType) == 0 {
-		return fmt.Errorf("metadata resource pattern type must be supplied")
+		return fieldErrorf("metadata.resourcePatternType", "metadata resource pattern type must be supplied")
 	}
 
 	if !str.Contains(a.Metadata.ResourcePatternType, aclResourcePatternTypes) {
-		return fmt.Errorf("metadata resource pattern type must be one of %q", strings.Join(aclResourcePatternTypes, "|"))
+		return fieldErrorf(
+			"metadata.resourcePatternType",
+			"metadata resource pattern type must be one of %q",
+			strings.Join(aclResourcePatternTypes, "|"),
+		)
+	}
+
+	if err := a.Spec.Roles.Validate(); err != nil {
+		return nestFieldError(err, "spec.roles", "")
+	}
+
+	if err := a.Spec.ACLs.Validate(); err != nil {
+		return nestFieldError(err, "spec.acls", "")
 	}
 
-	return a.Spec.ACLs.Validate()
+	if err := a.Spec.ACLs.ValidateEntries(a.Metadata.Type); err != nil {
+		return nestFieldError(err, "spec.acls", "")
+	}
+
+	return nil
+}
+
+// ValidateWithPrincipalTypes further validates the definition using the allowed principal types.
+func (a ACLDefinition) ValidateWithPrincipalTypes(principalTypes []string) error {
+	if err := a.Spec.Roles.ValidatePrincipalTypes(principalTypes); err != nil {
+		return nestFieldError(err, "spec.roles", "")
+	}
+
+	if err := a.Spec.ACLs.ValidatePrincipalTypes(principalTypes); err != nil {
+		return nestFieldError(err, "spec.acls", "")
+	}
+
+	return nil
 }
 
 // NewACLDefinition creates an ACL definition from metadata and config.
@@ -193,17 +371,8 @@ func LoadACLDefinition(
 ) (ACLDefinition, error) {
 	var def ACLDefinition
 
-	switch format {
-	case opt.YAMLFormat:
-		if err := yaml.Unmarshal([]byte(defDoc), &def); err != nil {
-			return def, err
-		}
-	case opt.JSONFormat:
-		if err := json.Unmarshal([]byte(defDoc), &def); err != nil {
-			return def, err
-		}
-	default:
-		return def, fmt.Errorf("unsupported format")
+	if err := unmarshalDefinition(defDoc, format, &def); err != nil {
+		return def, err
 	}
 
 	// Set defaults
@@ -213,3 +382,57 @@ func LoadACLDefinition(
 
 	return def, nil
 }
+
+// ACLBindings returns the ACL entries of the definition, including those granted by roles, bound to its resource.
+func (a ACLDefinition) ACLBindings(roles ACLRoles) (meta.ACLBindings, error) {
+	roleACLs, err := a.Spec.Roles.Expand(roles, a.Metadata.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	var bindings meta.ACLBindings
+	for _, group := range append(append(ACLEntryGroups{}, a.Spec.ACLs...), roleACLs...) {
+		for _, principal := range group.Principals {
+			for _, host := range group.Hosts {
+				for _, operation := range group.Operations {
+					bindings = append(bindings, meta.ACLBinding{
+						ResourceType:        a.Metadata.Type,
+						ResourceName:        a.Metadata.Name,
+						ResourcePatternType: a.Metadata.ResourcePatternType,
+						Principal:           principal,
+						Host:                host,
+						Operation:           operation,
+						PermissionType:      group.PermissionType,
+					})
+				}
+			}
+		}
+	}
+
+	return bindings, nil
+}
+
+// ValidateACLCheckRequest validates a request to be authorized by ACLs.
+func ValidateACLCheckRequest(req meta.ACLCheckRequest) error {
+	if len(req.Principal) == 0 {
+		return fmt.Errorf("principal must be supplied")
+	}
+
+	if len(req.Host) == 0 {
+		return fmt.Errorf("host must be supplied")
+	}
+
+	if req.Operation == "ALL" || !str.Contains(req.Operation, aclOperations) {
+		return fmt.Errorf("operation must be one of %q", strings.Join(aclOperations[1:], "|"))
+	}
+
+	if !str.Contains(req.ResourceType, aclResourceTypes) {
+		return fmt.Errorf("resource type must be one of %q", strings.Join(aclResourceTypes, "|"))
+	}
+
+	if len(req.ResourceName) == 0 {
+		return fmt.Errorf("resource name must be supplied")
+	}
+
+	return nil
+}
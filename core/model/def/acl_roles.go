@@ -0,0 +1,202 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/peter-evans/kdef/core/util/str"
+)
+
+// ACLRole represents the operations granted by a role, by resource type.
+type ACLRole map[string][]string
+
+// ACLRoles represents roles by name.
+type ACLRoles map[string]ACLRole
+
+// builtInACLRoles are the roles available to all ACL definitions.
+var builtInACLRoles = ACLRoles{
+	"producer": {
+		"topic":   {"WRITE", "DESCRIBE"},
+		"cluster": {"IDEMPOTENT_WRITE"},
+	},
+	"transactional-producer": {
+		"topic":            {"WRITE", "DESCRIBE"},
+		"transactional_id": {"WRITE", "DESCRIBE"},
+		"cluster":          {"IDEMPOTENT_WRITE"},
+	},
+	"consumer": {
+		"topic": {"READ", "DESCRIBE"},
+		"group": {"READ"},
+	},
+	"streams-app": {
+		"topic":            {"READ", "WRITE", "DESCRIBE", "CREATE", "DELETE", "DESCRIBE_CONFIGS"},
+		"group":            {"READ"},
+		"transactional_id": {"WRITE", "DESCRIBE"},
+		"cluster":          {"IDEMPOTENT_WRITE"},
+	},
+}
+
+// BuiltInACLRoleNames returns the sorted names of built-in roles.
+func BuiltInACLRoleNames() []string {
+	return builtInACLRoles.Names()
+}
+
+// Names returns the sorted names of the roles.
+func (r ACLRoles) Names() []string {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate validates roles.
+func (r ACLRoles) Validate() error {
+	for _, name := range r.Names() {
+		if len(name) == 0 {
+			return fmt.Errorf("role name cannot be an empty string")
+		}
+		if len(r[name]) == 0 {
+			return fmt.Errorf("role %q must grant operations on at least one resource type", name)
+		}
+		for resourceType, operations := range r[name] {
+			if !str.Contains(resourceType, aclResourceTypes) {
+				return fmt.Errorf("role %q: resource type must be one of %q", name, strings.Join(aclResourceTypes, "|"))
+			}
+			if len(operations) == 0 {
+				return fmt.Errorf("role %q: operations are missing for resource type %q", name, resourceType)
+			}
+			for _, operation := range operations {
+				if err := validateACLOperation(operation, resourceType); err != nil {
+					return fmt.Errorf("role %q: %v", name, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// NewACLRoles creates roles from built-in roles and user-defined roles.
+func NewACLRoles(userRoles ACLRoles) (ACLRoles, error) {
+	if err := userRoles.Validate(); err != nil {
+		return nil, err
+	}
+
+	roles := make(ACLRoles, len(builtInACLRoles)+len(userRoles))
+	for name, role := range builtInACLRoles {
+		roles[name] = role
+	}
+	for name, role := range userRoles {
+		if _, ok := builtInACLRoles[name]; ok {
+			return nil, fmt.Errorf("role %q cannot be redefined because it is a built-in role", name)
+		}
+		roles[name] = role
+	}
+
+	return roles, nil
+}
+
+// ACLRolesFile represents a file of user-defined roles.
+type ACLRolesFile struct {
+	Roles ACLRoles `json:"roles"`
+}
+
+// LoadACLRoles loads built-in roles and the user-defined roles of a YAML or JSON document.
+func LoadACLRoles(doc string) (ACLRoles, error) {
+	var rolesFile ACLRolesFile
+	if err := yaml.Unmarshal([]byte(doc), &rolesFile); err != nil {
+		return nil, err
+	}
+	return NewACLRoles(rolesFile.Roles)
+}
+
+// ACLRoleGroup represents a group of principals and hosts granted a role.
+type ACLRoleGroup struct {
+	Role       string   `json:"role"`
+	Principals []string `json:"principals"`
+	Hosts      []string `json:"hosts"`
+}
+
+// ACLRoleGroups represents a slice of ACL role groups.
+type ACLRoleGroups []ACLRoleGroup
+
+// Validate validates ACL role groups.
+// The paths of field errors are relative to the groups.
+func (a ACLRoleGroups) Validate() error {
+	for i, group := range a {
+		if len(group.Role) == 0 {
+			return fieldErrorf(fmt.Sprintf("%d.role", i), "role is missing from acl role group")
+		}
+		if len(group.Principals) == 0 {
+			return fieldErrorf(fmt.Sprintf("%d.principals", i), "principals are missing from acl role group %q", group.Role)
+		}
+		if len(group.Hosts) == 0 {
+			return fieldErrorf(fmt.Sprintf("%d.hosts", i), "hosts are missing from acl role group %q", group.Role)
+		}
+		msgPrefix := fmt.Sprintf("acl role group %q", group.Role)
+		for j, principal := range group.Principals {
+			if err := validateACLPrincipal(principal); err != nil {
+				return nestFieldError(err, fmt.Sprintf("%d.principals.%d", i, j), msgPrefix)
+			}
+		}
+		for j, host := range group.Hosts {
+			if err := validateACLHost(host); err != nil {
+				return nestFieldError(err, fmt.Sprintf("%d.hosts.%d", i, j), msgPrefix)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidatePrincipalTypes validates that the principals of ACL role groups have allowed principal types.
+// The paths of field errors are relative to the groups.
+func (a ACLRoleGroups) ValidatePrincipalTypes(principalTypes []string) error {
+	for i, group := range a {
+		for j, principal := range group.Principals {
+			if err := validateACLPrincipalType(principal, principalTypes); err != nil {
+				return nestFieldError(err, fmt.Sprintf("%d.principals.%d", i, j), fmt.Sprintf("acl role group %q", group.Role))
+			}
+		}
+	}
+
+	return nil
+}
+
+// Expand expands a role group to the ACL entry group it grants on a resource type.
+func (g ACLRoleGroup) Expand(roles ACLRoles, resourceType string) (ACLEntryGroup, error) {
+	role, ok := roles[g.Role]
+	if !ok {
+		return ACLEntryGroup{}, fmt.Errorf("role %q is not defined", g.Role)
+	}
+
+	operations, ok := role[resourceType]
+	if !ok {
+		return ACLEntryGroup{}, fmt.Errorf("role %q does not grant operations on resource type %q", g.Role, resourceType)
+	}
+
+	return ACLEntryGroup{
+		Principals:     g.Principals,
+		Hosts:          g.Hosts,
+		Operations:     operations,
+		PermissionType: "ALLOW",
+	}, nil
+}
+
+// Expand expands role groups to the ACL entry groups they grant on a resource type.
+func (a ACLRoleGroups) Expand(roles ACLRoles, resourceType string) (ACLEntryGroups, error) {
+	var groups ACLEntryGroups
+	for _, roleGroup := range a {
+		group, err := roleGroup.Expand(roles, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
@@ -0,0 +1,171 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter-evans/kdef/core/test/tutil"
+)
+
+func TestLoadACLRoles(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		wantRole string
+		wantErr  string
+	}{
+		{
+			name: "Tests a user-defined role",
+			doc: `roles:
+  auditor:
+    topic: ["DESCRIBE", "DESCRIBE_CONFIGS"]
+    cluster: ["DESCRIBE"]
+`,
+			wantRole: "auditor",
+			wantErr:  "",
+		},
+		{
+			name:     "Tests a user-defined role in JSON",
+			doc:      `{"roles": {"auditor": {"topic": ["DESCRIBE"]}}}`,
+			wantRole: "auditor",
+			wantErr:  "",
+		},
+		{
+			name: "Tests redefining a built-in role",
+			doc: `roles:
+  consumer:
+    topic: ["READ"]
+`,
+			wantErr: "role \"consumer\" cannot be redefined because it is a built-in role",
+		},
+		{
+			name: "Tests an invalid resource type",
+			doc: `roles:
+  auditor:
+    topics: ["DESCRIBE"]
+`,
+			wantErr: "role \"auditor\": resource type must be one of",
+		},
+		{
+			name: "Tests an invalid operation",
+			doc: `roles:
+  auditor:
+    topic: ["VIEW"]
+`,
+			wantErr: "role \"auditor\": acl operation \"VIEW\" must be one of",
+		},
+		{
+			name: "Tests a role without operations",
+			doc: `roles:
+  auditor: {}
+`,
+			wantErr: "role \"auditor\" must grant operations on at least one resource type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadACLRoles(tt.doc)
+			if !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("LoadACLRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if _, ok := got[tt.wantRole]; !ok {
+				t.Errorf("LoadACLRoles() is missing role %q", tt.wantRole)
+			}
+			for _, name := range BuiltInACLRoleNames() {
+				if _, ok := got[name]; !ok {
+					t.Errorf("LoadACLRoles() is missing built-in role %q", name)
+				}
+			}
+		})
+	}
+}
+
+func TestACLRoleGroups_Expand(t *testing.T) {
+	roles, err := NewACLRoles(nil)
+	if err != nil {
+		t.Fatalf("NewACLRoles() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		roleGroups   ACLRoleGroups
+		resourceType string
+		want         ACLEntryGroups
+		wantErr      string
+	}{
+		{
+			name: "Tests expanding roles on a topic",
+			roleGroups: ACLRoleGroups{
+				{Role: "producer", Principals: []string{"User:foo"}, Hosts: []string{"*"}},
+				{Role: "consumer", Principals: []string{"User:bar", "User:baz"}, Hosts: []string{"*"}},
+			},
+			resourceType: "topic",
+			want: ACLEntryGroups{
+				{
+					Principals:     []string{"User:foo"},
+					Hosts:          []string{"*"},
+					Operations:     []string{"WRITE", "DESCRIBE"},
+					PermissionType: "ALLOW",
+				},
+				{
+					Principals:     []string{"User:bar", "User:baz"},
+					Hosts:          []string{"*"},
+					Operations:     []string{"READ", "DESCRIBE"},
+					PermissionType: "ALLOW",
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "Tests expanding a role on the cluster",
+			roleGroups: ACLRoleGroups{
+				{Role: "transactional-producer", Principals: []string{"User:foo"}, Hosts: []string{"*"}},
+			},
+			resourceType: "cluster",
+			want: ACLEntryGroups{
+				{
+					Principals:     []string{"User:foo"},
+					Hosts:          []string{"*"},
+					Operations:     []string{"IDEMPOTENT_WRITE"},
+					PermissionType: "ALLOW",
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "Tests a role that does not grant operations on the resource type",
+			roleGroups: ACLRoleGroups{
+				{Role: "producer", Principals: []string{"User:foo"}, Hosts: []string{"*"}},
+			},
+			resourceType: "group",
+			want:         nil,
+			wantErr:      "role \"producer\" does not grant operations on resource type \"group\"",
+		},
+		{
+			name: "Tests an undefined role",
+			roleGroups: ACLRoleGroups{
+				{Role: "admin", Principals: []string{"User:foo"}, Hosts: []string{"*"}},
+			},
+			resourceType: "topic",
+			want:         nil,
+			wantErr:      "role \"admin\" is not defined",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.roleGroups.Expand(roles, tt.resourceType)
+			if !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("ACLRoleGroups.Expand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ACLRoleGroups.Expand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -181,7 +181,7 @@ func TestACLDefinition_Validate(t *testing.T) {
 					},
 				},
 			},
-			wantErr: "acl operation must be one of",
+			wantErr: "acl operation \"BAR\" must be one of",
 		},
 		{
 			name: "Tests invalid acl permission type",
@@ -233,6 +233,203 @@ func TestACLDefinition_Validate(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "Tests an invalid principal format",
+			aclDef: ACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindACL,
+					Metadata: ResourceMetadataDefinition{
+						Name:                "foo",
+						Type:                "topic",
+						ResourcePatternType: "literal",
+					},
+				},
+				Spec: ACLSpecDefinition{
+					ACLs: ACLEntryGroups{
+						ACLEntryGroup{
+							Principals:     []string{"User:foo", "alice"},
+							Hosts:          []string{"*"},
+							Operations:     []string{"READ"},
+							PermissionType: "ALLOW",
+						},
+					},
+				},
+			},
+			wantErr: "principal \"alice\" must be in the format \"Type:name\"",
+		},
+		{
+			name: "Tests a CIDR host",
+			aclDef: ACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindACL,
+					Metadata: ResourceMetadataDefinition{
+						Name:                "foo",
+						Type:                "topic",
+						ResourcePatternType: "literal",
+					},
+				},
+				Spec: ACLSpecDefinition{
+					ACLs: ACLEntryGroups{
+						ACLEntryGroup{
+							Principals:     []string{"User:foo"},
+							Hosts:          []string{"10.0.0.0/8"},
+							Operations:     []string{"READ"},
+							PermissionType: "ALLOW",
+						},
+					},
+				},
+			},
+			wantErr: "host \"10.0.0.0/8\" is a CIDR range",
+		},
+		{
+			name: "Tests an invalid host",
+			aclDef: ACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindACL,
+					Metadata: ResourceMetadataDefinition{
+						Name:                "foo",
+						Type:                "topic",
+						ResourcePatternType: "literal",
+					},
+				},
+				Spec: ACLSpecDefinition{
+					ACLs: ACLEntryGroups{
+						ACLEntryGroup{
+							Principals:     []string{"User:foo"},
+							Hosts:          []string{"*", "broker-1"},
+							Operations:     []string{"READ"},
+							PermissionType: "ALLOW",
+						},
+					},
+				},
+			},
+			wantErr: "host \"broker-1\" must be an IP address or \"*\"",
+		},
+		{
+			name: "Tests an operation invalid for the resource type",
+			aclDef: ACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindACL,
+					Metadata: ResourceMetadataDefinition{
+						Name:                "kafka-cluster",
+						Type:                "cluster",
+						ResourcePatternType: "literal",
+					},
+				},
+				Spec: ACLSpecDefinition{
+					ACLs: ACLEntryGroups{
+						ACLEntryGroup{
+							Principals:     []string{"User:foo"},
+							Hosts:          []string{"192.168.0.1"},
+							Operations:     []string{"ALTER", "WRITE"},
+							PermissionType: "ALLOW",
+						},
+					},
+				},
+			},
+			wantErr: "acl operation \"WRITE\" is not valid for resource type \"cluster\"",
+		},
+		{
+			name: "Tests config operations on a group",
+			aclDef: ACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindACL,
+					Metadata: ResourceMetadataDefinition{
+						Name:                "foo",
+						Type:                "group",
+						ResourcePatternType: "literal",
+					},
+				},
+				Spec: ACLSpecDefinition{
+					ACLs: ACLEntryGroups{
+						ACLEntryGroup{
+							Principals:     []string{"User:foo"},
+							Hosts:          []string{"*"},
+							Operations:     []string{"DESCRIBE_CONFIGS", "ALTER_CONFIGS"},
+							PermissionType: "ALLOW",
+						},
+					},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "Tests ALL operations on a cluster",
+			aclDef: ACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindACL,
+					Metadata: ResourceMetadataDefinition{
+						Name:                "kafka-cluster",
+						Type:                "cluster",
+						ResourcePatternType: "literal",
+					},
+				},
+				Spec: ACLSpecDefinition{
+					ACLs: ACLEntryGroups{
+						ACLEntryGroup{
+							Principals:     []string{"User:foo"},
+							Hosts:          []string{"::1"},
+							Operations:     []string{"ALL"},
+							PermissionType: "ALLOW",
+						},
+					},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "Tests a role group with missing principals",
+			aclDef: ACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindACL,
+					Metadata: ResourceMetadataDefinition{
+						Name:                "foo",
+						Type:                "topic",
+						ResourcePatternType: "literal",
+					},
+				},
+				Spec: ACLSpecDefinition{
+					Roles: ACLRoleGroups{
+						ACLRoleGroup{
+							Role:  "consumer",
+							Hosts: []string{"*"},
+						},
+					},
+				},
+			},
+			wantErr: "principals are missing from acl role group \"consumer\"",
+		},
+		{
+			name: "Tests a role group with a CIDR host",
+			aclDef: ACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindACL,
+					Metadata: ResourceMetadataDefinition{
+						Name:                "foo",
+						Type:                "topic",
+						ResourcePatternType: "literal",
+					},
+				},
+				Spec: ACLSpecDefinition{
+					Roles: ACLRoleGroups{
+						ACLRoleGroup{
+							Role:       "consumer",
+							Principals: []string{"User:foo"},
+							Hosts:      []string{"192.168.0.0/16"},
+						},
+					},
+				},
+			},
+			wantErr: "acl role group \"consumer\": host \"192.168.0.0/16\" is a CIDR range",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -242,3 +439,54 @@ func TestACLDefinition_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestACLDefinition_ValidateWithPrincipalTypes(t *testing.T) {
+	aclDef := ACLDefinition{
+		Spec: ACLSpecDefinition{
+			ACLs: ACLEntryGroups{
+				ACLEntryGroup{
+					Principals:     []string{"User:foo", "Group:bar"},
+					Hosts:          []string{"*"},
+					Operations:     []string{"READ"},
+					PermissionType: "ALLOW",
+				},
+			},
+			Roles: ACLRoleGroups{
+				ACLRoleGroup{
+					Role:       "consumer",
+					Principals: []string{"user:baz"},
+					Hosts:      []string{"*"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		principalTypes []string
+		wantErr        string
+	}{
+		{
+			name:           "Tests the default principal types",
+			principalTypes: DefaultACLPrincipalTypes,
+			wantErr:        "acl role group \"consumer\": principal \"user:baz\" has type \"user\" but principal type must be one of \"User\"",
+		},
+		{
+			name:           "Tests a principal type that is not allowed",
+			principalTypes: []string{"User", "user"},
+			wantErr:        "principal \"Group:bar\" has type \"Group\" but principal type must be one of \"User|user\"",
+		},
+		{
+			name:           "Tests allowed principal types",
+			principalTypes: []string{"User", "Group", "user"},
+			wantErr:        "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := aclDef.ValidateWithPrincipalTypes(tt.principalTypes); !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("ACLDefinition.ValidateWithPrincipalTypes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
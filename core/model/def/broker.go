@@ -2,10 +2,6 @@
 package def
 
 import (
-	"encoding/json"
-	"fmt"
-
-	"github.com/ghodss/yaml"
 	"github.com/gotidy/copy"
 	"github.com/peter-evans/kdef/core/model/meta"
 	"github.com/peter-evans/kdef/core/model/opt"
@@ -43,7 +39,7 @@ func (b BrokerDefinition) Validate() error {
 	}
 
 	if _, err := i32.ParseStr(b.Metadata.Name); err != nil {
-		return fmt.Errorf("metadata name must be an integer broker id")
+		return fieldErrorf("metadata.name", "metadata name must be an integer broker id")
 	}
 
 	return nil
@@ -54,10 +50,10 @@ func (b BrokerDefinition) ValidateWithMetadata(brokers meta.Brokers) error {
 	// Check the value of metadata name is a valid broker ID
 	brokerID, err := i32.ParseStr(b.Metadata.Name)
 	if err != nil {
-		return err
+		return nestFieldError(err, "metadata.name", "")
 	}
 	if !i32.Contains(brokerID, brokers.IDs()) {
-		return fmt.Errorf("metadata name must be the id of an available broker")
+		return fieldErrorf("metadata.name", "metadata name must be the id of an available broker")
 	}
 
 	return nil
@@ -89,17 +85,8 @@ func LoadBrokerDefinition(
 ) (BrokerDefinition, error) {
 	var def BrokerDefinition
 
-	switch format {
-	case opt.YAMLFormat:
-		if err := yaml.Unmarshal([]byte(defDoc), &def); err != nil {
-			return def, err
-		}
-	case opt.JSONFormat:
-		if err := json.Unmarshal([]byte(defDoc), &def); err != nil {
-			return def, err
-		}
-	default:
-		return def, fmt.Errorf("unsupported format")
+	if err := unmarshalDefinition(defDoc, format, &def); err != nil {
+		return def, err
 	}
 
 	return def, nil
@@ -0,0 +1,162 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gotidy/copy"
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/peter-evans/kdef/core/model/opt"
+	"github.com/peter-evans/kdef/core/util/i32"
+	"github.com/peter-evans/kdef/core/util/str"
+)
+
+// KindBrokerLogger represents the broker logger definition kind.
+const KindBrokerLogger string = "brokerLogger"
+
+// BrokerLoggerAllBrokers is the metadata name that targets the loggers of all brokers.
+const BrokerLoggerAllBrokers string = "all"
+
+// RootLogger is the name of the root logger.
+const RootLogger string = "root"
+
+// LogLevelValidValues represents valid values for logger levels.
+var LogLevelValidValues = []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
+
+// BrokerLoggerSpecDefinition represents a broker logger spec definition.
+type BrokerLoggerSpecDefinition struct {
+	Loggers map[string]string `json:"loggers,omitempty"`
+	Expiry  string            `json:"expiry,omitempty"`
+}
+
+// BrokerLoggerDefinition represents a broker logger resource definition.
+type BrokerLoggerDefinition struct {
+	ResourceDefinition
+	Spec BrokerLoggerSpecDefinition `json:"spec"`
+}
+
+// Copy creates a copy of this BrokerLoggerDefinition.
+func (b BrokerLoggerDefinition) Copy() BrokerLoggerDefinition {
+	copiers := copy.New()
+	copier := copiers.Get(&BrokerLoggerDefinition{}, &BrokerLoggerDefinition{})
+	var brokerLoggerDefCopy BrokerLoggerDefinition
+	copier.Copy(&brokerLoggerDefCopy, &b)
+	return brokerLoggerDefCopy
+}
+
+// AllBrokers determines if the definition targets the loggers of all brokers.
+func (b BrokerLoggerDefinition) AllBrokers() bool {
+	return b.Metadata.Name == BrokerLoggerAllBrokers
+}
+
+// HasExpiry determines if the definition has an expiry.
+func (b BrokerLoggerDefinition) HasExpiry() bool {
+	return len(b.Spec.Expiry) > 0
+}
+
+// Expired determines if the expiry of the definition has passed at the specified time.
+func (b BrokerLoggerDefinition) Expired(now time.Time) bool {
+	if !b.HasExpiry() {
+		return false
+	}
+	expiry, err := time.Parse(time.RFC3339, b.Spec.Expiry)
+	if err != nil {
+		return false
+	}
+	return !now.Before(expiry)
+}
+
+// Validate validates the definition.
+func (b BrokerLoggerDefinition) Validate() error {
+	if err := b.ValidateResource(); err != nil {
+		return err
+	}
+
+	if !b.AllBrokers() {
+		if _, err := i32.ParseStr(b.Metadata.Name); err != nil {
+			return fieldErrorf("metadata.name", "metadata name must be an integer broker id or %q", BrokerLoggerAllBrokers)
+		}
+	}
+
+	if len(b.Spec.Loggers) == 0 {
+		return fieldErrorf("spec.loggers", "loggers must be specified")
+	}
+
+	for logger, level := range b.Spec.Loggers {
+		if len(logger) == 0 {
+			return fieldErrorf("spec.loggers", "logger name cannot be an empty string")
+		}
+		if !str.Contains(level, LogLevelValidValues) {
+			return fieldErrorf(
+				"spec.loggers",
+				"level of logger %q must be one of %q",
+				logger,
+				strings.Join(LogLevelValidValues, "|"),
+			)
+		}
+	}
+
+	if b.HasExpiry() {
+		if _, err := time.Parse(time.RFC3339, b.Spec.Expiry); err != nil {
+			return fieldErrorf("spec.expiry", "expiry must be an RFC 3339 timestamp")
+		}
+		// Kafka does not allow the level of the root logger to be reverted.
+		if _, ok := b.Spec.Loggers[RootLogger]; ok {
+			return fieldErrorf("spec.expiry", "expiry cannot be specified for the %q logger", RootLogger)
+		}
+	}
+
+	return nil
+}
+
+// ValidateWithMetadata further validates the definition using metadata.
+func (b BrokerLoggerDefinition) ValidateWithMetadata(brokers meta.Brokers) error {
+	if b.AllBrokers() {
+		return nil
+	}
+
+	// Check the value of metadata name is a valid broker ID
+	brokerID, err := i32.ParseStr(b.Metadata.Name)
+	if err != nil {
+		return nestFieldError(err, "metadata.name", "")
+	}
+	if !i32.Contains(brokerID, brokers.IDs()) {
+		return fieldErrorf("metadata.name", "metadata name must be the id of an available broker")
+	}
+
+	return nil
+}
+
+// NewBrokerLoggerDefinition creates a broker logger definition from metadata and logger levels.
+func NewBrokerLoggerDefinition(
+	metadata ResourceMetadataDefinition,
+	loggers map[string]string,
+) BrokerLoggerDefinition {
+	brokerLoggerDef := BrokerLoggerDefinition{
+		ResourceDefinition: ResourceDefinition{
+			APIVersion: "v1",
+			Kind:       KindBrokerLogger,
+			Metadata:   metadata,
+		},
+		Spec: BrokerLoggerSpecDefinition{
+			Loggers: loggers,
+		},
+	}
+
+	return brokerLoggerDef
+}
+
+// LoadBrokerLoggerDefinition loads a broker logger definition from a document.
+func LoadBrokerLoggerDefinition(
+	defDoc string,
+	format opt.DefinitionFormat,
+) (BrokerLoggerDefinition, error) {
+	var def BrokerLoggerDefinition
+
+	if err := unmarshalDefinition(defDoc, format, &def); err != nil {
+		return def, err
+	}
+
+	return def, nil
+}
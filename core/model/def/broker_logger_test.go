@@ -0,0 +1,226 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"testing"
+	"time"
+
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/peter-evans/kdef/core/test/tutil"
+)
+
+func TestBrokerLoggerDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		brokerLoggerDef BrokerLoggerDefinition
+		wantErr         string
+	}{
+		{
+			name: "Tests an invalid metadata name",
+			brokerLoggerDef: BrokerLoggerDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindBrokerLogger,
+					Metadata: ResourceMetadataDefinition{
+						Name: "foo",
+					},
+				},
+			},
+			wantErr: "metadata name must be an integer broker id or \"all\"",
+		},
+		{
+			name: "Tests missing loggers",
+			brokerLoggerDef: BrokerLoggerDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindBrokerLogger,
+					Metadata: ResourceMetadataDefinition{
+						Name: "1",
+					},
+				},
+			},
+			wantErr: "loggers must be specified",
+		},
+		{
+			name: "Tests an invalid logger level",
+			brokerLoggerDef: BrokerLoggerDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindBrokerLogger,
+					Metadata: ResourceMetadataDefinition{
+						Name: "1",
+					},
+				},
+				Spec: BrokerLoggerSpecDefinition{
+					Loggers: map[string]string{"kafka.controller": "debug"},
+				},
+			},
+			wantErr: "level of logger \"kafka.controller\" must be one of",
+		},
+		{
+			name: "Tests an invalid expiry",
+			brokerLoggerDef: BrokerLoggerDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindBrokerLogger,
+					Metadata: ResourceMetadataDefinition{
+						Name: "all",
+					},
+				},
+				Spec: BrokerLoggerSpecDefinition{
+					Loggers: map[string]string{"kafka.controller": "DEBUG"},
+					Expiry:  "2026-01-01",
+				},
+			},
+			wantErr: "expiry must be an RFC 3339 timestamp",
+		},
+		{
+			name: "Tests an expiry of the root logger",
+			brokerLoggerDef: BrokerLoggerDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindBrokerLogger,
+					Metadata: ResourceMetadataDefinition{
+						Name: "all",
+					},
+				},
+				Spec: BrokerLoggerSpecDefinition{
+					Loggers: map[string]string{"root": "DEBUG"},
+					Expiry:  "2026-01-01T12:00:00Z",
+				},
+			},
+			wantErr: "expiry cannot be specified for the \"root\" logger",
+		},
+		{
+			name: "Tests a valid broker logger definition",
+			brokerLoggerDef: BrokerLoggerDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindBrokerLogger,
+					Metadata: ResourceMetadataDefinition{
+						Name: "1",
+					},
+				},
+				Spec: BrokerLoggerSpecDefinition{
+					Loggers: map[string]string{
+						"root":             "WARN",
+						"kafka.controller": "DEBUG",
+					},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "Tests a valid broker logger definition for all brokers with expiry",
+			brokerLoggerDef: BrokerLoggerDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindBrokerLogger,
+					Metadata: ResourceMetadataDefinition{
+						Name: "all",
+					},
+				},
+				Spec: BrokerLoggerSpecDefinition{
+					Loggers: map[string]string{"kafka.controller": "DEBUG"},
+					Expiry:  "2026-01-01T12:00:00Z",
+				},
+			},
+			wantErr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.brokerLoggerDef.Validate(); !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("BrokerLoggerDefinition.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBrokerLoggerDefinition_ValidateWithMetadata(t *testing.T) {
+	brokers := meta.Brokers{
+		meta.Broker{ID: 1, Rack: "zone-a"},
+		meta.Broker{ID: 2, Rack: "zone-b"},
+	}
+
+	tests := []struct {
+		name    string
+		defName string
+		wantErr string
+	}{
+		{
+			name:    "Tests an unavailable broker",
+			defName: "9",
+			wantErr: "metadata name must be the id of an available broker",
+		},
+		{
+			name:    "Tests an available broker",
+			defName: "1",
+			wantErr: "",
+		},
+		{
+			name:    "Tests all brokers",
+			defName: "all",
+			wantErr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := BrokerLoggerDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindBrokerLogger,
+					Metadata: ResourceMetadataDefinition{
+						Name: tt.defName,
+					},
+				},
+			}
+			if err := b.ValidateWithMetadata(brokers); !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("BrokerLoggerDefinition.ValidateWithMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBrokerLoggerDefinition_Expired(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		expiry string
+		want   bool
+	}{
+		{
+			name:   "Tests no expiry",
+			expiry: "",
+			want:   false,
+		},
+		{
+			name:   "Tests an expiry at the current time",
+			expiry: "2026-01-01T13:00:00+01:00",
+			want:   true,
+		},
+		{
+			name:   "Tests an expiry in the future",
+			expiry: "2026-01-01T12:00:01Z",
+			want:   false,
+		},
+		{
+			name:   "Tests an expiry in the past",
+			expiry: "2025-12-31T12:00:00Z",
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := BrokerLoggerDefinition{
+				Spec: BrokerLoggerSpecDefinition{
+					Expiry: tt.expiry,
+				},
+			}
+			if got := b.Expired(now); got != tt.want {
+				t.Errorf("BrokerLoggerDefinition.Expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,10 +2,6 @@
 package def
 
 import (
-	"encoding/json"
-	"fmt"
-
-	"github.com/ghodss/yaml"
 	"github.com/gotidy/copy"
 	"github.com/peter-evans/kdef/core/model/opt"
 )
@@ -65,17 +61,8 @@ func LoadBrokersDefinition(
 ) (BrokersDefinition, error) {
 	var def BrokersDefinition
 
-	switch format {
-	case opt.YAMLFormat:
-		if err := yaml.Unmarshal([]byte(defDoc), &def); err != nil {
-			return def, err
-		}
-	case opt.JSONFormat:
-		if err := json.Unmarshal([]byte(defDoc), &def); err != nil {
-			return def, err
-		}
-	default:
-		return def, fmt.Errorf("unsupported format")
+	if err := unmarshalDefinition(defDoc, format, &def); err != nil {
+		return def, err
 	}
 
 	return def, nil
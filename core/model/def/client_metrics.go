@@ -0,0 +1,122 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gotidy/copy"
+	"github.com/peter-evans/kdef/core/model/opt"
+	"github.com/peter-evans/kdef/core/util/str"
+)
+
+// KindClientMetrics represents the client metrics definition kind.
+const KindClientMetrics string = "clientMetrics"
+
+// clientMetricsMatchSelectors are the client selectors of the match config.
+var clientMetricsMatchSelectors = []string{
+	"client_id",
+	"client_instance_id",
+	"client_software_name",
+	"client_software_version",
+	"client_source_address",
+	"client_source_port",
+}
+
+// ClientMetricsSpecDefinition represents a client metrics spec definition.
+type ClientMetricsSpecDefinition struct {
+	Configs                ConfigsMap `json:"configs,omitempty"`
+	DeleteUndefinedConfigs bool       `json:"deleteUndefinedConfigs"`
+}
+
+// ClientMetricsDefinition represents a client metrics subscription resource definition.
+type ClientMetricsDefinition struct {
+	ResourceDefinition
+	Spec ClientMetricsSpecDefinition `json:"spec"`
+}
+
+// Copy creates a copy of this ClientMetricsDefinition.
+func (c ClientMetricsDefinition) Copy() ClientMetricsDefinition {
+	copiers := copy.New()
+	copier := copiers.Get(&ClientMetricsDefinition{}, &ClientMetricsDefinition{})
+	var clientMetricsDefCopy ClientMetricsDefinition
+	copier.Copy(&clientMetricsDefCopy, &c)
+	return clientMetricsDefCopy
+}
+
+// Validate validates the definition.
+func (c ClientMetricsDefinition) Validate() error {
+	if err := c.ValidateResource(); err != nil {
+		return err
+	}
+
+	if metrics, ok := c.Spec.Configs["metrics"]; ok && metrics != nil && len(*metrics) > 0 {
+		for _, metric := range strings.Split(*metrics, ",") {
+			if len(strings.TrimSpace(metric)) == 0 {
+				return fieldErrorf("spec.configs.metrics", "config \"metrics\" must be a comma-separated list of metric name prefixes")
+			}
+		}
+	}
+
+	if interval, ok := c.Spec.Configs["interval.ms"]; ok && interval != nil {
+		if i, err := strconv.Atoi(*interval); err != nil || i <= 0 {
+			return fieldErrorf("spec.configs", "config \"interval.ms\" must be a positive integer")
+		}
+	}
+
+	if match, ok := c.Spec.Configs["match"]; ok && match != nil && len(*match) > 0 {
+		for _, entry := range strings.Split(*match, ",") {
+			parts := strings.Split(entry, "=")
+			if len(parts) != 2 || len(strings.TrimSpace(parts[1])) == 0 {
+				return fieldErrorf("spec.configs.match", "config \"match\" entry %q must be in the form \"<selector>=<regex>\"", entry)
+			}
+			if !str.Contains(strings.TrimSpace(parts[0]), clientMetricsMatchSelectors) {
+				return fieldErrorf(
+					"spec.configs.match",
+					"config \"match\" entry %q selector must be one of %q",
+					entry,
+					strings.Join(clientMetricsMatchSelectors, "|"),
+				)
+			}
+			if _, err := regexp.Compile(strings.TrimSpace(parts[1])); err != nil {
+				return fieldErrorf("spec.configs.match", "config \"match\" entry %q pattern is not a valid regular expression", entry)
+			}
+		}
+	}
+
+	return nil
+}
+
+// NewClientMetricsDefinition creates a client metrics definition from metadata and config.
+func NewClientMetricsDefinition(
+	metadata ResourceMetadataDefinition,
+	configsMap ConfigsMap,
+) ClientMetricsDefinition {
+	clientMetricsDef := ClientMetricsDefinition{
+		ResourceDefinition: ResourceDefinition{
+			APIVersion: "v1",
+			Kind:       KindClientMetrics,
+			Metadata:   metadata,
+		},
+		Spec: ClientMetricsSpecDefinition{
+			Configs: configsMap,
+		},
+	}
+
+	return clientMetricsDef
+}
+
+// LoadClientMetricsDefinition loads a client metrics definition from a document.
+func LoadClientMetricsDefinition(
+	defDoc string,
+	format opt.DefinitionFormat,
+) (ClientMetricsDefinition, error) {
+	var def ClientMetricsDefinition
+
+	if err := unmarshalDefinition(defDoc, format, &def); err != nil {
+		return def, err
+	}
+
+	return def, nil
+}
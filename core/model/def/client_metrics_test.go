@@ -0,0 +1,97 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"testing"
+
+	"github.com/peter-evans/kdef/core/test/tutil"
+)
+
+func TestClientMetricsDefinition_Validate(t *testing.T) {
+	clientMetricsDef := func(configs map[string]string) ClientMetricsDefinition {
+		configsMap := make(ConfigsMap, len(configs))
+		for k, v := range configs {
+			configsMap[k] = &v
+		}
+		return ClientMetricsDefinition{
+			ResourceDefinition: ResourceDefinition{
+				APIVersion: "v1",
+				Kind:       KindClientMetrics,
+				Metadata: ResourceMetadataDefinition{
+					Name: "store-producers",
+				},
+			},
+			Spec: ClientMetricsSpecDefinition{
+				Configs: configsMap,
+			},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		clientMetricsDef ClientMetricsDefinition
+		wantErr          string
+	}{
+		{
+			name: "Tests a missing metadata name",
+			clientMetricsDef: ClientMetricsDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindClientMetrics,
+				},
+			},
+			wantErr: "metadata name must be supplied",
+		},
+		{
+			name:             "Tests an empty metric name prefix",
+			clientMetricsDef: clientMetricsDef(map[string]string{"metrics": "org.apache.kafka.producer.,"}),
+			wantErr:          "config \"metrics\" must be a comma-separated list of metric name prefixes",
+		},
+		{
+			name:             "Tests a non-integer interval",
+			clientMetricsDef: clientMetricsDef(map[string]string{"interval.ms": "1m"}),
+			wantErr:          "config \"interval.ms\" must be a positive integer",
+		},
+		{
+			name:             "Tests a zero interval",
+			clientMetricsDef: clientMetricsDef(map[string]string{"interval.ms": "0"}),
+			wantErr:          "config \"interval.ms\" must be a positive integer",
+		},
+		{
+			name:             "Tests a match entry without a regex",
+			clientMetricsDef: clientMetricsDef(map[string]string{"match": "client_id=store-.*,client_software_name"}),
+			wantErr:          "config \"match\" entry \"client_software_name\" must be in the form \"<selector>=<regex>\"",
+		},
+		{
+			name:             "Tests a match entry with an unknown selector",
+			clientMetricsDef: clientMetricsDef(map[string]string{"match": "client_name=store-.*"}),
+			wantErr:          "config \"match\" entry \"client_name=store-.*\" selector must be one of",
+		},
+		{
+			name:             "Tests a match entry with an invalid regex",
+			clientMetricsDef: clientMetricsDef(map[string]string{"match": "client_id=store-(.*"}),
+			wantErr:          "config \"match\" entry \"client_id=store-(.*\" pattern is not a valid regular expression",
+		},
+		{
+			name:             "Tests empty metrics and match configs",
+			clientMetricsDef: clientMetricsDef(map[string]string{"metrics": "", "match": ""}),
+			wantErr:          "",
+		},
+		{
+			name: "Tests a valid client metrics definition",
+			clientMetricsDef: clientMetricsDef(map[string]string{
+				"metrics":     "org.apache.kafka.producer., org.apache.kafka.consumer.",
+				"interval.ms": "60000",
+				"match":       "client_id=store-.*, client_software_name=apache-kafka-java",
+			}),
+			wantErr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.clientMetricsDef.Validate(); !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("ClientMetricsDefinition.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -16,6 +16,8 @@ const (
 	ConfigSourceStaticBrokerConfig         ConfigSource = 4
 	ConfigSourceDefaultConfig              ConfigSource = 5
 	ConfigSourceDynamicBrokerLoggerConfig  ConfigSource = 6
+	ConfigSourceClientMetricsConfig        ConfigSource = 7
+	ConfigSourceGroupConfig                ConfigSource = 8
 )
 
 // ConfigKey represents a config key.
@@ -32,7 +34,9 @@ func (c ConfigKey) IsDynamic() bool {
 	return c.Source == ConfigSourceDynamicTopicConfig ||
 		c.Source == ConfigSourceDynamicBrokerConfig ||
 		c.Source == ConfigSourceDynamicDefaultBrokerConfig ||
-		c.Source == ConfigSourceDynamicBrokerLoggerConfig
+		c.Source == ConfigSourceDynamicBrokerLoggerConfig ||
+		c.Source == ConfigSourceClientMetricsConfig ||
+		c.Source == ConfigSourceGroupConfig
 }
 
 // Configs represents a slice of ConfigKey.
@@ -57,3 +61,14 @@ func (c Configs) ToExportableMap() ConfigsMap {
 	}
 	return configsMap
 }
+
+// ToDynamicExportableMap returns an exportable map of the dynamic configs (default and static keys filtered out).
+func (c Configs) ToDynamicExportableMap() ConfigsMap {
+	configsMap := ConfigsMap{}
+	for _, config := range c {
+		if config.IsDynamic() && !config.IsSensitive {
+			configsMap[config.Name] = config.Value
+		}
+	}
+	return configsMap
+}
@@ -19,6 +19,13 @@ func TestConfigKey_IsDynamic(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "Tests a dynamic group key",
+			configItem: ConfigKey{
+				Source: ConfigSourceGroupConfig,
+			},
+			want: true,
+		},
 		{
 			name: "Tests a non-dynamic key",
 			configItem: ConfigKey{
@@ -100,3 +107,34 @@ func TestConfigs_ToExportableMap(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigs_ToDynamicExportableMap(t *testing.T) {
+	v1 := "foo-value"
+	v2 := "bar-value"
+	v3 := "baz-value"
+
+	tests := []struct {
+		name string
+		c    Configs
+		want ConfigsMap
+	}{
+		{
+			name: "Tests return of a dynamic config map",
+			c: Configs{
+				ConfigKey{Name: "foo", Value: &v1, Source: ConfigSourceGroupConfig},
+				ConfigKey{Name: "bar", Value: &v2, Source: ConfigSourceGroupConfig, IsSensitive: true},
+				ConfigKey{Name: "baz", Value: &v3, Source: ConfigSourceDefaultConfig},
+			},
+			want: ConfigsMap{
+				"foo": &v1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.ToDynamicExportableMap(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Configs.ToDynamicExportableMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"fmt"
+)
+
+// FieldError represents an error relating to a field of a definition.
+type FieldError struct {
+	// Path is the dot separated path of the field, e.g. "spec.partitions".
+	// Items of a sequence are referenced by their index, e.g. "spec.acls.0.hosts".
+	Path string
+	Err  error
+}
+
+// Error returns the message of the error.
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+// FieldPath returns the path of the field.
+func (e *FieldError) FieldPath() string {
+	return e.Path
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// fieldErrorf creates an error relating to the field at path.
+func fieldErrorf(path string, format string, a ...interface{}) error {
+	return &FieldError{Path: path, Err: fmt.Errorf(format, a...)}
+}
+
+// nestFieldError attributes an error of a nested validation to the field at path.
+// The relative path of a field error is appended to path, and the message is prefixed with msgPrefix if supplied.
+func nestFieldError(err error, path string, msgPrefix string) error {
+	if fieldErr, ok := err.(*FieldError); ok {
+		path = fmt.Sprintf("%s.%s", path, fieldErr.Path)
+		err = fieldErr.Err
+	}
+	if len(msgPrefix) > 0 {
+		err = fmt.Errorf("%s: %v", msgPrefix, err)
+	}
+	return &FieldError{Path: path, Err: err}
+}
@@ -0,0 +1,66 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_nestFieldError(t *testing.T) {
+	type args struct {
+		err       error
+		path      string
+		msgPrefix string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantPath string
+		wantMsg  string
+	}{
+		{
+			name: "Tests nesting a field error",
+			args: args{
+				err:  fieldErrorf("0.hosts", "hosts are missing from acl entry group"),
+				path: "spec.acls",
+			},
+			wantPath: "spec.acls.0.hosts",
+			wantMsg:  "hosts are missing from acl entry group",
+		},
+		{
+			name: "Tests nesting a field error with a message prefix",
+			args: args{
+				err:       fieldErrorf("permissionType", "acl permission type must be one of \"ALLOW|DENY\""),
+				path:      "spec.grants.1",
+				msgPrefix: "grant 1",
+			},
+			wantPath: "spec.grants.1.permissionType",
+			wantMsg:  "grant 1: acl permission type must be one of \"ALLOW|DENY\"",
+		},
+		{
+			name: "Tests attributing an error without a field to the path",
+			args: args{
+				err:       errors.New("principal \"foo\" must be in the format \"Type:name\""),
+				path:      "metadata.name",
+				msgPrefix: "metadata name",
+			},
+			wantPath: "metadata.name",
+			wantMsg:  "metadata name: principal \"foo\" must be in the format \"Type:name\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := nestFieldError(tt.args.err, tt.args.path, tt.args.msgPrefix)
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) {
+				t.Fatalf("nestFieldError() = %T, want *FieldError", err)
+			}
+			if fieldErr.Path != tt.wantPath {
+				t.Errorf("nestFieldError() path = %v, want %v", fieldErr.Path, tt.wantPath)
+			}
+			if err.Error() != tt.wantMsg {
+				t.Errorf("nestFieldError() message = %v, want %v", err.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
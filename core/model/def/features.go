@@ -0,0 +1,119 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"github.com/gotidy/copy"
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/peter-evans/kdef/core/model/opt"
+)
+
+// KindFeatures represents the features definition kind.
+const KindFeatures string = "features"
+
+// FeaturesSpecDefinition represents a features spec definition.
+type FeaturesSpecDefinition struct {
+	Features        map[string]int16 `json:"features,omitempty"`
+	AllowDowngrades bool             `json:"allowDowngrades"`
+}
+
+// FeaturesDefinition represents a features resource definition.
+type FeaturesDefinition struct {
+	ResourceDefinition
+	Spec FeaturesSpecDefinition `json:"spec"`
+}
+
+// Copy creates a copy of this FeaturesDefinition.
+func (f FeaturesDefinition) Copy() FeaturesDefinition {
+	copiers := copy.New()
+	copier := copiers.Get(&FeaturesDefinition{}, &FeaturesDefinition{})
+	var featuresDefCopy FeaturesDefinition
+	copier.Copy(&featuresDefCopy, &f)
+	return featuresDefCopy
+}
+
+// Validate validates the definition.
+func (f FeaturesDefinition) Validate() error {
+	if err := f.ValidateResource(); err != nil {
+		return err
+	}
+
+	if len(f.Spec.Features) == 0 {
+		return fieldErrorf("spec.features", "features must be specified")
+	}
+
+	for name, level := range f.Spec.Features {
+		if len(name) == 0 {
+			return fieldErrorf("spec.features", "feature name cannot be an empty string")
+		}
+		if level < 0 {
+			return fieldErrorf("spec.features", "level of feature %q cannot be negative", name)
+		}
+	}
+
+	return nil
+}
+
+// ValidateWithMetadata further validates the definition using metadata.
+func (f FeaturesDefinition) ValidateWithMetadata(features meta.Features) error {
+	for name, level := range f.Spec.Features {
+		feature, ok := features.Get(name)
+		if !ok {
+			return fieldErrorf("spec.features", "feature %q is not supported by all brokers", name)
+		}
+		// A level of 0 disables the feature.
+		if level != 0 && (level < feature.MinVersion || level > feature.MaxVersion) {
+			return fieldErrorf(
+				"spec.features",
+				"level %d of feature %q is outside of the supported range %d-%d",
+				level,
+				name,
+				feature.MinVersion,
+				feature.MaxVersion,
+			)
+		}
+		if level < feature.FinalizedLevel && !f.Spec.AllowDowngrades {
+			return fieldErrorf(
+				"spec.features",
+				"downgrading feature %q from level %d to %d requires downgrades to be allowed",
+				name,
+				feature.FinalizedLevel,
+				level,
+			)
+		}
+	}
+
+	return nil
+}
+
+// NewFeaturesDefinition creates a features definition from metadata and finalized feature levels.
+func NewFeaturesDefinition(
+	metadata ResourceMetadataDefinition,
+	features map[string]int16,
+) FeaturesDefinition {
+	featuresDef := FeaturesDefinition{
+		ResourceDefinition: ResourceDefinition{
+			APIVersion: "v1",
+			Kind:       KindFeatures,
+			Metadata:   metadata,
+		},
+		Spec: FeaturesSpecDefinition{
+			Features: features,
+		},
+	}
+
+	return featuresDef
+}
+
+// LoadFeaturesDefinition loads a features definition from a document.
+func LoadFeaturesDefinition(
+	defDoc string,
+	format opt.DefinitionFormat,
+) (FeaturesDefinition, error) {
+	var def FeaturesDefinition
+
+	if err := unmarshalDefinition(defDoc, format, &def); err != nil {
+		return def, err
+	}
+
+	return def, nil
+}
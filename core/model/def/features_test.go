@@ -0,0 +1,142 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"testing"
+
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/peter-evans/kdef/core/test/tutil"
+)
+
+func TestFeaturesDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		featuresDef FeaturesDefinition
+		wantErr     string
+	}{
+		{
+			name: "Tests missing features",
+			featuresDef: FeaturesDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindFeatures,
+					Metadata: ResourceMetadataDefinition{
+						Name: "features",
+					},
+				},
+			},
+			wantErr: "features must be specified",
+		},
+		{
+			name: "Tests a negative feature level",
+			featuresDef: FeaturesDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindFeatures,
+					Metadata: ResourceMetadataDefinition{
+						Name: "features",
+					},
+				},
+				Spec: FeaturesSpecDefinition{
+					Features: map[string]int16{"metadata.version": -1},
+				},
+			},
+			wantErr: "level of feature \"metadata.version\" cannot be negative",
+		},
+		{
+			name: "Tests a valid features definition",
+			featuresDef: FeaturesDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindFeatures,
+					Metadata: ResourceMetadataDefinition{
+						Name: "features",
+					},
+				},
+				Spec: FeaturesSpecDefinition{
+					Features: map[string]int16{"metadata.version": 14},
+				},
+			},
+			wantErr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.featuresDef.Validate(); !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("FeaturesDefinition.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFeaturesDefinition_ValidateWithMetadata(t *testing.T) {
+	features := meta.Features{
+		{Name: "kraft.version", MinVersion: 0, MaxVersion: 1, FinalizedLevel: 0},
+		{Name: "metadata.version", MinVersion: 7, MaxVersion: 19, FinalizedLevel: 14},
+	}
+
+	tests := []struct {
+		name            string
+		features        map[string]int16
+		allowDowngrades bool
+		wantErr         string
+	}{
+		{
+			name:     "Tests an unsupported feature",
+			features: map[string]int16{"foo.version": 1},
+			wantErr:  "feature \"foo.version\" is not supported by all brokers",
+		},
+		{
+			name:     "Tests a level above the supported range",
+			features: map[string]int16{"metadata.version": 20},
+			wantErr:  "level 20 of feature \"metadata.version\" is outside of the supported range 7-19",
+		},
+		{
+			name:            "Tests a level below the supported range",
+			features:        map[string]int16{"metadata.version": 6},
+			allowDowngrades: true,
+			wantErr:         "level 6 of feature \"metadata.version\" is outside of the supported range 7-19",
+		},
+		{
+			name:     "Tests a downgrade that is not allowed",
+			features: map[string]int16{"metadata.version": 13},
+			wantErr:  "downgrading feature \"metadata.version\" from level 14 to 13 requires downgrades to be allowed",
+		},
+		{
+			name:            "Tests an allowed downgrade",
+			features:        map[string]int16{"metadata.version": 13},
+			allowDowngrades: true,
+			wantErr:         "",
+		},
+		{
+			name:     "Tests an upgrade",
+			features: map[string]int16{"metadata.version": 19, "kraft.version": 1},
+			wantErr:  "",
+		},
+		{
+			name:     "Tests a disabled feature",
+			features: map[string]int16{"kraft.version": 0},
+			wantErr:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := FeaturesDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindFeatures,
+					Metadata: ResourceMetadataDefinition{
+						Name: "features",
+					},
+				},
+				Spec: FeaturesSpecDefinition{
+					Features:        tt.features,
+					AllowDowngrades: tt.allowDowngrades,
+				},
+			}
+			if err := f.ValidateWithMetadata(features); !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("FeaturesDefinition.ValidateWithMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
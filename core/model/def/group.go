@@ -0,0 +1,69 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"github.com/gotidy/copy"
+	"github.com/peter-evans/kdef/core/model/opt"
+)
+
+// KindGroup represents the group definition kind.
+const KindGroup string = "group"
+
+// GroupSpecDefinition represents a group spec definition.
+type GroupSpecDefinition struct {
+	Configs                ConfigsMap `json:"configs,omitempty"`
+	DeleteUndefinedConfigs bool       `json:"deleteUndefinedConfigs"`
+}
+
+// GroupDefinition represents a group config resource definition.
+type GroupDefinition struct {
+	ResourceDefinition
+	Spec GroupSpecDefinition `json:"spec"`
+}
+
+// Copy creates a copy of this GroupDefinition.
+func (c GroupDefinition) Copy() GroupDefinition {
+	copiers := copy.New()
+	copier := copiers.Get(&GroupDefinition{}, &GroupDefinition{})
+	var groupDefCopy GroupDefinition
+	copier.Copy(&groupDefCopy, &c)
+	return groupDefCopy
+}
+
+// Validate validates the definition.
+func (c GroupDefinition) Validate() error {
+	return c.ValidateResource()
+}
+
+// NewGroupDefinition creates a group definition from metadata and config.
+func NewGroupDefinition(
+	metadata ResourceMetadataDefinition,
+	configsMap ConfigsMap,
+) GroupDefinition {
+	groupDef := GroupDefinition{
+		ResourceDefinition: ResourceDefinition{
+			APIVersion: "v1",
+			Kind:       KindGroup,
+			Metadata:   metadata,
+		},
+		Spec: GroupSpecDefinition{
+			Configs: configsMap,
+		},
+	}
+
+	return groupDef
+}
+
+// LoadGroupDefinition loads a group definition from a document.
+func LoadGroupDefinition(
+	defDoc string,
+	format opt.DefinitionFormat,
+) (GroupDefinition, error) {
+	var def GroupDefinition
+
+	if err := unmarshalDefinition(defDoc, format, &def); err != nil {
+		return def, err
+	}
+
+	return def, nil
+}
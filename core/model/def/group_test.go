@@ -0,0 +1,130 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter-evans/kdef/core/model/opt"
+	"github.com/peter-evans/kdef/core/test/tutil"
+)
+
+func TestGroupDefinition_Validate(t *testing.T) {
+	sessionTimeout := "60000"
+
+	tests := []struct {
+		name     string
+		groupDef GroupDefinition
+		wantErr  string
+	}{
+		{
+			name: "Tests an invalid apiVersion",
+			groupDef: GroupDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v2",
+					Kind:       KindGroup,
+					Metadata: ResourceMetadataDefinition{
+						Name: "store.consumer",
+					},
+				},
+			},
+			wantErr: "invalid definition apiVersion \"v2\"",
+		},
+		{
+			name: "Tests a missing metadata name",
+			groupDef: GroupDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindGroup,
+				},
+			},
+			wantErr: "metadata name must be supplied",
+		},
+		{
+			name: "Tests a valid group definition",
+			groupDef: GroupDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindGroup,
+					Metadata: ResourceMetadataDefinition{
+						Name: "store.consumer",
+					},
+				},
+				Spec: GroupSpecDefinition{
+					Configs: ConfigsMap{
+						"consumer.session.timeout.ms": &sessionTimeout,
+					},
+					DeleteUndefinedConfigs: true,
+				},
+			},
+			wantErr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.groupDef.Validate(); !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("GroupDefinition.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadGroupDefinition(t *testing.T) {
+	sessionTimeout := "60000"
+
+	type args struct {
+		defDoc string
+		format opt.DefinitionFormat
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    GroupDefinition
+		wantErr string
+	}{
+		{
+			name: "Tests an unknown field",
+			args: args{
+				defDoc: "apiVersion: v1\nkind: group\nmetadata:\n  name: store.consumer\nspec:\n  config:\n    consumer.session.timeout.ms: 60000",
+				format: opt.YAMLFormat,
+			},
+			want:    GroupDefinition{},
+			wantErr: "unknown field \"spec.config\"",
+		},
+		{
+			name: "Tests loading a valid group definition",
+			args: args{
+				defDoc: "apiVersion: v1\nkind: group\nmetadata:\n  name: store.consumer\nspec:\n  configs:\n    consumer.session.timeout.ms: 60000\n  deleteUndefinedConfigs: true",
+				format: opt.YAMLFormat,
+			},
+			want: GroupDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindGroup,
+					Metadata: ResourceMetadataDefinition{
+						Name: "store.consumer",
+					},
+				},
+				Spec: GroupSpecDefinition{
+					Configs: ConfigsMap{
+						"consumer.session.timeout.ms": &sessionTimeout,
+					},
+					DeleteUndefinedConfigs: true,
+				},
+			},
+			wantErr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadGroupDefinition(tt.args.defDoc, tt.args.format)
+			if !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("LoadGroupDefinition() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(tt.wantErr) == 0 && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadGroupDefinition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
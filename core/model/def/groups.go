@@ -0,0 +1,157 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/gotidy/copy"
+	"github.com/peter-evans/kdef/core/model/opt"
+	"github.com/peter-evans/kdef/core/util/str"
+)
+
+// KindGroups represents the groups definition kind.
+const KindGroups string = "groups"
+
+// GroupsSpecDefinition represents a groups spec definition.
+type GroupsSpecDefinition struct {
+	AllowedGroups   []string `json:"allowedGroups,omitempty"`
+	AllowedPatterns []string `json:"allowedPatterns,omitempty"`
+	StaleAfter      string   `json:"staleAfter,omitempty"`
+}
+
+// GroupsStateDefinition represents a groups state definition.
+type GroupsStateDefinition struct {
+	StaleGroups []string `json:"staleGroups,omitempty"`
+}
+
+// GroupsDefinition represents a groups resource definition.
+type GroupsDefinition struct {
+	ResourceDefinition
+	Spec  GroupsSpecDefinition   `json:"spec"`
+	State *GroupsStateDefinition `json:"state,omitempty"`
+}
+
+// Copy creates a copy of this GroupsDefinition.
+func (g GroupsDefinition) Copy() GroupsDefinition {
+	copiers := copy.New()
+	copier := copiers.Get(&GroupsDefinition{}, &GroupsDefinition{})
+	var groupsDefCopy GroupsDefinition
+	copier.Copy(&groupsDefCopy, &g)
+	return groupsDefCopy
+}
+
+// HasStaleAfter determines if the definition has an age threshold for stale groups.
+func (g GroupsDefinition) HasStaleAfter() bool {
+	return len(g.Spec.StaleAfter) > 0
+}
+
+// StaleAfterDuration returns the age threshold for stale groups.
+func (g GroupsDefinition) StaleAfterDuration() time.Duration {
+	d, err := time.ParseDuration(g.Spec.StaleAfter)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// AllowedGroupsMatcher returns a matcher of the groups allowed by the definition.
+// The definition must be valid.
+func (g GroupsDefinition) AllowedGroupsMatcher() AllowedGroupsMatcher {
+	patterns := make([]*regexp.Regexp, len(g.Spec.AllowedPatterns))
+	for i, pattern := range g.Spec.AllowedPatterns {
+		patterns[i] = regexp.MustCompile(fmt.Sprintf("^(?:%s)$", pattern))
+	}
+	return AllowedGroupsMatcher{
+		groups:   g.Spec.AllowedGroups,
+		patterns: patterns,
+	}
+}
+
+// AllowedGroupsMatcher matches groups allowed by name or pattern.
+type AllowedGroupsMatcher struct {
+	groups   []string
+	patterns []*regexp.Regexp
+}
+
+// Allows determines if a group is allowed by name or pattern.
+func (m AllowedGroupsMatcher) Allows(group string) bool {
+	if str.Contains(group, m.groups) {
+		return true
+	}
+	for _, pattern := range m.patterns {
+		if pattern.MatchString(group) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate validates the definition.
+func (g GroupsDefinition) Validate() error {
+	if err := g.ValidateResource(); err != nil {
+		return err
+	}
+
+	for i, group := range g.Spec.AllowedGroups {
+		if len(group) == 0 {
+			return fieldErrorf(fmt.Sprintf("spec.allowedGroups.%d", i), "allowed groups cannot contain an empty string")
+		}
+	}
+
+	for i, pattern := range g.Spec.AllowedPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fieldErrorf(fmt.Sprintf("spec.allowedPatterns.%d", i), "allowed pattern %q is not a valid regular expression: %v", pattern, err)
+		}
+	}
+
+	if g.HasStaleAfter() {
+		d, err := time.ParseDuration(g.Spec.StaleAfter)
+		if err != nil {
+			return fieldErrorf("spec.staleAfter", "stale after must be a duration such as \"168h\"")
+		}
+		if d <= 0 {
+			return fieldErrorf("spec.staleAfter", "stale after must be greater than zero")
+		}
+	}
+
+	return nil
+}
+
+// NewGroupsDefinition creates a groups definition from metadata, spec and stale groups.
+func NewGroupsDefinition(
+	metadata ResourceMetadataDefinition,
+	spec GroupsSpecDefinition,
+	staleGroups []string,
+) GroupsDefinition {
+	groupsDef := GroupsDefinition{
+		ResourceDefinition: ResourceDefinition{
+			APIVersion: "v1",
+			Kind:       KindGroups,
+			Metadata:   metadata,
+		},
+		Spec: spec,
+		State: &GroupsStateDefinition{
+			StaleGroups: staleGroups,
+		},
+	}
+
+	return groupsDef
+}
+
+// LoadGroupsDefinition loads a groups definition from a document.
+func LoadGroupsDefinition(
+	defDoc string,
+	format opt.DefinitionFormat,
+) (GroupsDefinition, error) {
+	var def GroupsDefinition
+
+	if err := unmarshalDefinition(defDoc, format, &def); err != nil {
+		return def, err
+	}
+
+	def.State = nil
+
+	return def, nil
+}
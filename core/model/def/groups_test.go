@@ -0,0 +1,150 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"testing"
+
+	"github.com/peter-evans/kdef/core/test/tutil"
+)
+
+func TestGroupsDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		groupsDef GroupsDefinition
+		wantErr   string
+	}{
+		{
+			name: "Tests an empty allowed group",
+			groupsDef: GroupsDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindGroups,
+					Metadata: ResourceMetadataDefinition{
+						Name: "consumer-groups",
+					},
+				},
+				Spec: GroupsSpecDefinition{
+					AllowedGroups: []string{""},
+				},
+			},
+			wantErr: "allowed groups cannot contain an empty string",
+		},
+		{
+			name: "Tests an invalid allowed pattern",
+			groupsDef: GroupsDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindGroups,
+					Metadata: ResourceMetadataDefinition{
+						Name: "consumer-groups",
+					},
+				},
+				Spec: GroupsSpecDefinition{
+					AllowedPatterns: []string{"store.(.*"},
+				},
+			},
+			wantErr: "allowed pattern \"store.(.*\" is not a valid regular expression",
+		},
+		{
+			name: "Tests an invalid stale after duration",
+			groupsDef: GroupsDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindGroups,
+					Metadata: ResourceMetadataDefinition{
+						Name: "consumer-groups",
+					},
+				},
+				Spec: GroupsSpecDefinition{
+					StaleAfter: "7d",
+				},
+			},
+			wantErr: "stale after must be a duration",
+		},
+		{
+			name: "Tests a negative stale after duration",
+			groupsDef: GroupsDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindGroups,
+					Metadata: ResourceMetadataDefinition{
+						Name: "consumer-groups",
+					},
+				},
+				Spec: GroupsSpecDefinition{
+					StaleAfter: "-168h",
+				},
+			},
+			wantErr: "stale after must be greater than zero",
+		},
+		{
+			name: "Tests a valid groups definition",
+			groupsDef: GroupsDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindGroups,
+					Metadata: ResourceMetadataDefinition{
+						Name: "consumer-groups",
+					},
+				},
+				Spec: GroupsSpecDefinition{
+					AllowedGroups:   []string{"store.order-service"},
+					AllowedPatterns: []string{"store\\..*"},
+					StaleAfter:      "168h",
+				},
+			},
+			wantErr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.groupsDef.Validate(); !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("GroupsDefinition.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAllowedGroupsMatcher_Allows(t *testing.T) {
+	groupsDef := GroupsDefinition{
+		Spec: GroupsSpecDefinition{
+			AllowedGroups:   []string{"billing-service"},
+			AllowedPatterns: []string{"store\\..*", "audit-[0-9]+"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		group string
+		want  bool
+	}{
+		{
+			name:  "Tests a group allowed by name",
+			group: "billing-service",
+			want:  true,
+		},
+		{
+			name:  "Tests a group allowed by pattern",
+			group: "store.order-service",
+			want:  true,
+		},
+		{
+			name:  "Tests a pattern that must match the whole group name",
+			group: "audit-1-old",
+			want:  false,
+		},
+		{
+			name:  "Tests a group that is not allowed",
+			group: "billing-service-old",
+			want:  false,
+		},
+	}
+	matcher := groupsDef.AllowedGroupsMatcher()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.Allows(tt.group); got != tt.want {
+				t.Errorf("AllowedGroupsMatcher.Allows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
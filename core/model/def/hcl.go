@@ -0,0 +1,295 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// hclRefAttribute is the name of attributes of HCL blocks that reference fragments.
+const hclRefAttribute = "ref"
+
+// UnmarshalHCL unmarshals an HCL definition document to a value.
+// The document is a single block labelled with the metadata name, of a type that is the definition kind.
+// Nested blocks are unmarshalled to objects, or to the items of arrays where the value's field is a slice.
+func UnmarshalHCL(defDoc string, v interface{}) error {
+	b, err := hclToJSON(defDoc, reflect.TypeOf(v).Elem())
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// MarshalHCL marshals a definition to an HCL document.
+// Struct properties are marshalled to nested blocks, and slices of structs to repeated blocks.
+func MarshalHCL(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, err
+	}
+
+	var resourceDef ResourceDefinition
+	if err := json.Unmarshal(b, &resourceDef); err != nil {
+		return nil, err
+	}
+	if len(resourceDef.Kind) == 0 || len(resourceDef.Metadata.Name) == 0 {
+		return nil, fmt.Errorf("hcl definitions must have a kind and metadata name")
+	}
+
+	// The kind and metadata name are the block type and label.
+	delete(obj, "kind")
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "name")
+		if len(metadata) == 0 {
+			delete(obj, "metadata")
+		}
+	}
+
+	f := hclwrite.NewEmptyFile()
+	block := f.Body().AppendNewBlock(resourceDef.Kind, []string{resourceDef.Metadata.Name})
+	if err := writeHCLBody(block.Body(), obj, reflect.TypeOf(v)); err != nil {
+		return nil, err
+	}
+
+	return f.Bytes(), nil
+}
+
+// hclToJSON converts an HCL definition document to JSON, guided by the type the document will be unmarshalled to.
+func hclToJSON(defDoc string, t reflect.Type) ([]byte, error) {
+	file, diags := hclsyntax.ParseConfig([]byte(defDoc), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, hclDiagsErr(diags)
+	}
+
+	body := file.Body.(*hclsyntax.Body)
+	if len(body.Attributes) > 0 || len(body.Blocks) != 1 {
+		return nil, fmt.Errorf("hcl definition must contain exactly one block")
+	}
+
+	block := body.Blocks[0]
+	if len(block.Labels) != 1 {
+		return nil, hclLineErr(block.TypeRange, fmt.Errorf("block %q must have exactly one label, the metadata name", block.Type))
+	}
+
+	obj, err := hclBodyToObject(block.Body, t, "")
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, ok := obj["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = map[string]interface{}{}
+		obj["metadata"] = metadata
+	}
+	if name, ok := metadata["name"]; ok && name != block.Labels[0] {
+		return nil, hclLineErr(block.LabelRanges[0], fmt.Errorf("metadata name %q must match block label %q", name, block.Labels[0]))
+	}
+	metadata["name"] = block.Labels[0]
+	obj["kind"] = block.Type
+
+	return json.Marshal(obj)
+}
+
+// hclBodyToObject converts the body of an HCL block to an object.
+// Where the type of a property is unknown, repeated blocks are converted to an array.
+func hclBodyToObject(body *hclsyntax.Body, t reflect.Type, path string) (map[string]interface{}, error) {
+	t = derefType(t)
+	obj := map[string]interface{}{}
+
+	names := make([]string, 0, len(body.Attributes))
+	for name := range body.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		attr := body.Attributes[name]
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, hclDiagsErr(diags)
+		}
+		b, err := ctyjson.Marshal(val, val.Type())
+		if err != nil {
+			return nil, hclLineErr(attr.NameRange, err)
+		}
+		var v interface{}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		if name == hclRefAttribute {
+			// References to fragments are attributes named "ref", as "$ref" is not a valid HCL identifier.
+			name = "$ref"
+		}
+		obj[name] = v
+	}
+
+	repeated := map[string]bool{}
+	for _, block := range body.Blocks {
+		if len(block.Labels) > 0 {
+			return nil, hclLineErr(block.TypeRange, fmt.Errorf("block %q must not have labels", joinPath(path, block.Type)))
+		}
+		if _, ok := body.Attributes[block.Type]; ok {
+			return nil, hclLineErr(block.TypeRange, fmt.Errorf("%q is defined as both an attribute and a block", joinPath(path, block.Type)))
+		}
+
+		fieldType := hclFieldType(t, block.Type)
+		elemType := fieldType
+		isArray := false
+		if fieldType != nil {
+			if ft := derefType(fieldType); ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array {
+				elemType = ft.Elem()
+				isArray = true
+			}
+		}
+
+		v, err := hclBodyToObject(block.Body, elemType, joinPath(path, block.Type))
+		if err != nil {
+			return nil, err
+		}
+
+		existing, exists := obj[block.Type]
+		switch {
+		case isArray || repeated[block.Type]:
+			items, _ := existing.([]interface{})
+			obj[block.Type] = append(items, v)
+		case exists && fieldType == nil:
+			// Repeated blocks of an unknown property are converted to an array.
+			obj[block.Type] = []interface{}{existing, v}
+			repeated[block.Type] = true
+		case exists:
+			return nil, hclLineErr(block.TypeRange, fmt.Errorf("duplicate block %q", joinPath(path, block.Type)))
+		default:
+			obj[block.Type] = v
+		}
+	}
+
+	return obj, nil
+}
+
+// writeHCLBody writes the properties of an object to the body of an HCL block, in the order of the fields of its type.
+func writeHCLBody(body *hclwrite.Body, obj map[string]interface{}, t reflect.Type) error {
+	t = derefType(t)
+
+	var names []string
+	if t.Kind() == reflect.Struct {
+		for _, field := range jsonFields(t) {
+			if _, ok := obj[field.Name]; ok {
+				names = append(names, field.Name)
+			}
+		}
+	} else {
+		names = sortedKeys(obj)
+	}
+
+	for _, name := range names {
+		value := obj[name]
+		fieldType := hclFieldType(t, name)
+		if fieldType == nil {
+			return fmt.Errorf("unknown field %q", name)
+		}
+		fieldType = derefType(fieldType)
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if fieldType.Kind() == reflect.Struct {
+				if err := writeHCLBody(body.AppendNewBlock(name, nil).Body(), v, fieldType); err != nil {
+					return err
+				}
+				continue
+			}
+		case []interface{}:
+			if (fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array) &&
+				derefType(fieldType.Elem()).Kind() == reflect.Struct {
+				for _, item := range v {
+					itemObj, ok := item.(map[string]interface{})
+					if !ok {
+						return fmt.Errorf("invalid item of %q", name)
+					}
+					if err := writeHCLBody(body.AppendNewBlock(name, nil).Body(), itemObj, fieldType.Elem()); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+		}
+
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		ty, err := ctyjson.ImpliedType(b)
+		if err != nil {
+			return err
+		}
+		val, err := ctyjson.Unmarshal(b, ty)
+		if err != nil {
+			return err
+		}
+		body.SetAttributeValue(name, val)
+	}
+
+	return nil
+}
+
+// hclFieldType returns the type of the field of a struct type by its JSON name, or nil if the field is unknown.
+// The value type is returned for map types.
+func hclFieldType(t reflect.Type, name string) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	t = derefType(t)
+	switch t.Kind() {
+	case reflect.Struct:
+		for _, field := range jsonFields(t) {
+			if strings.EqualFold(field.Name, name) {
+				return field.Type
+			}
+		}
+	case reflect.Map:
+		return t.Elem()
+	}
+	return nil
+}
+
+// derefType returns the type that a pointer type points to.
+func derefType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// hclDiagsErr converts HCL diagnostics to an error containing the line of the first error.
+func hclDiagsErr(diags hcl.Diagnostics) error {
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagError {
+			continue
+		}
+		msg := diag.Summary
+		if len(diag.Detail) > 0 {
+			msg = fmt.Sprintf("%s; %s", msg, diag.Detail)
+		}
+		if diag.Subject != nil {
+			return hclLineErr(*diag.Subject, fmt.Errorf("%s", msg))
+		}
+		return fmt.Errorf("hcl: %s", msg)
+	}
+	return diags
+}
+
+// hclLineErr prefixes an error with the line of an HCL range relative to the start of the document.
+func hclLineErr(r hcl.Range, err error) error {
+	return fmt.Errorf("hcl: line %d: %v", r.Start.Line, err)
+}
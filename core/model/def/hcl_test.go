@@ -0,0 +1,112 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter-evans/kdef/core/model/opt"
+	"github.com/peter-evans/kdef/core/test/tutil"
+)
+
+func TestUnmarshalHCL(t *testing.T) {
+	tests := []struct {
+		name    string
+		defDoc  string
+		want    ResourceDefinition
+		wantErr string
+	}{
+		{
+			name:   "Tests the kind and metadata name from the block type and label",
+			defDoc: "topic \"foo\" {\n  apiVersion = \"v1\"\n  metadata {\n    labels = {\n      team = \"bar\"\n    }\n  }\n}",
+			want: ResourceDefinition{
+				APIVersion: "v1",
+				Kind:       KindTopic,
+				Metadata: ResourceMetadataDefinition{
+					Name:   "foo",
+					Labels: ResourceMetadataLabels{"team": "bar"},
+				},
+			},
+		},
+		{
+			name:    "Tests a block without a label",
+			defDoc:  "topic {\n  apiVersion = \"v1\"\n}",
+			wantErr: "hcl: line 1: block \"topic\" must have exactly one label, the metadata name",
+		},
+		{
+			name:    "Tests multiple blocks",
+			defDoc:  "topic \"foo\" {\n}\ntopic \"bar\" {\n}",
+			wantErr: "hcl definition must contain exactly one block",
+		},
+		{
+			name:    "Tests a metadata name not matching the block label",
+			defDoc:  "topic \"foo\" {\n  metadata {\n    name = \"bar\"\n  }\n}",
+			wantErr: "hcl: line 1: metadata name \"bar\" must match block label \"foo\"",
+		},
+		{
+			name:    "Tests a duplicate block",
+			defDoc:  "topic \"foo\" {\n  metadata {\n  }\n  metadata {\n  }\n}",
+			wantErr: "hcl: line 4: duplicate block \"metadata\"",
+		},
+		{
+			name:    "Tests invalid HCL",
+			defDoc:  "topic \"foo\" {\n  apiVersion =\n}",
+			wantErr: "hcl: line 2: Invalid expression",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ResourceDefinition
+			err := UnmarshalHCL(tt.defDoc, &got)
+			if !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("UnmarshalHCL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnmarshalHCL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalHCL(t *testing.T) {
+	// Repeated blocks are unmarshalled to the items of slices, and nested blocks to structs.
+	defDoc := `acl "foo" {
+  apiVersion = "v1"
+  metadata {
+    type                = "topic"
+    resourcePatternType = "literal"
+  }
+  spec {
+    acls {
+      principals     = ["User:bar"]
+      hosts          = ["*"]
+      operations     = ["READ"]
+      permissionType = "ALLOW"
+    }
+    acls {
+      principals     = ["User:baz"]
+      hosts          = ["*"]
+      operations     = ["WRITE"]
+      permissionType = "ALLOW"
+    }
+    deleteUndefinedAcls = false
+  }
+}
+`
+	aclDef, err := LoadACLDefinition(defDoc, opt.HCLFormat)
+	if err != nil {
+		t.Fatalf("LoadACLDefinition() error = %v", err)
+	}
+	if len(aclDef.Spec.ACLs) != 2 || aclDef.Spec.ACLs[1].Principals[0] != "User:baz" {
+		t.Errorf("LoadACLDefinition() acls = %v", aclDef.Spec.ACLs)
+	}
+
+	got, err := MarshalHCL(aclDef)
+	if err != nil {
+		t.Fatalf("MarshalHCL() error = %v", err)
+	}
+	if string(got) != defDoc {
+		t.Errorf("MarshalHCL() = %v, want %v", string(got), defDoc)
+	}
+}
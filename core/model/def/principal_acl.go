@@ -0,0 +1,246 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gotidy/copy"
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/peter-evans/kdef/core/model/opt"
+	"github.com/peter-evans/kdef/core/util/str"
+)
+
+// KindPrincipalACL represents the principal acl definition kind.
+const KindPrincipalACL string = "principalAcl"
+
+// ACLResource represents a resource that ACLs are applied to.
+type ACLResource struct {
+	Type        string
+	Name        string
+	PatternType string
+}
+
+// PrincipalACLGrant represents a grant of ACLs on a resource to a principal.
+type PrincipalACLGrant struct {
+	ResourceType        string   `json:"resourceType"`
+	ResourceName        string   `json:"resourceName"`
+	ResourcePatternType string   `json:"resourcePatternType,omitempty"`
+	Hosts               []string `json:"hosts"`
+	Operations          []string `json:"operations"`
+	PermissionType      string   `json:"permissionType"`
+}
+
+// Resource returns the resource of the grant.
+func (g PrincipalACLGrant) Resource() ACLResource {
+	return ACLResource{
+		Type:        g.ResourceType,
+		Name:        g.ResourceName,
+		PatternType: g.ResourcePatternType,
+	}
+}
+
+// ACLEntryGroup returns the ACL entry group of the grant for a principal.
+func (g PrincipalACLGrant) ACLEntryGroup(principal string) ACLEntryGroup {
+	return ACLEntryGroup{
+		Principals:     []string{principal},
+		Hosts:          g.Hosts,
+		Operations:     g.Operations,
+		PermissionType: g.PermissionType,
+	}
+}
+
+// PrincipalACLGrants represents a slice of principal ACL grants.
+type PrincipalACLGrants []PrincipalACLGrant
+
+// Explode explodes grants to one grant per host and operation.
+func (p PrincipalACLGrants) Explode() PrincipalACLGrants {
+	var exploded PrincipalACLGrants
+	for _, grant := range p {
+		for _, host := range grant.Hosts {
+			for _, operation := range grant.Operations {
+				exploded = append(exploded, PrincipalACLGrant{
+					ResourceType:        grant.ResourceType,
+					ResourceName:        grant.ResourceName,
+					ResourcePatternType: grant.ResourcePatternType,
+					Hosts:               []string{host},
+					Operations:          []string{operation},
+					PermissionType:      grant.PermissionType,
+				})
+			}
+		}
+	}
+	return exploded
+}
+
+// ByResource groups the ACL entries of grants for a principal by resource.
+func (p PrincipalACLGrants) ByResource(principal string) map[ACLResource]ACLEntryGroups {
+	resources := make(map[ACLResource]ACLEntryGroups)
+	for _, grant := range p {
+		resources[grant.Resource()] = append(resources[grant.Resource()], grant.ACLEntryGroup(principal))
+	}
+	return resources
+}
+
+// Sort sorts grants by resource, host, operation and permission type.
+// Grants are expected to be exploded to one host and operation per grant.
+func (p PrincipalACLGrants) Sort() {
+	sort.Slice(p, func(i, j int) bool {
+		a, b := p[i], p[j]
+		if a.ResourceType != b.ResourceType {
+			return a.ResourceType < b.ResourceType
+		}
+		if a.ResourcePatternType != b.ResourcePatternType {
+			return a.ResourcePatternType < b.ResourcePatternType
+		}
+		if a.ResourceName != b.ResourceName {
+			return a.ResourceName < b.ResourceName
+		}
+		if a.Hosts[0] != b.Hosts[0] {
+			return a.Hosts[0] < b.Hosts[0]
+		}
+		if a.Operations[0] != b.Operations[0] {
+			return a.Operations[0] < b.Operations[0]
+		}
+		return a.PermissionType < b.PermissionType
+	})
+}
+
+// PrincipalACLSpecDefinition represents a principal ACL spec definition.
+type PrincipalACLSpecDefinition struct {
+	Grants              PrincipalACLGrants `json:"grants,omitempty"`
+	DeleteUndefinedACLs bool               `json:"deleteUndefinedAcls"`
+}
+
+// PrincipalACLDefinition represents a principal ACL resource definition.
+type PrincipalACLDefinition struct {
+	ResourceDefinition
+	Spec PrincipalACLSpecDefinition `json:"spec"`
+}
+
+// Copy creates a copy of this PrincipalACLDefinition.
+func (p PrincipalACLDefinition) Copy() PrincipalACLDefinition {
+	copiers := copy.New()
+	copier := copiers.Get(&PrincipalACLDefinition{}, &PrincipalACLDefinition{})
+	var principalACLDefCopy PrincipalACLDefinition
+	copier.Copy(&principalACLDefCopy, &p)
+	return principalACLDefCopy
+}
+
+// Validate validates the definition.
+func (p PrincipalACLDefinition) Validate() error {
+	if err := p.ValidateResource(); err != nil {
+		return err
+	}
+
+	if err := validateACLPrincipal(p.Metadata.Name); err != nil {
+		return nestFieldError(err, "metadata.name", "metadata name")
+	}
+
+	for i, grant := range p.Spec.Grants {
+		path := fmt.Sprintf("spec.grants.%d", i)
+		if !str.Contains(grant.ResourceType, aclResourceTypes) {
+			return fieldErrorf(path+".resourceType", "grant %d: resource type must be one of %q", i, strings.Join(aclResourceTypes, "|"))
+		}
+
+		if len(grant.ResourceName) == 0 {
+			return fieldErrorf(path+".resourceName", "grant %d: resource name must be supplied", i)
+		}
+
+		if grant.ResourceType == "cluster" && grant.ResourceName != "kafka-cluster" {
+			return fieldErrorf(
+				path+".resourceName",
+				"grant %d: resource name must be \"kafka-cluster\" when resource type is \"cluster\"",
+				i,
+			)
+		}
+
+		if !str.Contains(grant.ResourcePatternType, aclResourcePatternTypes) {
+			return fieldErrorf(
+				path+".resourcePatternType",
+				"grant %d: resource pattern type must be one of %q",
+				i,
+				strings.Join(aclResourcePatternTypes, "|"),
+			)
+		}
+
+		// The hosts, operations and permission type of the grant share their paths with its acl entry group.
+		grantACLs := grant.ACLEntryGroup(p.Metadata.Name)
+		if err := grantACLs.validate(); err != nil {
+			return nestFieldError(err, path, fmt.Sprintf("grant %d", i))
+		}
+
+		if err := grantACLs.validateEntries(grant.ResourceType); err != nil {
+			return nestFieldError(err, path, fmt.Sprintf("grant %d", i))
+		}
+	}
+
+	return nil
+}
+
+// ValidateWithPrincipalTypes further validates the definition using the allowed principal types.
+func (p PrincipalACLDefinition) ValidateWithPrincipalTypes(principalTypes []string) error {
+	if err := validateACLPrincipalType(p.Metadata.Name, principalTypes); err != nil {
+		return nestFieldError(err, "metadata.name", "metadata name")
+	}
+
+	return nil
+}
+
+// NewPrincipalACLDefinition creates a principal ACL definition from metadata and grants.
+func NewPrincipalACLDefinition(
+	metadata ResourceMetadataDefinition,
+	grants PrincipalACLGrants,
+) PrincipalACLDefinition {
+	principalACLDef := PrincipalACLDefinition{
+		ResourceDefinition: ResourceDefinition{
+			APIVersion: "v1",
+			Kind:       KindPrincipalACL,
+			Metadata:   metadata,
+		},
+		Spec: PrincipalACLSpecDefinition{
+			Grants: grants,
+		},
+	}
+
+	return principalACLDef
+}
+
+// LoadPrincipalACLDefinition loads a principal ACL definition from a document.
+func LoadPrincipalACLDefinition(
+	defDoc string,
+	format opt.DefinitionFormat,
+) (PrincipalACLDefinition, error) {
+	var def PrincipalACLDefinition
+
+	if err := unmarshalDefinition(defDoc, format, &def); err != nil {
+		return def, err
+	}
+
+	// Set defaults
+	for i := range def.Spec.Grants {
+		if len(def.Spec.Grants[i].ResourcePatternType) == 0 {
+			def.Spec.Grants[i].ResourcePatternType = "literal"
+		}
+	}
+
+	return def, nil
+}
+
+// ACLBindings returns the ACL entries of the definition bound to their resources.
+func (p PrincipalACLDefinition) ACLBindings() meta.ACLBindings {
+	var bindings meta.ACLBindings
+	for _, grant := range p.Spec.Grants.Explode() {
+		bindings = append(bindings, meta.ACLBinding{
+			ResourceType:        grant.ResourceType,
+			ResourceName:        grant.ResourceName,
+			ResourcePatternType: grant.ResourcePatternType,
+			Principal:           p.Metadata.Name,
+			Host:                grant.Hosts[0],
+			Operation:           grant.Operations[0],
+			PermissionType:      grant.PermissionType,
+		})
+	}
+	return bindings
+}
@@ -0,0 +1,285 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter-evans/kdef/core/test/tutil"
+)
+
+func TestPrincipalACLDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		principalACLDef PrincipalACLDefinition
+		wantErr         string
+	}{
+		{
+			name: "Tests an invalid resource type",
+			principalACLDef: PrincipalACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindPrincipalACL,
+					Metadata: ResourceMetadataDefinition{
+						Name: "User:order-service",
+					},
+				},
+				Spec: PrincipalACLSpecDefinition{
+					Grants: PrincipalACLGrants{
+						{
+							ResourceType:        "foo",
+							ResourceName:        "store.events.order-created",
+							ResourcePatternType: "literal",
+							Hosts:               []string{"*"},
+							Operations:          []string{"WRITE"},
+							PermissionType:      "ALLOW",
+						},
+					},
+				},
+			},
+			wantErr: "grant 0: resource type must be one of",
+		},
+		{
+			name: "Tests an invalid principal",
+			principalACLDef: PrincipalACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindPrincipalACL,
+					Metadata: ResourceMetadataDefinition{
+						Name: "order-service",
+					},
+				},
+				Spec: PrincipalACLSpecDefinition{
+					Grants: PrincipalACLGrants{
+						{
+							ResourceType:        "topic",
+							ResourceName:        "store.events.order-created",
+							ResourcePatternType: "literal",
+							Hosts:               []string{"*"},
+							Operations:          []string{"WRITE"},
+							PermissionType:      "ALLOW",
+						},
+					},
+				},
+			},
+			wantErr: "metadata name: principal \"order-service\" must be in the format \"Type:name\"",
+		},
+		{
+			name: "Tests a CIDR host",
+			principalACLDef: PrincipalACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindPrincipalACL,
+					Metadata: ResourceMetadataDefinition{
+						Name: "User:order-service",
+					},
+				},
+				Spec: PrincipalACLSpecDefinition{
+					Grants: PrincipalACLGrants{
+						{
+							ResourceType:        "topic",
+							ResourceName:        "store.events.order-created",
+							ResourcePatternType: "literal",
+							Hosts:               []string{"10.0.0.0/8"},
+							Operations:          []string{"WRITE"},
+							PermissionType:      "ALLOW",
+						},
+					},
+				},
+			},
+			wantErr: "grant 0: host \"10.0.0.0/8\" is a CIDR range",
+		},
+		{
+			name: "Tests an operation invalid for the resource type",
+			principalACLDef: PrincipalACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindPrincipalACL,
+					Metadata: ResourceMetadataDefinition{
+						Name: "User:order-service",
+					},
+				},
+				Spec: PrincipalACLSpecDefinition{
+					Grants: PrincipalACLGrants{
+						{
+							ResourceType:        "group",
+							ResourceName:        "order-service",
+							ResourcePatternType: "literal",
+							Hosts:               []string{"*"},
+							Operations:          []string{"WRITE"},
+							PermissionType:      "ALLOW",
+						},
+					},
+				},
+			},
+			wantErr: "grant 0: acl operation \"WRITE\" is not valid for resource type \"group\"",
+		},
+		{
+			name: "Tests an invalid cluster resource name",
+			principalACLDef: PrincipalACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindPrincipalACL,
+					Metadata: ResourceMetadataDefinition{
+						Name: "User:order-service",
+					},
+				},
+				Spec: PrincipalACLSpecDefinition{
+					Grants: PrincipalACLGrants{
+						{
+							ResourceType:        "cluster",
+							ResourceName:        "foo",
+							ResourcePatternType: "literal",
+							Hosts:               []string{"*"},
+							Operations:          []string{"IDEMPOTENT_WRITE"},
+							PermissionType:      "ALLOW",
+						},
+					},
+				},
+			},
+			wantErr: "grant 0: resource name must be \"kafka-cluster\" when resource type is \"cluster\"",
+		},
+		{
+			name: "Tests an invalid resource pattern type",
+			principalACLDef: PrincipalACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindPrincipalACL,
+					Metadata: ResourceMetadataDefinition{
+						Name: "User:order-service",
+					},
+				},
+				Spec: PrincipalACLSpecDefinition{
+					Grants: PrincipalACLGrants{
+						{
+							ResourceType:        "topic",
+							ResourceName:        "store.events",
+							ResourcePatternType: "match",
+							Hosts:               []string{"*"},
+							Operations:          []string{"WRITE"},
+							PermissionType:      "ALLOW",
+						},
+					},
+				},
+			},
+			wantErr: "grant 0: resource pattern type must be one of",
+		},
+		{
+			name: "Tests an invalid operation",
+			principalACLDef: PrincipalACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindPrincipalACL,
+					Metadata: ResourceMetadataDefinition{
+						Name: "User:order-service",
+					},
+				},
+				Spec: PrincipalACLSpecDefinition{
+					Grants: PrincipalACLGrants{
+						{
+							ResourceType:        "topic",
+							ResourceName:        "store.events",
+							ResourcePatternType: "prefixed",
+							Hosts:               []string{"*"},
+							Operations:          []string{"PUBLISH"},
+							PermissionType:      "ALLOW",
+						},
+					},
+				},
+			},
+			wantErr: "grant 0: acl operation \"PUBLISH\" must be one of",
+		},
+		{
+			name: "Tests a valid principal acl definition",
+			principalACLDef: PrincipalACLDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindPrincipalACL,
+					Metadata: ResourceMetadataDefinition{
+						Name: "User:order-service",
+					},
+				},
+				Spec: PrincipalACLSpecDefinition{
+					Grants: PrincipalACLGrants{
+						{
+							ResourceType:        "topic",
+							ResourceName:        "store.events",
+							ResourcePatternType: "prefixed",
+							Hosts:               []string{"*"},
+							Operations:          []string{"WRITE", "DESCRIBE"},
+							PermissionType:      "ALLOW",
+						},
+						{
+							ResourceType:        "cluster",
+							ResourceName:        "kafka-cluster",
+							ResourcePatternType: "literal",
+							Hosts:               []string{"*"},
+							Operations:          []string{"IDEMPOTENT_WRITE"},
+							PermissionType:      "ALLOW",
+						},
+					},
+				},
+			},
+			wantErr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.principalACLDef.Validate(); !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("PrincipalACLDefinition.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrincipalACLGrants_ByResource(t *testing.T) {
+	grants := PrincipalACLGrants{
+		{
+			ResourceType:        "topic",
+			ResourceName:        "store.events",
+			ResourcePatternType: "prefixed",
+			Hosts:               []string{"*"},
+			Operations:          []string{"WRITE", "DESCRIBE"},
+			PermissionType:      "ALLOW",
+		},
+		{
+			ResourceType:        "group",
+			ResourceName:        "order-service",
+			ResourcePatternType: "literal",
+			Hosts:               []string{"*"},
+			Operations:          []string{"READ"},
+			PermissionType:      "ALLOW",
+		},
+	}
+
+	want := map[ACLResource]ACLEntryGroups{
+		{Type: "topic", Name: "store.events", PatternType: "prefixed"}: {
+			{
+				Principals:     []string{"User:order-service"},
+				Hosts:          []string{"*"},
+				Operations:     []string{"DESCRIBE"},
+				PermissionType: "ALLOW",
+			},
+			{
+				Principals:     []string{"User:order-service"},
+				Hosts:          []string{"*"},
+				Operations:     []string{"WRITE"},
+				PermissionType: "ALLOW",
+			},
+		},
+		{Type: "group", Name: "order-service", PatternType: "literal"}: {
+			{
+				Principals:     []string{"User:order-service"},
+				Hosts:          []string{"*"},
+				Operations:     []string{"READ"},
+				PermissionType: "ALLOW",
+			},
+		},
+	}
+
+	exploded := grants.Explode()
+	exploded.Sort()
+	if got := exploded.ByResource("User:order-service"); !reflect.DeepEqual(got, want) {
+		t.Errorf("PrincipalACLGrants.ByResource() = %v, want %v", got, want)
+	}
+}
@@ -1,17 +1,19 @@
 // Package def implements definitions for Kafka resources.
 package def
 
-import (
-	"fmt"
-
-	"github.com/peter-evans/kdef/core/util/str"
-)
+import "github.com/peter-evans/kdef/core/util/str"
 
 var definitionKindVersions = map[string][]string{
-	KindACL:     {"v1"},
-	KindBroker:  {"v1"},
-	KindBrokers: {"v1"},
-	KindTopic:   {"v1"},
+	KindACL:           {"v1"},
+	KindBroker:        {"v1"},
+	KindBrokerLogger:  {"v1"},
+	KindBrokers:       {"v1"},
+	KindClientMetrics: {"v1"},
+	KindFeatures:      {"v1"},
+	KindGroup:         {"v1"},
+	KindGroups:        {"v1"},
+	KindPrincipalACL:  {"v1"},
+	KindTopic:         {"v1"},
 }
 
 // ResourceMetadataLabels represents resource metadata labels.
@@ -36,14 +38,14 @@ type ResourceDefinition struct {
 func (r ResourceDefinition) ValidateResource() error {
 	if versions, ok := definitionKindVersions[r.Kind]; ok {
 		if !str.Contains(r.APIVersion, versions) {
-			return fmt.Errorf("invalid definition apiVersion %q", r.APIVersion)
+			return fieldErrorf("apiVersion", "invalid definition apiVersion %q", r.APIVersion)
 		}
 	} else {
-		return fmt.Errorf("invalid definition kind %q", r.Kind)
+		return fieldErrorf("kind", "invalid definition kind %q", r.Kind)
 	}
 
 	if len(r.Metadata.Name) == 0 {
-		return fmt.Errorf("metadata name must be supplied")
+		return fieldErrorf("metadata.name", "metadata name must be supplied")
 	}
 
 	return nil
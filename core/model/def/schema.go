@@ -0,0 +1,351 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/peter-evans/kdef/core/model/opt"
+)
+
+// jsonSchemaDraft is the JSON Schema draft of generated schemas.
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// refProperty is the name of object properties referencing fragment files.
+const refProperty = "$ref"
+
+// definitionKindTypes are the types of definitions by kind.
+var definitionKindTypes = map[string]reflect.Type{
+	KindACL:           reflect.TypeOf(ACLDefinition{}),
+	KindBroker:        reflect.TypeOf(BrokerDefinition{}),
+	KindBrokerLogger:  reflect.TypeOf(BrokerLoggerDefinition{}),
+	KindBrokers:       reflect.TypeOf(BrokersDefinition{}),
+	KindClientMetrics: reflect.TypeOf(ClientMetricsDefinition{}),
+	KindFeatures:      reflect.TypeOf(FeaturesDefinition{}),
+	KindGroup:         reflect.TypeOf(GroupDefinition{}),
+	KindGroups:        reflect.TypeOf(GroupsDefinition{}),
+	KindPrincipalACL:  reflect.TypeOf(PrincipalACLDefinition{}),
+	KindTopic:         reflect.TypeOf(TopicDefinition{}),
+}
+
+// definitionKindEnums are the valid values of string properties by kind and property path.
+// Enums of array and map properties apply to their items and values.
+var definitionKindEnums = map[string]map[string][]string{
+	KindACL: {
+		"metadata.type":                aclResourceTypes,
+		"metadata.resourcePatternType": aclResourcePatternTypes,
+		"spec.acls.operations":         aclOperations,
+		"spec.acls.permissionType":     aclPermissionTypes,
+	},
+	KindBrokerLogger: {
+		"spec.loggers": LogLevelValidValues,
+	},
+	KindPrincipalACL: {
+		"spec.grants.resourceType":        aclResourceTypes,
+		"spec.grants.resourcePatternType": aclResourcePatternTypes,
+		"spec.grants.operations":          aclOperations,
+		"spec.grants.permissionType":      aclPermissionTypes,
+	},
+	KindTopic: {
+		"spec.managedAssignments.balance":   balanceScopes,
+		"spec.managedAssignments.selection": selectionMethods,
+	},
+}
+
+// JSONSchema represents a JSON Schema.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 interface{}            `json:"type,omitempty"`
+	Const                string                 `json:"const,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	OneOf                []*JSONSchema          `json:"oneOf,omitempty"`
+	Definitions          map[string]*JSONSchema `json:"definitions,omitempty"`
+}
+
+// DefinitionKinds returns the sorted definition kinds.
+func DefinitionKinds() []string {
+	kinds := make([]string, 0, len(definitionKindVersions))
+	for kind := range definitionKindVersions {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// DefinitionKindVersions returns the apiVersions of a definition kind.
+func DefinitionKindVersions(kind string) []string {
+	return definitionKindVersions[kind]
+}
+
+// NewJSONSchema creates the JSON Schema of a definition kind and apiVersion.
+func NewJSONSchema(kind string, apiVersion string) (*JSONSchema, error) {
+	t, ok := definitionKindTypes[kind]
+	if !ok {
+		return nil, fmt.Errorf("invalid definition kind %q", kind)
+	}
+	versions := definitionKindVersions[kind]
+	found := false
+	for _, version := range versions {
+		found = found || version == apiVersion
+	}
+	if !found {
+		return nil, fmt.Errorf("invalid definition apiVersion %q for kind %q", apiVersion, kind)
+	}
+
+	schema := typeJSONSchema(t, "", definitionKindEnums[kind])
+	schema.Schema = jsonSchemaDraft
+	schema.Title = fmt.Sprintf("kdef %s definition (%s)", kind, apiVersion)
+	schema.Properties["apiVersion"] = &JSONSchema{Type: "string", Const: apiVersion}
+	schema.Properties["kind"] = &JSONSchema{Type: "string", Const: kind}
+	schema.Required = []string{"apiVersion", "kind", "metadata"}
+	schema.Properties["metadata"].Required = []string{"name"}
+
+	return schema, nil
+}
+
+// NewDefinitionsJSONSchema creates a JSON Schema matching any definition kind and apiVersion.
+func NewDefinitionsJSONSchema() (*JSONSchema, error) {
+	schema := &JSONSchema{
+		Schema:      jsonSchemaDraft,
+		Title:       "kdef definition",
+		Definitions: map[string]*JSONSchema{},
+	}
+
+	for _, kind := range DefinitionKinds() {
+		for _, apiVersion := range definitionKindVersions[kind] {
+			kindSchema, err := NewJSONSchema(kind, apiVersion)
+			if err != nil {
+				return nil, err
+			}
+			kindSchema.Schema = ""
+
+			name := fmt.Sprintf("%s.%s", kind, apiVersion)
+			schema.Definitions[name] = kindSchema
+			schema.OneOf = append(schema.OneOf, &JSONSchema{Ref: "#/definitions/" + name})
+		}
+	}
+
+	return schema, nil
+}
+
+// jsonField represents a field of a struct as it is marshalled to JSON.
+type jsonField struct {
+	Name string
+	Type reflect.Type
+}
+
+// jsonFields returns the fields of a struct type as they are marshalled to JSON, including those of embedded structs.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && len(name) == 0 && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, jsonFields(field.Type)...)
+			continue
+		}
+		if len(name) == 0 {
+			name = field.Name
+		}
+		fields = append(fields, jsonField{Name: name, Type: field.Type})
+	}
+	return fields
+}
+
+// joinPath joins a property name to a dot separated property path.
+func joinPath(path string, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+	return path + "." + name
+}
+
+// typeJSONSchema creates the JSON Schema of a type at a property path.
+func typeJSONSchema(t reflect.Type, path string, enums map[string][]string) *JSONSchema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		if t.Elem().Kind() == reflect.String {
+			// Nullable strings, such as config values, accept any scalar.
+			return &JSONSchema{Type: []string{"string", "number", "boolean", "null"}}
+		}
+		return typeJSONSchema(t.Elem(), path, enums)
+	case reflect.Struct:
+		schema := &JSONSchema{
+			Type:                 "object",
+			Properties:           map[string]*JSONSchema{},
+			AdditionalProperties: false,
+		}
+		for _, field := range jsonFields(t) {
+			schema.Properties[field.Name] = typeJSONSchema(field.Type, joinPath(path, field.Name), enums)
+		}
+		schema.Properties[refProperty] = refJSONSchema()
+		return schema
+	case reflect.Map:
+		return &JSONSchema{
+			Type:                 "object",
+			Properties:           map[string]*JSONSchema{refProperty: refJSONSchema()},
+			AdditionalProperties: typeJSONSchema(t.Elem(), path, enums),
+		}
+	case reflect.Slice, reflect.Array:
+		return &JSONSchema{
+			Type:  "array",
+			Items: typeJSONSchema(t.Elem(), path, enums),
+		}
+	case reflect.String:
+		return &JSONSchema{Type: "string", Enum: enums[path]}
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	default:
+		return &JSONSchema{}
+	}
+}
+
+// refJSONSchema creates the JSON Schema of an optional property referencing fragment files.
+// References are resolved before a definition is unmarshalled, so any object may contain one.
+func refJSONSchema() *JSONSchema {
+	return &JSONSchema{
+		OneOf: []*JSONSchema{
+			{Type: "string"},
+			{Type: "array", Items: &JSONSchema{Type: "string"}},
+		},
+	}
+}
+
+// DefinitionToJSON converts a definition document to JSON.
+func DefinitionToJSON(defDoc string, format opt.DefinitionFormat) ([]byte, error) {
+	switch format {
+	case opt.YAMLFormat:
+		return yaml.YAMLToJSON([]byte(defDoc))
+	case opt.JSONFormat:
+		return []byte(defDoc), nil
+	case opt.HCLFormat:
+		// The kind of the definition determines how nested blocks are converted.
+		var resourceDef ResourceDefinition
+		if err := UnmarshalHCL(defDoc, &resourceDef); err != nil {
+			return nil, err
+		}
+		return hclToJSON(defDoc, definitionKindTypes[resourceDef.Kind])
+	default:
+		return nil, fmt.Errorf("unsupported format")
+	}
+}
+
+// unmarshalDefinition unmarshals a definition document, rejecting fields that are unknown to the definition.
+func unmarshalDefinition(defDoc string, format opt.DefinitionFormat, def interface{}) error {
+	var doc interface{}
+
+	switch format {
+	case opt.YAMLFormat:
+		if err := yaml.Unmarshal([]byte(defDoc), def); err != nil {
+			return err
+		}
+		if err := yaml.Unmarshal([]byte(defDoc), &doc); err != nil {
+			return err
+		}
+	case opt.JSONFormat:
+		if err := json.Unmarshal([]byte(defDoc), def); err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(defDoc), &doc); err != nil {
+			return err
+		}
+	case opt.HCLFormat:
+		b, err := hclToJSON(defDoc, reflect.TypeOf(def).Elem())
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(b, def); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(b, &doc); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported format")
+	}
+
+	return checkUnknownFields(doc, reflect.TypeOf(def).Elem(), "")
+}
+
+// checkUnknownFields checks that an unmarshalled document has no fields unknown to a type.
+// Field names are matched case-insensitively, as they are when unmarshalling.
+func checkUnknownFields(doc interface{}, t reflect.Type, path string) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		fields := jsonFields(t)
+		for _, key := range sortedKeys(obj) {
+			var fieldType reflect.Type
+			for _, field := range fields {
+				if strings.EqualFold(field.Name, key) {
+					fieldType = field.Type
+					break
+				}
+			}
+			if fieldType == nil {
+				return fmt.Errorf("unknown field %q", joinPath(path, key))
+			}
+			if err := checkUnknownFields(obj[key], fieldType, joinPath(path, key)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for _, key := range sortedKeys(obj) {
+			if err := checkUnknownFields(obj[key], t.Elem(), joinPath(path, key)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := doc.([]interface{})
+		if !ok {
+			return nil
+		}
+		for _, item := range items {
+			if err := checkUnknownFields(item, t.Elem(), path); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortedKeys returns the sorted keys of an unmarshalled object.
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,146 @@
+// Package def implements definitions for Kafka resources.
+package def
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter-evans/kdef/core/test/tutil"
+)
+
+func TestNewJSONSchema(t *testing.T) {
+	type args struct {
+		kind       string
+		apiVersion string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		path    []string
+		want    *JSONSchema
+		wantErr string
+	}{
+		{
+			name: "Tests an invalid kind",
+			args: args{
+				kind:       "foo",
+				apiVersion: "v1",
+			},
+			wantErr: "invalid definition kind \"foo\"",
+		},
+		{
+			name: "Tests an invalid apiVersion",
+			args: args{
+				kind:       KindTopic,
+				apiVersion: "v2",
+			},
+			wantErr: "invalid definition apiVersion \"v2\" for kind \"topic\"",
+		},
+		{
+			name: "Tests the kind constant",
+			args: args{
+				kind:       KindTopic,
+				apiVersion: "v1",
+			},
+			path: []string{"kind"},
+			want: &JSONSchema{Type: "string", Const: KindTopic},
+		},
+		{
+			name: "Tests a string enum",
+			args: args{
+				kind:       KindTopic,
+				apiVersion: "v1",
+			},
+			path: []string{"spec", "managedAssignments", "balance"},
+			want: &JSONSchema{Type: "string", Enum: balanceScopes},
+		},
+		{
+			name: "Tests an array enum",
+			args: args{
+				kind:       KindACL,
+				apiVersion: "v1",
+			},
+			path: []string{"spec", "acls", "operations"},
+			want: &JSONSchema{Type: "array", Items: &JSONSchema{Type: "string", Enum: aclOperations}},
+		},
+		{
+			name: "Tests a map enum",
+			args: args{
+				kind:       KindBrokerLogger,
+				apiVersion: "v1",
+			},
+			path: []string{"spec", "loggers"},
+			want: &JSONSchema{
+				Type:                 "object",
+				Properties:           map[string]*JSONSchema{refProperty: refJSONSchema()},
+				AdditionalProperties: &JSONSchema{Type: "string", Enum: LogLevelValidValues},
+			},
+		},
+		{
+			name: "Tests a reference on an object",
+			args: args{
+				kind:       KindTopic,
+				apiVersion: "v1",
+			},
+			path: []string{"spec", "$ref"},
+			want: &JSONSchema{
+				OneOf: []*JSONSchema{
+					{Type: "string"},
+					{Type: "array", Items: &JSONSchema{Type: "string"}},
+				},
+			},
+		},
+		{
+			name: "Tests a reference on a map",
+			args: args{
+				kind:       KindTopic,
+				apiVersion: "v1",
+			},
+			path: []string{"spec", "configs", "$ref"},
+			want: &JSONSchema{
+				OneOf: []*JSONSchema{
+					{Type: "string"},
+					{Type: "array", Items: &JSONSchema{Type: "string"}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewJSONSchema(tt.args.kind, tt.args.apiVersion)
+			if !tutil.ErrorContains(err, tt.wantErr) {
+				t.Errorf("NewJSONSchema() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			for _, name := range tt.path {
+				if got.Items != nil {
+					got = got.Items
+				}
+				if got = got.Properties[name]; got == nil {
+					t.Fatalf("NewJSONSchema() missing property %q", name)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewJSONSchema() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDefinitionsJSONSchema(t *testing.T) {
+	got, err := NewDefinitionsJSONSchema()
+	if err != nil {
+		t.Fatalf("NewDefinitionsJSONSchema() error = %v", err)
+	}
+	if len(got.OneOf) != len(definitionKindVersions) {
+		t.Errorf("NewDefinitionsJSONSchema() oneOf = %d, want %d", len(got.OneOf), len(definitionKindVersions))
+	}
+	for _, ref := range got.OneOf {
+		if _, ok := got.Definitions[ref.Ref[len("#/definitions/"):]]; !ok {
+			t.Errorf("NewDefinitionsJSONSchema() missing definition for %q", ref.Ref)
+		}
+	}
+}
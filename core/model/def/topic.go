@@ -2,12 +2,10 @@
 package def
 
 import (
-	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 
-	"github.com/ghodss/yaml"
 	"github.com/gotidy/copy"
 	"github.com/peter-evans/kdef/cli/log"
 	"github.com/peter-evans/kdef/core/model/meta"
@@ -32,13 +30,17 @@ var balanceScopes = []string{
 
 // Selection types.
 const (
-	SelectionTopicClusterUse = "topic-cluster-use"
-	SelectionTopicUse        = "topic-use"
+	SelectionTopicClusterUse      = "topic-cluster-use"
+	SelectionTopicUse             = "topic-use"
+	SelectionTopicClusterDiskUse  = "topic-cluster-disk-use"
+	SelectionTopicClusterDiskFree = "topic-cluster-disk-free"
 )
 
 var selectionMethods = []string{
 	SelectionTopicClusterUse,
 	SelectionTopicUse,
+	SelectionTopicClusterDiskUse,
+	SelectionTopicClusterDiskFree,
 }
 
 // PartitionAssignments represents partition assignments by broker ID.
@@ -50,11 +52,18 @@ type PartitionRacks [][]string
 // PartitionLeaders represents partition leaders by broker ID.
 type PartitionLeaders []int32
 
+// PartitionLogDirs represents log directories of partition replicas in the order of the partition assignments.
+type PartitionLogDirs [][]string
+
+// LogDirAny represents a replica log directory that is not placed by kdef.
+const LogDirAny string = "any"
+
 // ManagedAssignmentsDefinition represents a managed assignments definition.
 type ManagedAssignmentsDefinition struct {
 	Balance         string         `json:"balance,omitempty"`
 	Selection       string         `json:"selection,omitempty"`
 	RackConstraints PartitionRacks `json:"rackConstraints,omitempty"`
+	RackAware       bool           `json:"rackAware,omitempty"`
 }
 
 // HasRackConstraints determines if a managed assignments definition has rack constraints.
@@ -70,6 +79,7 @@ type TopicSpecDefinition struct {
 	ReplicationFactor      int                           `json:"replicationFactor"`
 	Assignments            PartitionAssignments          `json:"assignments,omitempty"`
 	ManagedAssignments     *ManagedAssignmentsDefinition `json:"managedAssignments,omitempty"`
+	LogDirs                PartitionLogDirs              `json:"logDirs,omitempty"`
 	MaintainLeaders        bool                          `json:"maintainLeaders"`
 }
 
@@ -78,6 +88,11 @@ func (t TopicSpecDefinition) HasAssignments() bool {
 	return len(t.Assignments) > 0
 }
 
+// HasLogDirs determines if a spec has log dirs.
+func (t TopicSpecDefinition) HasLogDirs() bool {
+	return len(t.LogDirs) > 0
+}
+
 // HasManagedAssignments determines if a spec has a managed assignments definition.
 func (t TopicSpecDefinition) HasManagedAssignments() bool {
 	return t.ManagedAssignments != nil
@@ -112,55 +127,84 @@ func (t TopicDefinition) Validate() error {
 	}
 
 	if t.Spec.Partitions <= 0 {
-		return fmt.Errorf("partitions must be greater than 0")
+		return fieldErrorf("spec.partitions", "partitions must be greater than 0")
 	}
 
 	if t.Spec.ReplicationFactor <= 0 {
-		return fmt.Errorf("replication factor must be greater than 0")
+		return fieldErrorf("spec.replicationFactor", "replication factor must be greater than 0")
 	}
 
 	if t.Spec.HasAssignments() && t.Spec.HasManagedAssignments() {
-		return fmt.Errorf("assignments and managed assignments cannot be specified together")
+		return fieldErrorf("spec.managedAssignments", "assignments and managed assignments cannot be specified together")
 	}
 
 	if t.Spec.HasAssignments() {
 		if len(t.Spec.Assignments) != t.Spec.Partitions {
-			return fmt.Errorf("number of replica assignments must match partitions")
+			return fieldErrorf("spec.assignments", "number of replica assignments must match partitions")
 		}
 
-		for _, replicas := range t.Spec.Assignments {
+		for partition, replicas := range t.Spec.Assignments {
+			path := fmt.Sprintf("spec.assignments.%d", partition)
 			if len(replicas) != t.Spec.ReplicationFactor {
-				return fmt.Errorf("number of replicas in each assignment must match replication factor")
+				return fieldErrorf(path, "number of replicas in each assignment must match replication factor")
 			}
 
 			if i32.ContainsDuplicate(replicas) {
-				return fmt.Errorf("a replica assignment cannot contain duplicate brokers")
+				return fieldErrorf(path, "a replica assignment cannot contain duplicate brokers")
+			}
+		}
+	}
+
+	if t.Spec.HasLogDirs() {
+		if !t.Spec.HasAssignments() {
+			return fieldErrorf("spec.logDirs", "log dirs require assignments to be specified")
+		}
+
+		if len(t.Spec.LogDirs) != t.Spec.Partitions {
+			return fieldErrorf("spec.logDirs", "number of partition log dirs must match partitions")
+		}
+
+		for partition, logDirs := range t.Spec.LogDirs {
+			path := fmt.Sprintf("spec.logDirs.%d", partition)
+			if len(logDirs) != t.Spec.ReplicationFactor {
+				return fieldErrorf(path, "number of log dirs in each partition must match replication factor")
+			}
+
+			for replica, logDir := range logDirs {
+				if len(logDir) == 0 {
+					return fieldErrorf(fmt.Sprintf("%s.%d", path, replica), "log dirs cannot be an empty string")
+				}
 			}
 		}
 	}
 
 	if t.Spec.HasManagedAssignments() {
 		if !str.Contains(t.Spec.ManagedAssignments.Balance, balanceScopes) {
-			return fmt.Errorf("balance must be one of %q", strings.Join(balanceScopes, "|"))
+			return fieldErrorf("spec.managedAssignments.balance", "balance must be one of %q", strings.Join(balanceScopes, "|"))
 		}
 
 		if !str.Contains(t.Spec.ManagedAssignments.Selection, selectionMethods) {
-			return fmt.Errorf("selection must be one of %q", strings.Join(selectionMethods, "|"))
+			return fieldErrorf("spec.managedAssignments.selection", "selection must be one of %q", strings.Join(selectionMethods, "|"))
+		}
+
+		if t.Spec.ManagedAssignments.HasRackConstraints() && t.Spec.ManagedAssignments.RackAware {
+			return fieldErrorf("spec.managedAssignments.rackAware", "rack constraints and rack-aware placement cannot be specified together")
 		}
 
 		if t.Spec.ManagedAssignments.HasRackConstraints() {
 			if len(t.Spec.ManagedAssignments.RackConstraints) != t.Spec.Partitions {
-				return fmt.Errorf("number of rack constraints must match partitions")
+				return fieldErrorf("spec.managedAssignments.rackConstraints", "number of rack constraints must match partitions")
 			}
 
-			for _, replicas := range t.Spec.ManagedAssignments.RackConstraints {
+			for partition, replicas := range t.Spec.ManagedAssignments.RackConstraints {
+				path := fmt.Sprintf("spec.managedAssignments.rackConstraints.%d", partition)
 				if len(replicas) != t.Spec.ReplicationFactor {
-					return fmt.Errorf("number of replicas in a partition's rack constraints must match replication factor")
+					return fieldErrorf(path, "number of replicas in a partition's rack constraints must match replication factor")
 				}
 
-				for _, rackID := range replicas {
+				for replica, rackID := range replicas {
 					if len(rackID) == 0 {
-						return fmt.Errorf("rack ids cannot be an empty string")
+						return fieldErrorf(fmt.Sprintf("%s.%d", path, replica), "rack ids cannot be an empty string")
 					}
 				}
 			}
@@ -176,20 +220,29 @@ func (t TopicDefinition) ValidateWithMetadata(brokers meta.Brokers) error {
 	// Validation specific to either create or update can remain in the applier.
 
 	if t.Spec.ReplicationFactor > len(brokers) {
-		return fmt.Errorf("replication factor cannot exceed the number of available brokers")
+		return fieldErrorf("spec.replicationFactor", "replication factor cannot exceed the number of available brokers")
 	}
 
 	if t.Spec.HasAssignments() {
 		// Check the broker IDs in the assignments are valid.
-		for _, replicas := range t.Spec.Assignments {
-			for _, id := range replicas {
+		for partition, replicas := range t.Spec.Assignments {
+			for replica, id := range replicas {
 				if !i32.Contains(id, brokers.IDs()) {
-					return fmt.Errorf("invalid broker id %q in assignments", fmt.Sprint(id))
+					return fieldErrorf(fmt.Sprintf("spec.assignments.%d.%d", partition, replica), "invalid broker id %q in assignments", fmt.Sprint(id))
 				}
 			}
 		}
 	}
 
+	if t.Spec.HasManagedAssignments() && t.Spec.ManagedAssignments.RackAware {
+		// Check all brokers have a rack ID.
+		for _, broker := range brokers {
+			if len(broker.Rack) == 0 {
+				return fieldErrorf("spec.managedAssignments.rackAware", "rack-aware placement requires a rack id on all brokers but broker id %q has none", fmt.Sprint(broker.ID))
+			}
+		}
+	}
+
 	if t.Spec.HasManagedAssignments() && t.Spec.ManagedAssignments.HasRackConstraints() {
 		// Warn if the cluster has no rack ID set on brokers.
 		for _, broker := range brokers {
@@ -203,9 +256,9 @@ func (t TopicDefinition) ValidateWithMetadata(brokers meta.Brokers) error {
 		// Check the rack IDs in the rack constraints are valid.
 		for partition, replicas := range t.Spec.ManagedAssignments.RackConstraints {
 			rackIDCounts := make(map[string]int)
-			for _, rackID := range replicas {
+			for replica, rackID := range replicas {
 				if !str.Contains(rackID, brokers.Racks()) {
-					return fmt.Errorf("invalid rack id %q in rack constraints", rackID)
+					return fieldErrorf(fmt.Sprintf("spec.managedAssignments.rackConstraints.%d.%d", partition, replica), "invalid rack id %q in rack constraints", rackID)
 				}
 				rackIDCounts[rackID]++
 			}
@@ -216,7 +269,8 @@ func (t TopicDefinition) ValidateWithMetadata(brokers meta.Brokers) error {
 			for rackID, count := range rackIDCounts {
 				rackBrokerCount := len(brokersByRack[rackID])
 				if count > rackBrokerCount {
-					return fmt.Errorf(
+					return fieldErrorf(
+						fmt.Sprintf("spec.managedAssignments.rackConstraints.%d", partition),
 						"rack id %q contains %d brokers, but is specified for %d replicas in partition %d",
 						rackID,
 						rackBrokerCount,
@@ -281,17 +335,8 @@ func LoadTopicDefinition(
 ) (TopicDefinition, error) {
 	var def TopicDefinition
 
-	switch format {
-	case opt.YAMLFormat:
-		if err := yaml.Unmarshal([]byte(defDoc), &def); err != nil {
-			return def, err
-		}
-	case opt.JSONFormat:
-		if err := json.Unmarshal([]byte(defDoc), &def); err != nil {
-			return def, err
-		}
-	default:
-		return def, fmt.Errorf("unsupported format")
+	if err := unmarshalDefinition(defDoc, format, &def); err != nil {
+		return def, err
 	}
 
 	// Set defaults
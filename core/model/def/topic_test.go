@@ -103,6 +103,72 @@ func TestTopicDefinition_Validate(t *testing.T) {
 			},
 			wantErr: "balance must be one of",
 		},
+		{
+			name: "Tests log dirs without assignments",
+			topicDef: TopicDefinition{
+				ResourceDefinition: resDef,
+				Spec: TopicSpecDefinition{
+					Partitions:        1,
+					ReplicationFactor: 2,
+					LogDirs: PartitionLogDirs{
+						{"/data1", "any"},
+					},
+				},
+			},
+			wantErr: "log dirs require assignments to be specified",
+		},
+		{
+			name: "Tests invalid number of partition log dirs",
+			topicDef: TopicDefinition{
+				ResourceDefinition: resDef,
+				Spec: TopicSpecDefinition{
+					Partitions:        2,
+					ReplicationFactor: 2,
+					Assignments: PartitionAssignments{
+						{1, 2},
+						{2, 3},
+					},
+					LogDirs: PartitionLogDirs{
+						{"/data1", "any"},
+					},
+				},
+			},
+			wantErr: "number of partition log dirs must match partitions",
+		},
+		{
+			name: "Tests invalid number of log dirs in a partition",
+			topicDef: TopicDefinition{
+				ResourceDefinition: resDef,
+				Spec: TopicSpecDefinition{
+					Partitions:        1,
+					ReplicationFactor: 2,
+					Assignments: PartitionAssignments{
+						{1, 2},
+					},
+					LogDirs: PartitionLogDirs{
+						{"/data1"},
+					},
+				},
+			},
+			wantErr: "number of log dirs in each partition must match replication factor",
+		},
+		{
+			name: "Tests empty log dir",
+			topicDef: TopicDefinition{
+				ResourceDefinition: resDef,
+				Spec: TopicSpecDefinition{
+					Partitions:        1,
+					ReplicationFactor: 2,
+					Assignments: PartitionAssignments{
+						{1, 2},
+					},
+					LogDirs: PartitionLogDirs{
+						{"/data1", ""},
+					},
+				},
+			},
+			wantErr: "log dirs cannot be an empty string",
+		},
 		{
 			name: "Tests invalid selection",
 			topicDef: TopicDefinition{
@@ -118,6 +184,26 @@ func TestTopicDefinition_Validate(t *testing.T) {
 			},
 			wantErr: "selection must be one of",
 		},
+		{
+			name: "Tests rack constraints with rack-aware placement",
+			topicDef: TopicDefinition{
+				ResourceDefinition: resDef,
+				Spec: TopicSpecDefinition{
+					Partitions:        2,
+					ReplicationFactor: 2,
+					ManagedAssignments: &ManagedAssignmentsDefinition{
+						Balance:   "new",
+						Selection: "topic-cluster-use",
+						RackConstraints: PartitionRacks{
+							{"zone-a", "zone-b"},
+							{"zone-b", "zone-a"},
+						},
+						RackAware: true,
+					},
+				},
+			},
+			wantErr: "rack constraints and rack-aware placement cannot be specified together",
+		},
 		{
 			name: "Tests invalid number of rack constraints",
 			topicDef: TopicDefinition{
@@ -343,6 +429,25 @@ func TestTopicDefinition_ValidateWithMetadata(t *testing.T) {
 			},
 			wantErr: "rack id \"zone-c\" contains 2 brokers, but is specified for 3 replicas in partition 2",
 		},
+		{
+			name: "Tests rack-aware placement with a broker without a rack id",
+			topicDef: TopicDefinition{
+				ResourceDefinition: resDef,
+				Spec: TopicSpecDefinition{
+					Partitions:        3,
+					ReplicationFactor: 2,
+					ManagedAssignments: &ManagedAssignmentsDefinition{
+						Balance:   "new",
+						Selection: "topic-cluster-use",
+						RackAware: true,
+					},
+				},
+			},
+			args: args{
+				brokers: append(meta.Brokers{meta.Broker{ID: 7}}, brokers...),
+			},
+			wantErr: "rack-aware placement requires a rack id on all brokers but broker id \"7\" has none",
+		},
 		{
 			name: "Tests a valid TopicDefinition",
 			topicDef: TopicDefinition{
@@ -404,6 +509,36 @@ func TestLoadTopicDefinition(t *testing.T) {
 			want:    TopicDefinition{},
 			wantErr: "property \"topic.foo\" is not overridable",
 		},
+		{
+			name: "Tests an unknown field",
+			args: args{
+				defDoc:        "apiVersion: v1\nkind: topic\nmetadata:\n  name: baz\nspec:\n  partitions: 3\n  replicas: 1",
+				format:        opt.YAMLFormat,
+				propOverrides: nil,
+			},
+			want:    TopicDefinition{},
+			wantErr: "unknown field \"spec.replicas\"",
+		},
+		{
+			name: "Tests an unknown field in a JSON definition",
+			args: args{
+				defDoc:        `{"apiVersion":"v1","kind":"topic","metadata":{"name":"baz","label":"x"},"spec":{"partitions":3}}`,
+				format:        opt.JSONFormat,
+				propOverrides: nil,
+			},
+			want:    TopicDefinition{},
+			wantErr: "unknown field \"metadata.label\"",
+		},
+		{
+			name: "Tests an unknown field in an HCL definition",
+			args: args{
+				defDoc:        "topic \"baz\" {\n  apiVersion = \"v1\"\n  spec {\n    partitions = 3\n    replicas   = 1\n  }\n}",
+				format:        opt.HCLFormat,
+				propOverrides: nil,
+			},
+			want:    TopicDefinition{},
+			wantErr: "unknown field \"spec.replicas\"",
+		},
 		{
 			name: "Tests loading a valid topic definition",
 			args: args{
@@ -430,6 +565,32 @@ func TestLoadTopicDefinition(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "Tests loading a valid HCL topic definition",
+			args: args{
+				defDoc:        "topic \"baz\" {\n  apiVersion = \"v1\"\n  spec {\n    partitions        = 3\n    replicationFactor = 1\n  }\n}",
+				format:        opt.HCLFormat,
+				propOverrides: nil,
+			},
+			want: TopicDefinition{
+				ResourceDefinition: ResourceDefinition{
+					APIVersion: "v1",
+					Kind:       KindTopic,
+					Metadata: ResourceMetadataDefinition{
+						Name: "baz",
+					},
+				},
+				Spec: TopicSpecDefinition{
+					Partitions:        3,
+					ReplicationFactor: 1,
+					ManagedAssignments: &ManagedAssignmentsDefinition{
+						Balance:   BalanceNew,
+						Selection: SelectionTopicClusterUse,
+					},
+				},
+			},
+			wantErr: "",
+		},
 		{
 			name: "Tests loading a valid topic definition with overrides",
 			args: args{
@@ -0,0 +1,60 @@
+// Package meta implements metadata structures and related operations.
+package meta
+
+import "sort"
+
+// ACLBinding represents an ACL entry bound to a resource.
+type ACLBinding struct {
+	ResourceType        string `json:"resourceType"`
+	ResourceName        string `json:"resourceName"`
+	ResourcePatternType string `json:"resourcePatternType"`
+	Principal           string `json:"principal"`
+	Host                string `json:"host"`
+	Operation           string `json:"operation"`
+	PermissionType      string `json:"permissionType"`
+}
+
+// ACLBindings represents a slice of ACLBinding.
+type ACLBindings []ACLBinding
+
+// Sort sorts ACL bindings by resource, principal, host, operation and permission type.
+func (a ACLBindings) Sort() {
+	sort.Slice(a, func(i, j int) bool {
+		x, y := a[i], a[j]
+		if x.ResourceType != y.ResourceType {
+			return x.ResourceType < y.ResourceType
+		}
+		if x.ResourcePatternType != y.ResourcePatternType {
+			return x.ResourcePatternType < y.ResourcePatternType
+		}
+		if x.ResourceName != y.ResourceName {
+			return x.ResourceName < y.ResourceName
+		}
+		if x.Principal != y.Principal {
+			return x.Principal < y.Principal
+		}
+		if x.Host != y.Host {
+			return x.Host < y.Host
+		}
+		if x.Operation != y.Operation {
+			return x.Operation < y.Operation
+		}
+		return x.PermissionType < y.PermissionType
+	})
+}
+
+// ACLCheckRequest represents a request to be authorized by ACLs.
+type ACLCheckRequest struct {
+	Principal    string `json:"principal"`
+	Host         string `json:"host"`
+	Operation    string `json:"operation"`
+	ResourceType string `json:"resourceType"`
+	ResourceName string `json:"resourceName"`
+}
+
+// ACLCheckDecision represents the authorization decision of a request and the ACLs that caused it.
+type ACLCheckDecision struct {
+	Allowed      bool        `json:"allowed"`
+	Reason       string      `json:"reason"`
+	MatchingACLs ACLBindings `json:"matchingAcls"`
+}
@@ -0,0 +1,73 @@
+// Package meta implements metadata structures and related operations.
+package meta
+
+import "sort"
+
+// Feature represents the supported version range and finalized version level of a cluster feature.
+type Feature struct {
+	Name           string `json:"name"`
+	MinVersion     int16  `json:"minVersion"`
+	MaxVersion     int16  `json:"maxVersion"`
+	FinalizedLevel int16  `json:"finalizedLevel"`
+}
+
+// Features represents a slice of Feature.
+type Features []Feature
+
+// Get returns the feature with the specified name.
+func (f Features) Get(name string) (Feature, bool) {
+	for _, feature := range f {
+		if feature.Name == name {
+			return feature, true
+		}
+	}
+	return Feature{}, false
+}
+
+// FinalizedLevels returns the finalized version levels of features that are enabled.
+func (f Features) FinalizedLevels() map[string]int16 {
+	levels := make(map[string]int16)
+	for _, feature := range f {
+		if feature.FinalizedLevel > 0 {
+			levels[feature.Name] = feature.FinalizedLevel
+		}
+	}
+	return levels
+}
+
+// IntersectFeatures returns the features supported by all brokers, narrowed to the version range supported by all brokers.
+func IntersectFeatures(brokerFeatures []Features) Features {
+	if len(brokerFeatures) == 0 {
+		return nil
+	}
+
+	var features Features
+	for _, feature := range brokerFeatures[0] {
+		supported := true
+		for _, other := range brokerFeatures[1:] {
+			o, ok := other.Get(feature.Name)
+			if !ok {
+				supported = false
+				break
+			}
+			if o.MinVersion > feature.MinVersion {
+				feature.MinVersion = o.MinVersion
+			}
+			if o.MaxVersion < feature.MaxVersion {
+				feature.MaxVersion = o.MaxVersion
+			}
+			if o.FinalizedLevel > feature.FinalizedLevel {
+				feature.FinalizedLevel = o.FinalizedLevel
+			}
+		}
+		if supported {
+			features = append(features, feature)
+		}
+	}
+
+	sort.Slice(features, func(i, j int) bool {
+		return features[i].Name < features[j].Name
+	})
+
+	return features
+}
@@ -0,0 +1,99 @@
+// Package meta implements metadata structures and related operations.
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFeatures_FinalizedLevels(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Features
+		want map[string]int16
+	}{
+		{
+			name: "Tests finalized levels excluding disabled features",
+			f: Features{
+				{Name: "metadata.version", MinVersion: 1, MaxVersion: 19, FinalizedLevel: 14},
+				{Name: "kraft.version", MinVersion: 0, MaxVersion: 1, FinalizedLevel: 0},
+			},
+			want: map[string]int16{
+				"metadata.version": 14,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.FinalizedLevels(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Features.FinalizedLevels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntersectFeatures(t *testing.T) {
+	type args struct {
+		brokerFeatures []Features
+	}
+	tests := []struct {
+		name string
+		args args
+		want Features
+	}{
+		{
+			name: "Tests no brokers",
+			args: args{
+				brokerFeatures: nil,
+			},
+			want: nil,
+		},
+		{
+			name: "Tests intersection of supported version ranges",
+			args: args{
+				brokerFeatures: []Features{
+					{
+						{Name: "metadata.version", MinVersion: 1, MaxVersion: 19, FinalizedLevel: 14},
+						{Name: "kraft.version", MinVersion: 0, MaxVersion: 1, FinalizedLevel: 0},
+					},
+					{
+						{Name: "metadata.version", MinVersion: 1, MaxVersion: 17, FinalizedLevel: 14},
+						{Name: "kraft.version", MinVersion: 0, MaxVersion: 1, FinalizedLevel: 0},
+					},
+					{
+						{Name: "metadata.version", MinVersion: 7, MaxVersion: 19, FinalizedLevel: 14},
+						{Name: "kraft.version", MinVersion: 0, MaxVersion: 1, FinalizedLevel: 0},
+					},
+				},
+			},
+			want: Features{
+				{Name: "kraft.version", MinVersion: 0, MaxVersion: 1, FinalizedLevel: 0},
+				{Name: "metadata.version", MinVersion: 7, MaxVersion: 17, FinalizedLevel: 14},
+			},
+		},
+		{
+			name: "Tests exclusion of features not supported by all brokers",
+			args: args{
+				brokerFeatures: []Features{
+					{
+						{Name: "metadata.version", MinVersion: 1, MaxVersion: 19, FinalizedLevel: 14},
+						{Name: "group.version", MinVersion: 0, MaxVersion: 1, FinalizedLevel: 0},
+					},
+					{
+						{Name: "metadata.version", MinVersion: 1, MaxVersion: 19, FinalizedLevel: 14},
+					},
+				},
+			},
+			want: Features{
+				{Name: "metadata.version", MinVersion: 1, MaxVersion: 19, FinalizedLevel: 14},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IntersectFeatures(tt.args.brokerFeatures); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IntersectFeatures() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Package meta implements metadata structures and related operations.
+package meta
+
+// Group states of groups without active members.
+const (
+	GroupStateEmpty = "Empty"
+	GroupStateDead  = "Dead"
+)
+
+// Group represents a group and its membership.
+type Group struct {
+	Group        string `json:"group"`
+	ProtocolType string `json:"protocolType"`
+	State        string `json:"state"`
+	Members      int    `json:"members"`
+}
+
+// IsEmpty determines if the group has no active members.
+func (g Group) IsEmpty() bool {
+	return g.Members == 0 && (g.State == GroupStateEmpty || g.State == GroupStateDead)
+}
+
+// Groups represents a slice of Group.
+type Groups []Group
+
+// Names returns the names of the groups.
+func (g Groups) Names() []string {
+	names := make([]string, len(g))
+	for i, group := range g {
+		names[i] = group.Group
+	}
+	return names
+}
+
+// GroupOffsets represents offsets by topic and partition.
+type GroupOffsets map[string]map[int32]int64
+
+// Topics returns the partitions of the offsets by topic.
+func (g GroupOffsets) Topics() map[string][]int32 {
+	topics := make(map[string][]int32, len(g))
+	for topic, partitions := range g {
+		for partition := range partitions {
+			topics[topic] = append(topics[topic], partition)
+		}
+	}
+	return topics
+}
+
+// GroupActivity represents whether a group has consumed records produced since a point in time.
+type GroupActivity int
+
+// Group activity values.
+const (
+	GroupActivityUnknown GroupActivity = iota
+	GroupActivityActive
+	GroupActivityInactive
+)
+
+// ActivitySince determines if committed offsets consumed records produced since a point in time.
+// The offsets since are those of the first records produced to each partition since that time,
+// where an offset of -1 indicates that no records have been produced to a partition since that time.
+//
+// Activity is:
+//   - active if any committed offset is beyond the offset of the first record produced since that time.
+//   - inactive if otherwise records have been produced since that time to a partition with a committed offset.
+//   - unknown if otherwise no records have been produced since that time to any partition with a committed offset,
+//     including when there are no committed offsets.
+func (g GroupOffsets) ActivitySince(offsetsSince GroupOffsets) GroupActivity {
+	activity := GroupActivityUnknown
+	for topic, partitions := range g {
+		for partition, committed := range partitions {
+			since, ok := offsetsSince[topic][partition]
+			if !ok || committed < 0 || since < 0 {
+				continue
+			}
+			if committed > since {
+				return GroupActivityActive
+			}
+			activity = GroupActivityInactive
+		}
+	}
+	return activity
+}
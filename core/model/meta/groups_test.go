@@ -0,0 +1,112 @@
+// Package meta implements metadata structures and related operations.
+package meta
+
+import (
+	"testing"
+)
+
+func TestGroup_IsEmpty(t *testing.T) {
+	tests := []struct {
+		name  string
+		group Group
+		want  bool
+	}{
+		{
+			name:  "Tests an empty group",
+			group: Group{Group: "foo", State: GroupStateEmpty, Members: 0},
+			want:  true,
+		},
+		{
+			name:  "Tests a dead group",
+			group: Group{Group: "foo", State: GroupStateDead, Members: 0},
+			want:  true,
+		},
+		{
+			name:  "Tests a stable group",
+			group: Group{Group: "foo", State: "Stable", Members: 2},
+			want:  false,
+		},
+		{
+			name:  "Tests a rebalancing group without members",
+			group: Group{Group: "foo", State: "PreparingRebalance", Members: 0},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.group.IsEmpty(); got != tt.want {
+				t.Errorf("Group.IsEmpty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupOffsets_ActivitySince(t *testing.T) {
+	committed := GroupOffsets{
+		"foo": {0: 100, 1: 50},
+		"bar": {0: 10},
+	}
+
+	tests := []struct {
+		name         string
+		committed    GroupOffsets
+		offsetsSince GroupOffsets
+		want         GroupActivity
+	}{
+		{
+			name:      "Tests records consumed since",
+			committed: committed,
+			offsetsSince: GroupOffsets{
+				"foo": {0: 100, 1: 40},
+				"bar": {0: -1},
+			},
+			want: GroupActivityActive,
+		},
+		{
+			name:      "Tests no records consumed since",
+			committed: committed,
+			offsetsSince: GroupOffsets{
+				"foo": {0: 100, 1: 50},
+				"bar": {0: 10},
+			},
+			want: GroupActivityInactive,
+		},
+		{
+			name:      "Tests no records consumed since with an idle partition",
+			committed: committed,
+			offsetsSince: GroupOffsets{
+				"foo": {0: -1, 1: 50},
+				"bar": {0: -1},
+			},
+			want: GroupActivityInactive,
+		},
+		{
+			name:      "Tests no records produced since",
+			committed: committed,
+			offsetsSince: GroupOffsets{
+				"foo": {0: -1, 1: -1},
+				"bar": {0: -1},
+			},
+			want: GroupActivityUnknown,
+		},
+		{
+			name:         "Tests missing offsets",
+			committed:    committed,
+			offsetsSince: GroupOffsets{},
+			want:         GroupActivityUnknown,
+		},
+		{
+			name:         "Tests no committed offsets",
+			committed:    GroupOffsets{},
+			offsetsSince: GroupOffsets{},
+			want:         GroupActivityUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.committed.ActivitySince(tt.offsetsSince); got != tt.want {
+				t.Errorf("GroupOffsets.ActivitySince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
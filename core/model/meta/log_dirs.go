@@ -0,0 +1,86 @@
+// Package meta implements metadata structures and related operations.
+package meta
+
+// LogDir represents a broker log directory.
+// Total and usable bytes are -1 if not reported by the broker (Kafka 3.3.0+).
+type LogDir struct {
+	Broker      int32
+	Dir         string
+	TotalBytes  int64
+	UsableBytes int64
+}
+
+// LogDirs represents a slice of LogDir.
+type LogDirs []LogDir
+
+// UsableBytesByBroker returns the usable bytes of each broker's log directories.
+// The usable bytes of a broker are -1 if any of its log directories do not report them.
+func (l LogDirs) UsableBytesByBroker() map[int32]int64 {
+	usable := make(map[int32]int64)
+	for _, dir := range l {
+		if dir.UsableBytes < 0 || usable[dir.Broker] < 0 {
+			usable[dir.Broker] = -1
+			continue
+		}
+		usable[dir.Broker] += dir.UsableBytes
+	}
+	return usable
+}
+
+// ReplicaLogDir represents a partition replica in a broker log directory.
+type ReplicaLogDir struct {
+	Broker    int32
+	Dir       string
+	Topic     string
+	Partition int32
+	Size      int64
+	IsFuture  bool
+}
+
+// ReplicaLogDirs represents a slice of ReplicaLogDir.
+type ReplicaLogDirs []ReplicaLogDir
+
+// PartitionSizes returns the size in bytes of each partition by topic.
+// The size of a partition is the size of its largest current replica.
+func (r ReplicaLogDirs) PartitionSizes() map[string]map[int32]int64 {
+	sizes := make(map[string]map[int32]int64)
+	for _, replica := range r {
+		// Future replicas are copies in the process of moving between log directories.
+		if replica.IsFuture {
+			continue
+		}
+		if _, ok := sizes[replica.Topic]; !ok {
+			sizes[replica.Topic] = make(map[int32]int64)
+		}
+		if replica.Size > sizes[replica.Topic][replica.Partition] {
+			sizes[replica.Topic][replica.Partition] = replica.Size
+		}
+	}
+	return sizes
+}
+
+// SizesByBroker returns the total size in bytes of replicas on each broker.
+// Future replicas are included as they occupy disk space.
+func (r ReplicaLogDirs) SizesByBroker() map[int32]int64 {
+	sizes := make(map[int32]int64)
+	for _, replica := range r {
+		sizes[replica.Broker] += replica.Size
+	}
+	return sizes
+}
+
+// Dirs returns the log directories of a topic's replicas by partition and broker.
+// Future replicas are returned instead of current replicas if future is set.
+func (r ReplicaLogDirs) Dirs(topic string, future bool) map[int32]map[int32]string {
+	dirs := make(map[int32]map[int32]string)
+	for _, replica := range r {
+		if replica.Topic != topic || replica.IsFuture != future {
+			continue
+		}
+		if _, ok := dirs[replica.Partition]; !ok {
+			dirs[replica.Partition] = make(map[int32]string)
+		}
+		dirs[replica.Partition][replica.Broker] = replica.Dir
+	}
+	return dirs
+}
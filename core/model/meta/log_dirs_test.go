@@ -0,0 +1,152 @@
+// Package meta implements metadata structures and related operations.
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLogDirs_UsableBytesByBroker(t *testing.T) {
+	tests := []struct {
+		name string
+		l    LogDirs
+		want map[int32]int64
+	}{
+		{
+			name: "Test no log directories",
+			l:    LogDirs{},
+			want: map[int32]int64{},
+		},
+		{
+			name: "Test usable bytes summed by broker",
+			l: LogDirs{
+				{Broker: 1, Dir: "/data1", TotalBytes: 1000, UsableBytes: 400},
+				{Broker: 1, Dir: "/data2", TotalBytes: 1000, UsableBytes: 100},
+				{Broker: 2, Dir: "/data1", TotalBytes: 1000, UsableBytes: 900},
+			},
+			want: map[int32]int64{1: 500, 2: 900},
+		},
+		{
+			name: "Test unreported usable bytes",
+			l: LogDirs{
+				{Broker: 1, Dir: "/data1", TotalBytes: 1000, UsableBytes: 400},
+				{Broker: 1, Dir: "/data2", TotalBytes: -1, UsableBytes: -1},
+				{Broker: 1, Dir: "/data3", TotalBytes: 1000, UsableBytes: 400},
+				{Broker: 2, Dir: "/data1", TotalBytes: 1000, UsableBytes: 900},
+			},
+			want: map[int32]int64{1: -1, 2: 900},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.l.UsableBytesByBroker(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LogDirs.UsableBytesByBroker() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplicaLogDirs_PartitionSizes(t *testing.T) {
+	tests := []struct {
+		name string
+		r    ReplicaLogDirs
+		want map[string]map[int32]int64
+	}{
+		{
+			name: "Test no replicas",
+			r:    ReplicaLogDirs{},
+			want: map[string]map[int32]int64{},
+		},
+		{
+			name: "Test largest current replica size",
+			r: ReplicaLogDirs{
+				{Broker: 1, Dir: "/data", Topic: "foo", Partition: 0, Size: 100},
+				{Broker: 2, Dir: "/data", Topic: "foo", Partition: 0, Size: 120},
+				{Broker: 3, Dir: "/data", Topic: "foo", Partition: 0, Size: 500, IsFuture: true},
+				{Broker: 1, Dir: "/data", Topic: "foo", Partition: 1, Size: 50},
+				{Broker: 2, Dir: "/data", Topic: "bar", Partition: 0, Size: 10},
+			},
+			want: map[string]map[int32]int64{
+				"foo": {0: 120, 1: 50},
+				"bar": {0: 10},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.PartitionSizes(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReplicaLogDirs.PartitionSizes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplicaLogDirs_SizesByBroker(t *testing.T) {
+	tests := []struct {
+		name string
+		r    ReplicaLogDirs
+		want map[int32]int64
+	}{
+		{
+			name: "Test no replicas",
+			r:    ReplicaLogDirs{},
+			want: map[int32]int64{},
+		},
+		{
+			name: "Test replica sizes summed by broker",
+			r: ReplicaLogDirs{
+				{Broker: 1, Dir: "/data", Topic: "foo", Partition: 0, Size: 100},
+				{Broker: 1, Dir: "/data", Topic: "foo", Partition: 1, Size: 50},
+				{Broker: 2, Dir: "/data", Topic: "foo", Partition: 0, Size: 120},
+				{Broker: 2, Dir: "/data", Topic: "bar", Partition: 0, Size: 30, IsFuture: true},
+			},
+			want: map[int32]int64{1: 150, 2: 150},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.SizesByBroker(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReplicaLogDirs.SizesByBroker() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplicaLogDirs_Dirs(t *testing.T) {
+	r := ReplicaLogDirs{
+		{Broker: 1, Dir: "/data1", Topic: "foo", Partition: 0, Size: 100},
+		{Broker: 1, Dir: "/data2", Topic: "foo", Partition: 0, Size: 50, IsFuture: true},
+		{Broker: 2, Dir: "/data1", Topic: "foo", Partition: 0, Size: 100},
+		{Broker: 2, Dir: "/data2", Topic: "foo", Partition: 1, Size: 100},
+		{Broker: 1, Dir: "/data1", Topic: "bar", Partition: 0, Size: 100},
+	}
+
+	tests := []struct {
+		name   string
+		future bool
+		want   map[int32]map[int32]string
+	}{
+		{
+			name:   "Test current replicas",
+			future: false,
+			want: map[int32]map[int32]string{
+				0: {1: "/data1", 2: "/data1"},
+				1: {2: "/data2"},
+			},
+		},
+		{
+			name:   "Test future replicas",
+			future: true,
+			want: map[int32]map[int32]string{
+				0: {1: "/data2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Dirs("foo", tt.future); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReplicaLogDirs.Dirs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
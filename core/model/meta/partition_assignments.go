@@ -0,0 +1,53 @@
+// Package meta implements metadata structures and related operations.
+package meta
+
+// TopicPartitionAssignment represents the replica assignment of a topic partition.
+type TopicPartitionAssignment struct {
+	Topic     string  `json:"topic"`
+	Partition int32   `json:"partition"`
+	Replicas  []int32 `json:"replicas"`
+}
+
+// TopicPartitionAssignments represents a slice of TopicPartitionAssignment.
+type TopicPartitionAssignments []TopicPartitionAssignment
+
+// Batches splits the assignments into batches containing a maximum number of assignments.
+// A batch size less than 1 results in a single batch.
+func (t TopicPartitionAssignments) Batches(size int) []TopicPartitionAssignments {
+	if len(t) == 0 {
+		return nil
+	}
+	if size < 1 || size >= len(t) {
+		return []TopicPartitionAssignments{t}
+	}
+
+	var batches []TopicPartitionAssignments
+	for start := 0; start < len(t); start += size {
+		end := start + size
+		if end > len(t) {
+			end = len(t)
+		}
+		batches = append(batches, t[start:end])
+	}
+	return batches
+}
+
+// ReplicationThrottle represents the throttled replicas of a topic during partition reassignments.
+// Replicas are identified in the format "partition:broker".
+type ReplicationThrottle struct {
+	Topic            string
+	LeaderReplicas   []string
+	FollowerReplicas []string
+}
+
+// ReplicationThrottles represents a slice of ReplicationThrottle.
+type ReplicationThrottles []ReplicationThrottle
+
+// Topics returns the topics of the replication throttles.
+func (r ReplicationThrottles) Topics() []string {
+	topics := make([]string, len(r))
+	for i, t := range r {
+		topics[i] = t.Topic
+	}
+	return topics
+}
@@ -0,0 +1,70 @@
+// Package meta implements metadata structures and related operations.
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTopicPartitionAssignments_Batches(t *testing.T) {
+	assignments := TopicPartitionAssignments{
+		{Topic: "foo", Partition: 0, Replicas: []int32{1, 2}},
+		{Topic: "foo", Partition: 1, Replicas: []int32{2, 3}},
+		{Topic: "foo", Partition: 2, Replicas: []int32{3, 1}},
+		{Topic: "bar", Partition: 0, Replicas: []int32{1, 2}},
+		{Topic: "bar", Partition: 1, Replicas: []int32{2, 3}},
+	}
+
+	tests := []struct {
+		name string
+		t    TopicPartitionAssignments
+		size int
+		want []TopicPartitionAssignments
+	}{
+		{
+			name: "Test empty assignments",
+			t:    TopicPartitionAssignments{},
+			size: 2,
+			want: nil,
+		},
+		{
+			name: "Test unlimited batch size",
+			t:    assignments,
+			size: 0,
+			want: []TopicPartitionAssignments{assignments},
+		},
+		{
+			name: "Test batch size greater than assignments",
+			t:    assignments,
+			size: 10,
+			want: []TopicPartitionAssignments{assignments},
+		},
+		{
+			name: "Test batches with remainder",
+			t:    assignments,
+			size: 2,
+			want: []TopicPartitionAssignments{
+				assignments[0:2],
+				assignments[2:4],
+				assignments[4:5],
+			},
+		},
+		{
+			name: "Test batches of one",
+			t:    assignments[0:3],
+			size: 1,
+			want: []TopicPartitionAssignments{
+				assignments[0:1],
+				assignments[1:2],
+				assignments[2:3],
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.Batches(tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TopicPartitionAssignments.Batches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
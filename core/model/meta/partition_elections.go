@@ -0,0 +1,45 @@
+// Package meta implements metadata structures and related operations.
+package meta
+
+import "sort"
+
+// PartitionElection represents the leader election of a partition.
+type PartitionElection struct {
+	Topic     string  `json:"topic"`
+	Partition int32   `json:"partition"`
+	Replicas  []int32 `json:"replicas"`
+	ISR       []int32 `json:"isr"`
+	Leader    int32   `json:"leader"`
+	Err       string  `json:"error"`
+}
+
+// PartitionElections represents a slice of PartitionElection.
+type PartitionElections []PartitionElection
+
+// Sort sorts by topic and partition ID.
+func (p PartitionElections) Sort() {
+	sort.Slice(p, func(i, j int) bool {
+		return p[i].Topic < p[j].Topic ||
+			p[i].Topic == p[j].Topic && p[i].Partition < p[j].Partition
+	})
+}
+
+// Partitions returns the partitions of the elections by topic.
+func (p PartitionElections) Partitions() map[string][]int32 {
+	partitions := make(map[string][]int32)
+	for _, e := range p {
+		partitions[e.Topic] = append(partitions[e.Topic], e.Partition)
+	}
+	return partitions
+}
+
+// Failed returns the number of elections with an error.
+func (p PartitionElections) Failed() int {
+	failed := 0
+	for _, e := range p {
+		if len(e.Err) > 0 {
+			failed++
+		}
+	}
+	return failed
+}
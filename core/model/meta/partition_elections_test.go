@@ -0,0 +1,29 @@
+// Package meta implements metadata structures and related operations.
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPartitionElections(t *testing.T) {
+	p := PartitionElections{
+		{Topic: "foo", Partition: 1, Err: "ELIGIBLE_LEADERS_NOT_AVAILABLE"},
+		{Topic: "bar", Partition: 0},
+		{Topic: "foo", Partition: 0},
+	}
+
+	p.Sort()
+	if p[0].Topic != "bar" || p[1].Partition != 0 || p[2].Partition != 1 {
+		t.Errorf("PartitionElections.Sort() = %v", p)
+	}
+
+	wantPartitions := map[string][]int32{"bar": {0}, "foo": {0, 1}}
+	if got := p.Partitions(); !reflect.DeepEqual(got, wantPartitions) {
+		t.Errorf("PartitionElections.Partitions() = %v, want %v", got, wantPartitions)
+	}
+
+	if got := p.Failed(); got != 1 {
+		t.Errorf("PartitionElections.Failed() = %v, want %v", got, 1)
+	}
+}
@@ -5,6 +5,7 @@ import "github.com/bradfitz/slice" //nolint
 
 // PartitionReassignment represents a partition reassignment.
 type PartitionReassignment struct {
+	Topic            string  `json:"topic,omitempty"`
 	Partition        int32   `json:"partition"`
 	Replicas         []int32 `json:"replicas"`
 	AddingReplicas   []int32 `json:"addingReplicas"`
@@ -14,11 +15,25 @@ type PartitionReassignment struct {
 // PartitionReassignments represents a slice of PartitionReassignment.
 type PartitionReassignments []PartitionReassignment
 
-// Sort sorts by partition ID.
+// Sort sorts by topic and partition ID.
 func (p PartitionReassignments) Sort() {
 	// TODO: Use sort.Slice in the standard library after upgrading to Go 1.8.
 	//nolint
 	slice.Sort(p[:], func(i, j int) bool {
-		return p[i].Partition < p[j].Partition
+		return p[i].Topic < p[j].Topic ||
+			p[i].Topic == p[j].Topic && p[i].Partition < p[j].Partition
 	})
 }
+
+// Topics returns a unique slice of the topics being reassigned.
+func (p PartitionReassignments) Topics() []string {
+	k := make(map[string]bool)
+	topics := []string{}
+	for _, r := range p {
+		if _, ok := k[r.Topic]; !ok {
+			k[r.Topic] = true
+			topics = append(topics, r.Topic)
+		}
+	}
+	return topics
+}
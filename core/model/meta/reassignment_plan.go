@@ -0,0 +1,106 @@
+// Package meta implements metadata structures and related operations.
+package meta
+
+import (
+	"fmt"
+
+	"github.com/peter-evans/kdef/core/util/i32"
+)
+
+// ReassignmentPlanVersion is the supported version of the reassignment plan format.
+const ReassignmentPlanVersion int = 1
+
+// reassignmentPlanAnyLogDir is the log dir placeholder allowing the broker to choose the log dir.
+const reassignmentPlanAnyLogDir string = "any"
+
+// ReassignmentPlanPartition represents the replica assignment of a partition in a reassignment plan.
+type ReassignmentPlanPartition struct {
+	Topic     string   `json:"topic"`
+	Partition int32    `json:"partition"`
+	Replicas  []int32  `json:"replicas"`
+	LogDirs   []string `json:"log_dirs,omitempty"`
+}
+
+// ReassignmentPlan represents a reassignment plan in the JSON format of the kafka-reassign-partitions tool.
+type ReassignmentPlan struct {
+	Version    int                         `json:"version"`
+	Partitions []ReassignmentPlanPartition `json:"partitions"`
+}
+
+// NewReassignmentPlan creates a reassignment plan from topic partition assignments.
+func NewReassignmentPlan(assignments TopicPartitionAssignments) ReassignmentPlan {
+	partitions := make([]ReassignmentPlanPartition, len(assignments))
+	for i, a := range assignments {
+		partitions[i] = ReassignmentPlanPartition{
+			Topic:     a.Topic,
+			Partition: a.Partition,
+			Replicas:  a.Replicas,
+		}
+	}
+	return ReassignmentPlan{
+		Version:    ReassignmentPlanVersion,
+		Partitions: partitions,
+	}
+}
+
+// Assignments returns the topic partition assignments of the reassignment plan.
+func (r ReassignmentPlan) Assignments() TopicPartitionAssignments {
+	assignments := make(TopicPartitionAssignments, len(r.Partitions))
+	for i, p := range r.Partitions {
+		assignments[i] = TopicPartitionAssignment{
+			Topic:     p.Topic,
+			Partition: p.Partition,
+			Replicas:  p.Replicas,
+		}
+	}
+	return assignments
+}
+
+// Validate validates the reassignment plan.
+func (r ReassignmentPlan) Validate() error {
+	if r.Version != ReassignmentPlanVersion {
+		return fmt.Errorf("unsupported reassignment plan version %d", r.Version)
+	}
+
+	seen := make(map[string]map[int32]bool)
+	for _, p := range r.Partitions {
+		if len(p.Topic) == 0 {
+			return fmt.Errorf("topic cannot be an empty string")
+		}
+		if p.Partition < 0 {
+			return fmt.Errorf("invalid partition %d of topic %q", p.Partition, p.Topic)
+		}
+		if _, ok := seen[p.Topic]; !ok {
+			seen[p.Topic] = make(map[int32]bool)
+		}
+		if seen[p.Topic][p.Partition] {
+			return fmt.Errorf("partition %d of topic %q is specified more than once", p.Partition, p.Topic)
+		}
+		seen[p.Topic][p.Partition] = true
+
+		if len(p.Replicas) == 0 {
+			return fmt.Errorf("partition %d of topic %q must have at least one replica", p.Partition, p.Topic)
+		}
+		if i32.ContainsDuplicate(p.Replicas) {
+			return fmt.Errorf("partition %d of topic %q cannot contain duplicate replicas", p.Partition, p.Topic)
+		}
+		if len(p.LogDirs) > 0 {
+			if len(p.LogDirs) != len(p.Replicas) {
+				return fmt.Errorf("number of log dirs must match replicas in partition %d of topic %q", p.Partition, p.Topic)
+			}
+			for _, logDir := range p.LogDirs {
+				if logDir != reassignmentPlanAnyLogDir {
+					return fmt.Errorf(
+						"log dir %q in partition %d of topic %q is not supported; only %q is supported",
+						logDir,
+						p.Partition,
+						p.Topic,
+						reassignmentPlanAnyLogDir,
+					)
+				}
+			}
+		}
+	}
+
+	return nil
+}
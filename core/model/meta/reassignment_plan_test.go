@@ -0,0 +1,106 @@
+// Package meta implements metadata structures and related operations.
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReassignmentPlan_Assignments(t *testing.T) {
+	assignments := TopicPartitionAssignments{
+		{Topic: "foo", Partition: 0, Replicas: []int32{1, 2}},
+		{Topic: "bar", Partition: 3, Replicas: []int32{3, 1}},
+	}
+
+	plan := NewReassignmentPlan(assignments)
+	if plan.Version != ReassignmentPlanVersion {
+		t.Errorf("NewReassignmentPlan() version = %v, want %v", plan.Version, ReassignmentPlanVersion)
+	}
+	if got := plan.Assignments(); !reflect.DeepEqual(got, assignments) {
+		t.Errorf("ReassignmentPlan.Assignments() = %v, want %v", got, assignments)
+	}
+}
+
+func TestReassignmentPlan_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       ReassignmentPlan
+		wantErr string
+	}{
+		{
+			name: "Test valid plan",
+			r: ReassignmentPlan{
+				Version: 1,
+				Partitions: []ReassignmentPlanPartition{
+					{Topic: "foo", Partition: 0, Replicas: []int32{1, 2}},
+					{Topic: "foo", Partition: 1, Replicas: []int32{2, 3}, LogDirs: []string{"any", "any"}},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name:    "Test unsupported version",
+			r:       ReassignmentPlan{Version: 2},
+			wantErr: "unsupported reassignment plan version 2",
+		},
+		{
+			name: "Test duplicate partition",
+			r: ReassignmentPlan{
+				Version: 1,
+				Partitions: []ReassignmentPlanPartition{
+					{Topic: "foo", Partition: 0, Replicas: []int32{1, 2}},
+					{Topic: "foo", Partition: 0, Replicas: []int32{2, 3}},
+				},
+			},
+			wantErr: "partition 0 of topic \"foo\" is specified more than once",
+		},
+		{
+			name: "Test no replicas",
+			r: ReassignmentPlan{
+				Version: 1,
+				Partitions: []ReassignmentPlanPartition{
+					{Topic: "foo", Partition: 0},
+				},
+			},
+			wantErr: "partition 0 of topic \"foo\" must have at least one replica",
+		},
+		{
+			name: "Test duplicate replicas",
+			r: ReassignmentPlan{
+				Version: 1,
+				Partitions: []ReassignmentPlanPartition{
+					{Topic: "foo", Partition: 0, Replicas: []int32{1, 1}},
+				},
+			},
+			wantErr: "partition 0 of topic \"foo\" cannot contain duplicate replicas",
+		},
+		{
+			name: "Test log dirs length mismatch",
+			r: ReassignmentPlan{
+				Version: 1,
+				Partitions: []ReassignmentPlanPartition{
+					{Topic: "foo", Partition: 0, Replicas: []int32{1, 2}, LogDirs: []string{"any"}},
+				},
+			},
+			wantErr: "number of log dirs must match replicas in partition 0 of topic \"foo\"",
+		},
+		{
+			name: "Test unsupported log dir",
+			r: ReassignmentPlan{
+				Version: 1,
+				Partitions: []ReassignmentPlanPartition{
+					{Topic: "foo", Partition: 0, Replicas: []int32{1}, LogDirs: []string{"/data"}},
+				},
+			},
+			wantErr: "log dir \"/data\" in partition 0 of topic \"foo\" is not supported; only \"any\" is supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.r.Validate()
+			if (err != nil || len(tt.wantErr) > 0) && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("ReassignmentPlan.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -9,10 +9,11 @@ const (
 	UnsupportedFormat DefinitionFormat = 0
 	YAMLFormat        DefinitionFormat = 1
 	JSONFormat        DefinitionFormat = 2
+	HCLFormat         DefinitionFormat = 3
 )
 
 // DefinitionFormatValidValues represents valid values for definition format.
-var DefinitionFormatValidValues = []string{"yaml", "json"}
+var DefinitionFormatValidValues = []string{"yaml", "json", "hcl"}
 
 // ParseDefinitionFormat parses a definition format from a string.
 func ParseDefinitionFormat(format string) DefinitionFormat {
@@ -21,6 +22,8 @@ func ParseDefinitionFormat(format string) DefinitionFormat {
 		return YAMLFormat
 	case "json":
 		return JSONFormat
+	case "hcl":
+		return HCLFormat
 	default:
 		return UnsupportedFormat
 	}
@@ -33,6 +36,8 @@ func (d DefinitionFormat) Ext() string {
 		return "yml"
 	case JSONFormat:
 		return "json"
+	case HCLFormat:
+		return "hcl"
 	default:
 		return "unsupported"
 	}
@@ -0,0 +1,33 @@
+// Package res implements structures handling the result of operations.
+package res
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/peter-evans/kdef/core/model/meta"
+)
+
+// ACLCheckResult represents the result of an ACL check operation.
+type ACLCheckResult struct {
+	Request  meta.ACLCheckRequest  `json:"request"`
+	Decision meta.ACLCheckDecision `json:"decision"`
+	Err      string                `json:"error"`
+}
+
+// GetErr returns the error of an ACL check operation.
+func (a ACLCheckResult) GetErr() error {
+	if len(a.Err) > 0 {
+		return fmt.Errorf("%s", a.Err)
+	}
+	return nil
+}
+
+// JSON converts an ACL check result to JSON.
+func (a ACLCheckResult) JSON() (string, error) {
+	j, err := json.Marshal(a)
+	if err != nil {
+		return "", err
+	}
+	return string(j), nil
+}
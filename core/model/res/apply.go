@@ -3,8 +3,10 @@ package res
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/peter-evans/kdef/core/model/def"
 	"github.com/peter-evans/kdef/core/model/meta"
 )
 
@@ -16,14 +18,30 @@ type ApplyResult struct {
 	Diff      string      `json:"diff"`
 	Err       string      `json:"error"`
 	Applied   bool        `json:"applied"`
+
+	// The path of the definition field the error relates to, if known.
+	errPath string
+}
+
+// SetErr sets the error of an apply, retaining the path of the definition field it relates to.
+func (a *ApplyResult) SetErr(err error) {
+	a.Err = err.Error()
+	var fieldErr *def.FieldError
+	if errors.As(err, &fieldErr) {
+		a.errPath = fieldErr.Path
+	}
 }
 
 // GetErr returns the error of an apply.
+// Errors relating to a definition field are returned as a def.FieldError.
 func (a ApplyResult) GetErr() error {
-	if len(a.Err) > 0 {
-		return fmt.Errorf("%s", a.Err)
+	if len(a.Err) == 0 {
+		return nil
+	}
+	if len(a.errPath) > 0 {
+		return &def.FieldError{Path: a.errPath, Err: errors.New(a.Err)}
 	}
-	return nil
+	return fmt.Errorf("%s", a.Err)
 }
 
 // HasUnappliedChanges determines if the apply has unapplied changes.
@@ -68,5 +86,20 @@ func (a ApplyResults) JSON() (string, error) {
 
 // TopicApplyResultData represents misc data for a topic apply result.
 type TopicApplyResultData struct {
-	PartitionReassignments []meta.PartitionReassignment `json:"partitionReassignments"`
+	PartitionReassignments []meta.PartitionReassignment   `json:"partitionReassignments"`
+	PartitionMoves         meta.TopicPartitionAssignments `json:"partitionMoves"`
+}
+
+// *** Groups apply specific ***
+
+// GroupsApplyResultData represents misc data for a groups apply result.
+type GroupsApplyResultData struct {
+	StaleGroups meta.Groups `json:"staleGroups"`
+}
+
+// *** ACL prune specific ***
+
+// ACLPruneApplyResultData represents misc data for an acl prune apply result.
+type ACLPruneApplyResultData struct {
+	PrunedACLs meta.ACLBindings `json:"prunedAcls"`
 }
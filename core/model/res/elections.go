@@ -0,0 +1,33 @@
+// Package res implements structures handling the result of operations.
+package res
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/peter-evans/kdef/core/model/meta"
+)
+
+// ElectionsResult represents the result of a partition leader elections operation.
+type ElectionsResult struct {
+	PartitionElections meta.PartitionElections `json:"partitionElections"`
+	Err                string                  `json:"error"`
+	Applied            bool                    `json:"applied"`
+}
+
+// GetErr returns the error of a partition leader elections operation.
+func (e ElectionsResult) GetErr() error {
+	if len(e.Err) > 0 {
+		return fmt.Errorf("%s", e.Err)
+	}
+	return nil
+}
+
+// JSON converts a partition leader elections result to JSON.
+func (e ElectionsResult) JSON() (string, error) {
+	j, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	return string(j), nil
+}
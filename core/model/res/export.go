@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 
 	"github.com/bradfitz/slice" //nolint
+	"github.com/peter-evans/kdef/core/model/meta"
 )
 
 // ExportResult represents an export result.
@@ -12,6 +13,7 @@ type ExportResult struct {
 	ID   string      `json:"id"`
 	Type string      `json:"type,omitempty"`
 	Def  interface{} `json:"definition"`
+	Data interface{} `json:"data,omitempty"`
 }
 
 // ExportResults represents a slice of ExportResult.
@@ -53,3 +55,10 @@ func (e ExportResults) JSON() (string, error) {
 	}
 	return string(j), nil
 }
+
+// *** Topic export specific ***
+
+// TopicExportResultData represents misc data for a topic export result.
+type TopicExportResultData struct {
+	PartitionAssignments meta.TopicPartitionAssignments `json:"partitionAssignments"`
+}
@@ -0,0 +1,33 @@
+// Package res implements structures handling the result of operations.
+package res
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/peter-evans/kdef/core/model/meta"
+)
+
+// ReassignmentsResult represents the result of a partition reassignments operation.
+type ReassignmentsResult struct {
+	PartitionReassignments meta.PartitionReassignments `json:"partitionReassignments"`
+	Err                    string                      `json:"error"`
+	Applied                bool                        `json:"applied"`
+}
+
+// GetErr returns the error of a partition reassignments operation.
+func (r ReassignmentsResult) GetErr() error {
+	if len(r.Err) > 0 {
+		return fmt.Errorf("%s", r.Err)
+	}
+	return nil
+}
+
+// JSON converts a partition reassignments result to JSON.
+func (r ReassignmentsResult) JSON() (string, error) {
+	j, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	return string(j), nil
+}
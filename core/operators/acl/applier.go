@@ -4,6 +4,7 @@ package acl
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/peter-evans/kdef/cli/log"
 	"github.com/peter-evans/kdef/core/client"
@@ -20,6 +21,8 @@ type ApplierOptions struct {
 	DefinitionFormat  opt.DefinitionFormat
 	PropertyOverrides []string
 	DryRun            bool
+	Roles             def.ACLRoles
+	PrincipalTypes    []string
 }
 
 // NewApplier creates a new applier.
@@ -55,6 +58,7 @@ type applier struct {
 	localDef   def.ACLDefinition
 	remoteDef  def.ACLDefinition
 	remoteACLs def.ACLEntryGroups
+	roleACLs   def.ACLEntryGroups
 	ops        applierOps
 
 	// Result fields.
@@ -64,7 +68,7 @@ type applier struct {
 // Execute executes the applier.
 func (a *applier) Execute(ctx context.Context) *res.ApplyResult {
 	if err := a.apply(ctx); err != nil {
-		a.res.Err = err.Error()
+		a.res.SetErr(err)
 		log.Error(err)
 	} else if a.ops.pending() && !a.opts.DryRun {
 		a.res.Applied = true
@@ -84,6 +88,10 @@ func (a *applier) apply(ctx context.Context) error {
 		return err
 	}
 
+	if err := a.localDef.ValidateWithPrincipalTypes(a.principalTypes()); err != nil {
+		return err
+	}
+
 	if err := a.fetchRemote(ctx); err != nil {
 		return err
 	}
@@ -121,9 +129,13 @@ func (a *applier) createLocal() error {
 		return err
 	}
 
+	if err := a.expandRoles(); err != nil {
+		return err
+	}
+
 	// Explode the local acl entry groups to one entry per group.
 	var explodedACLs def.ACLEntryGroups
-	for _, group := range a.localDef.Spec.ACLs {
+	for _, group := range append(a.localDef.Spec.ACLs, a.roleACLs...) {
 		for _, principal := range group.Principals {
 			for _, host := range group.Hosts {
 				for _, operation := range group.Operations {
@@ -145,6 +157,33 @@ func (a *applier) createLocal() error {
 	return nil
 }
 
+// expandRoles expands the role groups of the local definition to the acl entry groups they grant.
+func (a *applier) expandRoles() error {
+	if len(a.localDef.Spec.Roles) == 0 {
+		return nil
+	}
+
+	if err := a.localDef.Spec.Roles.Validate(); err != nil {
+		return err
+	}
+
+	roles := a.opts.Roles
+	if roles == nil {
+		var err error
+		if roles, err = def.NewACLRoles(nil); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	a.roleACLs, err = a.localDef.Spec.Roles.Expand(roles, a.localDef.Metadata.Type)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // fetchRemote fetches the remote definition and necessary metadata.
 func (a *applier) fetchRemote(ctx context.Context) error {
 	log.Infof("Fetching remote ACLs...")
@@ -179,6 +218,7 @@ func (a *applier) updateApplyResult() error {
 	// Modify the remote definition to remove optional properties not specified in local.
 	// Further, set properties that are local only and have no remote state.
 	remoteCopy.Spec.DeleteUndefinedACLs = a.localDef.Spec.DeleteUndefinedACLs
+	remoteCopy.Spec.Roles = a.localDef.Spec.Roles
 
 	if !a.localDef.Spec.DeleteUndefinedACLs {
 		// Remove ACLs from the remote def that are not in local to prevent them showing in the diff.
@@ -206,6 +246,16 @@ func (a *applier) updateApplyResult() error {
 func (a *applier) displayPendingOps() {
 	log.Infof("acl definition %q diff (local -> remote):", a.localDef.Metadata.Name)
 	fmt.Println(a.res.Diff)
+
+	for i, roleGroup := range a.localDef.Spec.Roles {
+		log.Infof(
+			"Role %q grants %s on hosts %s to principals %s",
+			roleGroup.Role,
+			strings.Join(a.roleACLs[i].Operations, ", "),
+			strings.Join(a.roleACLs[i].Hosts, ", "),
+			strings.Join(a.roleACLs[i].Principals, ", "),
+		)
+	}
 }
 
 // executeOps executes update operations.
@@ -276,3 +326,11 @@ func (a *applier) deleteACLs(ctx context.Context) error {
 
 	return nil
 }
+
+// principalTypes returns the allowed principal types.
+func (a *applier) principalTypes() []string {
+	if len(a.opts.PrincipalTypes) == 0 {
+		return def.DefaultACLPrincipalTypes
+	}
+	return a.opts.PrincipalTypes
+}
@@ -0,0 +1,238 @@
+// Package acl implements operators for acl definition operations.
+package acl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/helpers/acls"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/peter-evans/kdef/core/model/res"
+)
+
+// PrunerOptions represents options to configure a pruner.
+type PrunerOptions struct {
+	Match        string
+	Exclude      string
+	ResourceType string
+	DryRun       bool
+
+	// Resources with acl definitions, whose ACLs are never pruned.
+	DefinedResources []def.ACLResource
+	// ACL bindings of principal acl definitions. ACLs of the principals of these bindings are
+	// never pruned from the bound resources.
+	DefinedPrincipalBindings meta.ACLBindings
+}
+
+// NewPruner creates a new pruner.
+func NewPruner(
+	cl *client.Client,
+	opts PrunerOptions,
+) *pruner { //revive:disable-line:unexported-return
+	return &pruner{
+		srv:  kafka.NewService(cl),
+		opts: opts,
+	}
+}
+
+type pruner struct {
+	srv  *kafka.Service
+	opts PrunerOptions
+
+	// Internal fields.
+	pruneACLs map[def.ACLResource]def.ACLEntryGroups
+
+	// Result fields.
+	res res.ApplyResult
+}
+
+// Execute executes the prune operation.
+func (p *pruner) Execute(ctx context.Context) *res.ApplyResult {
+	if err := p.prune(ctx); err != nil {
+		p.res.Err = err.Error()
+		log.Error(err)
+	} else if len(p.pruneACLs) > 0 && !p.opts.DryRun {
+		p.res.Applied = true
+	}
+
+	return &p.res
+}
+
+// prune performs the prune operation sequence.
+func (p *pruner) prune(ctx context.Context) error {
+	if err := p.buildOps(ctx); err != nil {
+		return err
+	}
+
+	bindings := p.bindings()
+	p.res.Data = res.ACLPruneApplyResultData{
+		PrunedACLs: bindings,
+	}
+
+	if len(bindings) == 0 {
+		log.Infof("No undefined ACLs to prune")
+		return nil
+	}
+
+	p.res.Diff = diff(bindings)
+
+	if !log.Quiet {
+		log.Infof("%d undefined ACL(s) will be pruned:", len(bindings))
+		display(bindings)
+	}
+
+	return p.deleteACLs(ctx)
+}
+
+// buildOps fetches the ACLs of the cluster and determines the undefined ACLs in scope to prune.
+func (p *pruner) buildOps(ctx context.Context) error {
+	log.Infof("Fetching remote ACLs to prune...")
+	resourceACLs, err := p.srv.DescribeAllResourceACLs(ctx, p.opts.ResourceType)
+	if err != nil {
+		return err
+	}
+
+	return p.buildPruneACLs(resourceACLs)
+}
+
+// buildPruneACLs determines the ACLs in scope that are not defined by acl or principal acl definitions.
+func (p *pruner) buildPruneACLs(resourceACLs []kafka.ResourceACLs) error {
+	matchRegExp, err := regexp.Compile(p.opts.Match)
+	if err != nil {
+		return err
+	}
+	excludeRegExp, err := regexp.Compile(p.opts.Exclude)
+	if err != nil {
+		return err
+	}
+
+	definedResources := make(map[def.ACLResource]bool, len(p.opts.DefinedResources))
+	for _, resource := range p.opts.DefinedResources {
+		definedResources[resource] = true
+	}
+
+	// Principal acl definitions define the ACLs of a principal on the resources of their grants only.
+	definedPrincipals := make(map[def.ACLResource]map[string]bool)
+	for _, b := range p.opts.DefinedPrincipalBindings {
+		resource := def.ACLResource{
+			Type:        b.ResourceType,
+			Name:        b.ResourceName,
+			PatternType: b.ResourcePatternType,
+		}
+		if _, ok := definedPrincipals[resource]; !ok {
+			definedPrincipals[resource] = make(map[string]bool)
+		}
+		definedPrincipals[resource][b.Principal] = true
+	}
+
+	p.pruneACLs = make(map[def.ACLResource]def.ACLEntryGroups)
+	for _, r := range resourceACLs {
+		resource := def.ACLResource{
+			Type:        r.ResourceType,
+			Name:        r.ResourceName,
+			PatternType: r.ResourcePatternType,
+		}
+		if definedResources[resource] {
+			continue
+		}
+		if !matchRegExp.MatchString(resource.Name) || excludeRegExp.MatchString(resource.Name) {
+			continue
+		}
+		for _, acl := range r.ACLs {
+			if definedPrincipals[resource][acl.Principals[0]] {
+				continue
+			}
+			p.pruneACLs[resource] = append(p.pruneACLs[resource], acl)
+		}
+	}
+
+	return nil
+}
+
+// bindings returns the ACLs to prune bound to their resources.
+func (p *pruner) bindings() meta.ACLBindings {
+	var bindings meta.ACLBindings
+	for resource, entries := range p.pruneACLs {
+		for _, acl := range entries {
+			bindings = append(bindings, meta.ACLBinding{
+				ResourceType:        resource.Type,
+				ResourceName:        resource.Name,
+				ResourcePatternType: resource.PatternType,
+				Principal:           acl.Principals[0],
+				Host:                acl.Hosts[0],
+				Operation:           acl.Operations[0],
+				PermissionType:      acl.PermissionType,
+			})
+		}
+	}
+	bindings.Sort()
+	return bindings
+}
+
+// deleteACLs deletes the ACLs to prune.
+func (p *pruner) deleteACLs(ctx context.Context) error {
+	log.InfoMaybeWithKeyf("dry-run", p.opts.DryRun, "Pruning ACLs...")
+
+	if !p.opts.DryRun {
+		for _, resource := range acls.SortedResources(p.pruneACLs) {
+			if err := p.srv.DeleteACLs(
+				ctx,
+				resource.Name,
+				resource.Type,
+				resource.PatternType,
+				p.pruneACLs[resource],
+			); err != nil {
+				return err
+			}
+		}
+	}
+	log.InfoMaybeWithKeyf("dry-run", p.opts.DryRun, "Pruned ACLs of %d resource(s)", len(p.pruneACLs))
+
+	return nil
+}
+
+// diff returns a human readable diff of the ACLs to prune.
+func diff(bindings meta.ACLBindings) string {
+	lines := make([]string, len(bindings))
+	for i, b := range bindings {
+		lines[i] = fmt.Sprintf(
+			"-%s:%s:%s %s %s %s %s",
+			b.ResourceType,
+			b.ResourcePatternType,
+			b.ResourceName,
+			b.Principal,
+			b.Host,
+			b.Operation,
+			b.PermissionType,
+		)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// display displays the ACLs to prune in a table.
+func display(bindings meta.ACLBindings) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Resource Type", "Resource Name", "Pattern Type", "Principal", "Host", "Operation", "Permission"})
+	for _, b := range bindings {
+		t.AppendRow(table.Row{
+			b.ResourceType,
+			b.ResourceName,
+			b.ResourcePatternType,
+			b.Principal,
+			b.Host,
+			b.Operation,
+			b.PermissionType,
+		})
+	}
+	t.SetStyle(table.StyleLight)
+	t.Render()
+}
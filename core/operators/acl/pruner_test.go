@@ -0,0 +1,108 @@
+// Package acl implements operators for acl definition operations.
+package acl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/meta"
+)
+
+func Test_pruner_buildPruneACLs(t *testing.T) {
+	fooACL := def.ACLEntryGroup{
+		Principals:     []string{"User:foo"},
+		Hosts:          []string{"*"},
+		Operations:     []string{"READ"},
+		PermissionType: "ALLOW",
+	}
+	barACL := def.ACLEntryGroup{
+		Principals:     []string{"User:bar"},
+		Hosts:          []string{"*"},
+		Operations:     []string{"READ"},
+		PermissionType: "ALLOW",
+	}
+	resourceACLs := []kafka.ResourceACLs{
+		{
+			ResourceName:        "store.events",
+			ResourceType:        "topic",
+			ResourcePatternType: "LITERAL",
+			ACLs:                def.ACLEntryGroups{fooACL, barACL},
+		},
+		{
+			ResourceName:        "store.orders",
+			ResourceType:        "topic",
+			ResourcePatternType: "LITERAL",
+			ACLs:                def.ACLEntryGroups{fooACL, barACL},
+		},
+	}
+	eventsTopic := def.ACLResource{Type: "topic", Name: "store.events", PatternType: "LITERAL"}
+	ordersTopic := def.ACLResource{Type: "topic", Name: "store.orders", PatternType: "LITERAL"}
+
+	tests := []struct {
+		name string
+		opts PrunerOptions
+		want map[def.ACLResource]def.ACLEntryGroups
+	}{
+		{
+			name: "Tests pruning all undefined ACLs",
+			opts: PrunerOptions{Match: ".*", Exclude: ".^"},
+			want: map[def.ACLResource]def.ACLEntryGroups{
+				eventsTopic: {fooACL, barACL},
+				ordersTopic: {fooACL, barACL},
+			},
+		},
+		{
+			name: "Tests ACLs of a resource with an acl definition are not pruned",
+			opts: PrunerOptions{
+				Match:            ".*",
+				Exclude:          ".^",
+				DefinedResources: []def.ACLResource{eventsTopic},
+			},
+			want: map[def.ACLResource]def.ACLEntryGroups{
+				ordersTopic: {fooACL, barACL},
+			},
+		},
+		{
+			name: "Tests ACLs of a principal are not pruned from resources of its principal acl definition only",
+			opts: PrunerOptions{
+				Match:   ".*",
+				Exclude: ".^",
+				DefinedPrincipalBindings: meta.ACLBindings{
+					{
+						ResourceType:        "topic",
+						ResourceName:        "store.events",
+						ResourcePatternType: "LITERAL",
+						Principal:           "User:foo",
+						Host:                "*",
+						Operation:           "WRITE",
+						PermissionType:      "ALLOW",
+					},
+				},
+			},
+			want: map[def.ACLResource]def.ACLEntryGroups{
+				eventsTopic: {barACL},
+				ordersTopic: {fooACL, barACL},
+			},
+		},
+		{
+			name: "Tests ACLs of resources out of scope are not pruned",
+			opts: PrunerOptions{Match: "store\\..*", Exclude: ".*orders"},
+			want: map[def.ACLResource]def.ACLEntryGroups{
+				eventsTopic: {fooACL, barACL},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &pruner{opts: tt.opts}
+			if err := p.buildPruneACLs(resourceACLs); err != nil {
+				t.Fatalf("pruner.buildPruneACLs() error = %v", err)
+			}
+			if !reflect.DeepEqual(p.pruneACLs, tt.want) {
+				t.Errorf("pruner.buildPruneACLs() = %v, want %v", p.pruneACLs, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Package aclcheck implements operators for ACL check operations.
+package aclcheck
+
+import (
+	"context"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/helpers/authorizer"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/peter-evans/kdef/core/model/res"
+)
+
+// CheckerOptions represents options to configure a checker.
+type CheckerOptions struct {
+	Request       meta.ACLCheckRequest
+	AllowIfNoACLs bool
+
+	// Bindings are the ACLs to check the request against.
+	// If nil, the ACLs of the cluster are fetched.
+	Bindings meta.ACLBindings
+}
+
+// NewChecker creates a new checker.
+func NewChecker(
+	cl *client.Client,
+	opts CheckerOptions,
+) *checker { //revive:disable-line:unexported-return
+	return &checker{
+		srv:  kafka.NewService(cl),
+		opts: opts,
+	}
+}
+
+type checker struct {
+	srv  *kafka.Service
+	opts CheckerOptions
+}
+
+// Execute executes the check operation.
+func (c *checker) Execute(ctx context.Context) *res.ACLCheckResult {
+	result := res.ACLCheckResult{
+		Request: c.opts.Request,
+	}
+
+	if err := def.ValidateACLCheckRequest(c.opts.Request); err != nil {
+		result.Err = err.Error()
+		log.Error(err)
+		return &result
+	}
+
+	bindings := c.opts.Bindings
+	if bindings == nil {
+		var err error
+		if bindings, err = c.fetchBindings(ctx); err != nil {
+			result.Err = err.Error()
+			log.Error(err)
+			return &result
+		}
+	}
+
+	result.Decision = authorizer.Authorize(bindings, c.opts.Request, c.opts.AllowIfNoACLs)
+
+	return &result
+}
+
+// fetchBindings fetches the ACLs of the cluster for the resource type of the request.
+func (c *checker) fetchBindings(ctx context.Context) (meta.ACLBindings, error) {
+	log.Infof("Fetching %s ACLs...", c.opts.Request.ResourceType)
+	resourceACLs, err := c.srv.DescribeAllResourceACLs(ctx, c.opts.Request.ResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	bindings := meta.ACLBindings{}
+	for _, resource := range resourceACLs {
+		for _, acl := range resource.ACLs {
+			bindings = append(bindings, meta.ACLBinding{
+				ResourceType:        resource.ResourceType,
+				ResourceName:        resource.ResourceName,
+				ResourcePatternType: resource.ResourcePatternType,
+				Principal:           acl.Principals[0],
+				Host:                acl.Hosts[0],
+				Operation:           acl.Operations[0],
+				PermissionType:      acl.PermissionType,
+			})
+		}
+	}
+
+	return bindings, nil
+}
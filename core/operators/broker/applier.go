@@ -62,7 +62,7 @@ type applier struct {
 // Execute executes the applier.
 func (a *applier) Execute(ctx context.Context) *res.ApplyResult {
 	if err := a.apply(ctx); err != nil {
-		a.res.Err = err.Error()
+		a.res.SetErr(err)
 		log.Error(err)
 	} else if a.ops.pending() && !a.opts.DryRun {
 		a.res.Applied = true
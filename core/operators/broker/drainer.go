@@ -0,0 +1,189 @@
+// Package broker implements operators for broker definition operations.
+package broker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/helpers/assignments"
+	"github.com/peter-evans/kdef/core/helpers/reassignments"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/meta"
+	opreassignments "github.com/peter-evans/kdef/core/operators/reassignments"
+	"github.com/peter-evans/kdef/core/util/i32"
+)
+
+// DrainerOptions represents options to configure a drainer.
+type DrainerOptions struct {
+	ReassMaxMoves     int
+	ReassAwaitTimeout int
+	ThrottleRate      int64
+	DryRun            bool
+}
+
+// NewDrainer creates a new drainer.
+func NewDrainer(
+	cl *client.Client,
+	brokerID int32,
+	opts DrainerOptions,
+) *drainer { //revive:disable-line:unexported-return
+	return &drainer{
+		srv:      kafka.NewService(cl),
+		brokerID: brokerID,
+		opts:     opts,
+	}
+}
+
+type drainer struct {
+	// Constructor fields.
+	srv      *kafka.Service
+	brokerID int32
+	opts     DrainerOptions
+
+	// Internal fields.
+	current meta.TopicPartitionAssignments
+	moves   meta.TopicPartitionAssignments
+}
+
+// Execute executes the drainer.
+func (d *drainer) Execute(ctx context.Context) error {
+	if err := d.buildMoves(ctx); err != nil {
+		return err
+	}
+
+	if len(d.moves) == 0 {
+		log.Infof("Broker %d holds no partition replicas", d.brokerID)
+	} else {
+		if !log.Quiet {
+			log.InfoMaybeWithKeyf("dry-run", d.opts.DryRun, "Partition moves to drain broker %d:", d.brokerID)
+			reassignments.DisplayMoves(d.current, d.moves)
+		}
+
+		executor := opreassignments.NewExecutor(d.srv, opreassignments.ExecutorOptions{
+			MaxMoves:     d.opts.ReassMaxMoves,
+			AwaitTimeout: d.opts.ReassAwaitTimeout,
+			ThrottleRate: d.opts.ThrottleRate,
+			DryRun:       d.opts.DryRun,
+		})
+		if err := executor.Execute(ctx, d.current, d.moves); err != nil {
+			return err
+		}
+	}
+
+	if d.opts.DryRun {
+		return nil
+	}
+
+	return d.verify(ctx)
+}
+
+// buildMoves builds the partition moves required to remove all replicas from the broker.
+func (d *drainer) buildMoves(ctx context.Context) error {
+	log.Infof("Fetching cluster metadata...")
+	metadata, err := d.srv.DescribeMetadata(ctx, nil, false)
+	if err != nil {
+		return err
+	}
+
+	brokerFound := i32.Contains(d.brokerID, metadata.Brokers.IDs())
+	maxRepFactor := 0
+	clusterReplicaCounts := make(map[int32]int)
+	for _, topic := range metadata.Topics {
+		for partition, replicas := range topic.PartitionAssignments {
+			d.current = append(d.current, meta.TopicPartitionAssignment{
+				Topic:     topic.Topic,
+				Partition: int32(partition),
+				Replicas:  replicas,
+			})
+			for _, brokerID := range replicas {
+				clusterReplicaCounts[brokerID]++
+				if brokerID == d.brokerID {
+					brokerFound = true
+				}
+			}
+			if len(replicas) > maxRepFactor {
+				maxRepFactor = len(replicas)
+			}
+		}
+	}
+
+	if !brokerFound {
+		return fmt.Errorf("broker %d does not exist in the cluster", d.brokerID)
+	}
+	if clusterReplicaCounts[d.brokerID] == 0 {
+		return nil
+	}
+
+	brokers := i32.Diff(metadata.Brokers.IDs(), []int32{d.brokerID})
+	if maxRepFactor > len(brokers) {
+		return fmt.Errorf(
+			"replication factor %d of existing partitions cannot be satisfied by the %d remaining broker(s)",
+			maxRepFactor,
+			len(brokers),
+		)
+	}
+
+	racksByBroker := metadata.Brokers.RacksByBroker()
+	for _, topic := range metadata.Topics {
+		newAssignments := assignments.RemoveBroker(
+			topic.PartitionAssignments,
+			d.brokerID,
+			&assignments.ClusterUse{ReplicaCounts: clusterReplicaCounts},
+			brokers,
+			racksByBroker,
+		)
+		for partition, replicas := range newAssignments {
+			if i32.Contains(d.brokerID, replicas) {
+				return fmt.Errorf(
+					"no replacement broker available for partition %d of topic %q",
+					partition,
+					topic.Topic,
+				)
+			}
+			if i32.Contains(d.brokerID, topic.PartitionAssignments[partition]) {
+				d.moves = append(d.moves, meta.TopicPartitionAssignment{
+					Topic:     topic.Topic,
+					Partition: int32(partition),
+					Replicas:  replicas,
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// verify verifies the broker holds no partition replicas and no partition leadership.
+func (d *drainer) verify(ctx context.Context) error {
+	log.Infof("Verifying broker %d is drained...", d.brokerID)
+	metadata, err := d.srv.DescribeMetadata(ctx, nil, false)
+	if err != nil {
+		return err
+	}
+
+	var replicas, leaders int
+	for _, topic := range metadata.Topics {
+		for partition, r := range topic.PartitionAssignments {
+			if i32.Contains(d.brokerID, r) {
+				replicas++
+			}
+			if topic.PartitionLeaders[partition] == d.brokerID {
+				leaders++
+			}
+		}
+	}
+	if replicas > 0 || leaders > 0 {
+		return fmt.Errorf(
+			"broker %d still holds %d partition replica(s) and leadership of %d partition(s)",
+			d.brokerID,
+			replicas,
+			leaders,
+		)
+	}
+
+	log.Infof("Broker %d holds no partition replicas or partition leadership", d.brokerID)
+
+	return nil
+}
@@ -0,0 +1,304 @@
+// Package brokerlogger implements operators for broker logger definition operations.
+package brokerlogger
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/helpers/jsondiff"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/opt"
+	"github.com/peter-evans/kdef/core/model/res"
+	"github.com/peter-evans/kdef/core/util/str"
+)
+
+// ApplierOptions represents options to configure an applier.
+type ApplierOptions struct {
+	DefinitionFormat  opt.DefinitionFormat
+	PropertyOverrides []string
+	DryRun            bool
+}
+
+// NewApplier creates a new applier.
+func NewApplier(
+	cl *client.Client,
+	defDoc string,
+	opts ApplierOptions,
+) *applier { //revive:disable-line:unexported-return
+	return &applier{
+		srv:    kafka.NewService(cl),
+		defDoc: defDoc,
+		opts:   opts,
+	}
+}
+
+type applierOps struct {
+	loggers map[string]kafka.ConfigOperations
+}
+
+func (a applierOps) pending() bool {
+	return len(a.loggers) > 0
+}
+
+type applier struct {
+	// Constructor fields.
+	srv    *kafka.Service
+	defDoc string
+	opts   ApplierOptions
+
+	// Internal fields.
+	localDef      def.BrokerLoggerDefinition
+	targetDef     def.BrokerLoggerDefinition
+	remoteDef     def.BrokerLoggerDefinition
+	brokerIDs     []string
+	remoteLoggers map[string]def.ConfigsMap
+	expired       bool
+	ops           applierOps
+
+	// Result fields.
+	res res.ApplyResult
+}
+
+// Execute executes the applier.
+func (a *applier) Execute(ctx context.Context) *res.ApplyResult {
+	if err := a.apply(ctx); err != nil {
+		a.res.SetErr(err)
+		log.Error(err)
+	} else if a.ops.pending() && !a.opts.DryRun {
+		a.res.Applied = true
+	}
+
+	return &a.res
+}
+
+// apply performs the apply operation sequence.
+func (a *applier) apply(ctx context.Context) error {
+	if err := a.createLocal(); err != nil {
+		return err
+	}
+
+	log.Debugf("Validating broker logger definition")
+	if err := a.localDef.Validate(); err != nil {
+		return err
+	}
+
+	if err := a.fetchRemote(ctx); err != nil {
+		return err
+	}
+
+	a.buildOps()
+
+	if err := a.updateApplyResult(); err != nil {
+		return err
+	}
+
+	if a.ops.pending() {
+		if !log.Quiet {
+			a.displayPendingOps()
+		}
+
+		if err := a.executeOps(ctx); err != nil {
+			return err
+		}
+
+		log.InfoMaybeWithKeyf("dry-run", a.opts.DryRun, "Completed apply for broker logger definition %q", a.localDef.Metadata.Name)
+	} else {
+		log.Infof("No changes to apply for broker logger definition %q", a.localDef.Metadata.Name)
+	}
+
+	return nil
+}
+
+// createLocal creates the local definition.
+func (a *applier) createLocal() error {
+	var err error
+	a.localDef, err = def.LoadBrokerLoggerDefinition(a.defDoc, a.opts.DefinitionFormat)
+	if err != nil {
+		return err
+	}
+
+	a.res.LocalDef = &a.localDef
+
+	// The target state of an expired definition is the reverted logger levels.
+	a.targetDef = a.localDef.Copy()
+	a.expired = a.localDef.Expired(time.Now())
+	if a.expired {
+		log.Infof(
+			"Broker logger definition %q expired at %s and its logger levels will be reverted",
+			a.localDef.Metadata.Name,
+			a.localDef.Spec.Expiry,
+		)
+		a.targetDef.Spec.Loggers = nil
+	}
+
+	return nil
+}
+
+// fetchRemote fetches the remote definition and necessary metadata.
+func (a *applier) fetchRemote(ctx context.Context) error {
+	log.Infof("Fetching cluster metadata...")
+	metadata, err := a.srv.DescribeMetadata(ctx, []string{}, false)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Validating broker logger definition using cluster metadata")
+	if err := a.localDef.ValidateWithMetadata(metadata.Brokers); err != nil {
+		return err
+	}
+
+	if a.localDef.AllBrokers() {
+		ids := metadata.Brokers.IDs()
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		for _, id := range ids {
+			a.brokerIDs = append(a.brokerIDs, strconv.Itoa(int(id)))
+		}
+	} else {
+		a.brokerIDs = []string{a.localDef.Metadata.Name}
+	}
+
+	log.Infof("Fetching remote broker logger levels...")
+	resourceConfigs, err := a.srv.DescribeBrokerLoggerConfigs(ctx, a.brokerIDs)
+	if err != nil {
+		return err
+	}
+
+	a.remoteLoggers = make(map[string]def.ConfigsMap)
+	for _, rc := range resourceConfigs {
+		a.remoteLoggers[rc.ResourceName] = rc.Configs.ToMap()
+	}
+
+	return nil
+}
+
+// buildOps builds broker logger operations and the remote definition.
+func (a *applier) buildOps() {
+	loggers := make([]string, 0, len(a.localDef.Spec.Loggers))
+	for logger := range a.localDef.Spec.Loggers {
+		loggers = append(loggers, logger)
+	}
+	sort.Strings(loggers)
+
+	// The remote definition shows the level of the first broker requiring an operation for each logger.
+	// Loggers that do not require operations on any broker show the target state.
+	remoteLoggers := make(map[string]string)
+	a.ops.loggers = make(map[string]kafka.ConfigOperations)
+	for _, logger := range loggers {
+		level := a.localDef.Spec.Loggers[logger]
+		if !a.expired {
+			remoteLoggers[logger] = level
+		}
+		diffFound := false
+
+		for _, brokerID := range a.brokerIDs {
+			brokerLoggers := a.remoteLoggers[brokerID]
+			current, exists := brokerLoggers[logger]
+
+			if a.expired {
+				// Reverting a logger resets its level to that of the root logger.
+				if !exists || str.Deref(current) == str.Deref(brokerLoggers[def.RootLogger]) {
+					continue
+				}
+				log.Debugf("Level of logger %q on broker %s has expired and will be reverted", logger, brokerID)
+				a.ops.loggers[brokerID] = append(a.ops.loggers[brokerID], kafka.ConfigOperation{
+					Name: logger,
+					Op:   kafka.DeleteConfigOperation,
+				})
+			} else {
+				if exists && str.Deref(current) == level {
+					continue
+				}
+				log.Debugf("Level of logger %q on broker %s will be set to %q", logger, brokerID, level)
+				value := level
+				a.ops.loggers[brokerID] = append(a.ops.loggers[brokerID], kafka.ConfigOperation{
+					Name:  logger,
+					Value: &value,
+					Op:    kafka.SetConfigOperation,
+				})
+			}
+
+			if !diffFound {
+				diffFound = true
+				if exists && current != nil {
+					remoteLoggers[logger] = *current
+				} else {
+					delete(remoteLoggers, logger)
+				}
+			}
+		}
+	}
+
+	a.remoteDef = def.NewBrokerLoggerDefinition(a.localDef.Metadata, remoteLoggers)
+}
+
+// updateApplyResult updates the apply result with the remote definition and human readable diff.
+func (a *applier) updateApplyResult() error {
+	remoteCopy := a.remoteDef.Copy()
+
+	// Set properties that are local only and have no remote state.
+	remoteCopy.Spec.Expiry = a.localDef.Spec.Expiry
+
+	diff, err := jsondiff.Diff(&remoteCopy, &a.targetDef)
+	if err != nil {
+		return fmt.Errorf("failed to compute diff: %v", err)
+	}
+
+	if diffExists := (len(diff) > 0); diffExists != a.ops.pending() {
+		return fmt.Errorf("existence of diff was %v, but expected %v", diffExists, a.ops.pending())
+	}
+
+	a.res.RemoteDef = remoteCopy
+	a.res.Diff = diff
+
+	return nil
+}
+
+// displayPendingOps displays pending operations.
+func (a *applier) displayPendingOps() {
+	log.Infof("broker logger definition %q diff (local -> remote):", a.localDef.Metadata.Name)
+	fmt.Println(a.res.Diff)
+
+	if a.localDef.AllBrokers() {
+		var brokerIDs []string
+		for _, brokerID := range a.brokerIDs {
+			if _, ok := a.ops.loggers[brokerID]; ok {
+				brokerIDs = append(brokerIDs, brokerID)
+			}
+		}
+		log.Infof("Logger levels will be altered on broker(s) %s", strings.Join(brokerIDs, ", "))
+	}
+}
+
+// executeOps executes update operations.
+func (a *applier) executeOps(ctx context.Context) error {
+	if len(a.ops.loggers) > 0 {
+		if err := a.updateLoggers(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateLoggers updates broker logger levels.
+func (a *applier) updateLoggers(ctx context.Context) error {
+	log.InfoMaybeWithKeyf("dry-run", a.opts.DryRun, "Altering logger levels...")
+	if err := a.srv.AlterBrokerLoggerConfigs(ctx, a.ops.loggers, a.opts.DryRun); err != nil {
+		return err
+	}
+	log.InfoMaybeWithKeyf(
+		"dry-run",
+		a.opts.DryRun,
+		"Altered logger levels for broker logger definition %q",
+		a.localDef.Metadata.Name,
+	)
+
+	return nil
+}
@@ -0,0 +1,76 @@
+// Package clientmetrics implements operators for client metrics definition operations.
+package clientmetrics
+
+import (
+	"context"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/res"
+)
+
+// NewExporter creates a new exporter.
+func NewExporter(
+	cl *client.Client,
+) *exporter { //revive:disable-line:unexported-return
+	return &exporter{
+		srv: kafka.NewService(cl),
+	}
+}
+
+type exporter struct {
+	// constructor params
+	srv *kafka.Service
+}
+
+// Execute executes the export operation.
+func (e *exporter) Execute(ctx context.Context) (res.ExportResults, error) {
+	log.Infof("Fetching remote client metrics subscription configuration...")
+	clientMetricsDefs, err := e.getClientMetricsDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(clientMetricsDefs) == 0 {
+		return nil, nil
+	}
+
+	results := make(res.ExportResults, len(clientMetricsDefs))
+	for i, clientMetricsDef := range clientMetricsDefs {
+		results[i] = res.ExportResult{
+			ID:  clientMetricsDef.Metadata.Name,
+			Def: clientMetricsDef,
+		}
+	}
+
+	results.Sort()
+
+	return results, nil
+}
+
+func (e *exporter) getClientMetricsDefinitions(ctx context.Context) ([]def.ClientMetricsDefinition, error) {
+	names, err := e.srv.ListClientMetricsResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clientMetricsDefs := []def.ClientMetricsDefinition{}
+	for _, name := range names {
+		configs, err := e.srv.DescribeClientMetricsConfigs(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		clientMetricsDefs = append(
+			clientMetricsDefs, def.NewClientMetricsDefinition(
+				def.ResourceMetadataDefinition{
+					Name: name,
+				},
+				configs.ToExportableMap(),
+			),
+		)
+	}
+
+	return clientMetricsDefs, nil
+}
@@ -0,0 +1,215 @@
+// Package cluster implements operators for cluster-wide operations.
+package cluster
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/helpers/assignments"
+	"github.com/peter-evans/kdef/core/helpers/reassignments"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/meta"
+	opreassignments "github.com/peter-evans/kdef/core/operators/reassignments"
+	"github.com/peter-evans/kdef/core/util/i32"
+)
+
+// LeaderBalancerOptions represents options to configure a leader balancer.
+type LeaderBalancerOptions struct {
+	Match           string
+	Exclude         string
+	IncludeInternal bool
+	DryRun          bool
+}
+
+// NewLeaderBalancer creates a new leader balancer.
+func NewLeaderBalancer(
+	cl *client.Client,
+	opts LeaderBalancerOptions,
+) *leaderBalancer { //revive:disable-line:unexported-return
+	return &leaderBalancer{
+		srv:  kafka.NewService(cl),
+		opts: opts,
+	}
+}
+
+type leaderBalancer struct {
+	// Constructor fields.
+	srv  *kafka.Service
+	opts LeaderBalancerOptions
+
+	// Internal fields.
+	brokers      meta.Brokers
+	current      meta.TopicPartitionAssignments
+	moves        meta.TopicPartitionAssignments
+	elections    map[string][]int32
+	leaderCounts struct {
+		before map[int32]int
+		after  map[int32]int
+	}
+}
+
+// Execute executes the leader balancer.
+func (l *leaderBalancer) Execute(ctx context.Context) error {
+	if err := l.buildMoves(ctx); err != nil {
+		return err
+	}
+
+	if !log.Quiet {
+		log.InfoMaybeWithKeyf("dry-run", l.opts.DryRun, "Preferred partition leaders per broker:")
+		reassignments.DisplayLeaderCounts(l.brokers, l.leaderCounts.before, l.leaderCounts.after)
+	}
+
+	if len(l.moves) == 0 && len(l.elections) == 0 {
+		log.Infof("Partition leaders are balanced")
+		return nil
+	}
+
+	if len(l.moves) > 0 {
+		if !log.Quiet {
+			log.InfoMaybeWithKeyf("dry-run", l.opts.DryRun, "Replica reorders to balance preferred leaders:")
+			reassignments.DisplayMoves(l.current, l.moves)
+		}
+
+		// Reordering replicas moves no data, so reassignments complete immediately without throttling.
+		// Preferred leaders of reordered partitions are elected by the executor.
+		executor := opreassignments.NewExecutor(l.srv, opreassignments.ExecutorOptions{
+			AwaitTimeout: opreassignments.DefaultAwaitTimeout,
+			DryRun:       l.opts.DryRun,
+		})
+		if err := executor.Execute(ctx, l.current, l.moves); err != nil {
+			return err
+		}
+	}
+
+	return l.electPreferredLeaders(ctx)
+}
+
+// buildMoves builds the replica reorders required to balance preferred leaders of matching topics.
+func (l *leaderBalancer) buildMoves(ctx context.Context) error {
+	matchRegExp, err := regexp.Compile(l.opts.Match)
+	if err != nil {
+		return err
+	}
+	excludeRegExp, err := regexp.Compile(l.opts.Exclude)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Fetching cluster metadata...")
+	metadata, err := l.srv.DescribeMetadata(ctx, nil, false)
+	if err != nil {
+		return err
+	}
+	l.brokers = metadata.Brokers
+
+	// Preferred leaders are counted before and after balancing, which elects the preferred leader of every partition.
+	// Preferred leaders of topics that are not balanced remain fixed.
+	fixedLeaderCounts := make(map[int32]int)
+	l.leaderCounts.before = make(map[int32]int)
+
+	var partitionAssignments [][]int32
+	var eligibleLeaders [][]int32
+	var currentLeaders []int32
+	for _, topic := range metadata.Topics {
+		matched := matchRegExp.MatchString(topic.Topic) && !excludeRegExp.MatchString(topic.Topic)
+		// Kafka internal topics are prefixed by double underscores.
+		// Confluent internal topics are prefixed by single underscores.
+		if strings.HasPrefix(topic.Topic, "_") && !l.opts.IncludeInternal {
+			matched = false
+		}
+
+		for partition, replicas := range topic.PartitionAssignments {
+			if len(replicas) == 0 {
+				continue
+			}
+			l.leaderCounts.before[replicas[0]]++
+			if !matched {
+				fixedLeaderCounts[replicas[0]]++
+				continue
+			}
+
+			l.current = append(l.current, meta.TopicPartitionAssignment{
+				Topic:     topic.Topic,
+				Partition: int32(partition),
+				Replicas:  replicas,
+			})
+			partitionAssignments = append(partitionAssignments, replicas)
+			// Only in-sync replicas can be elected as the preferred leader.
+			eligibleLeaders = append(eligibleLeaders, topic.PartitionISR[partition])
+			currentLeaders = append(currentLeaders, topic.PartitionLeaders[partition])
+		}
+	}
+
+	newAssignments := assignments.BalanceLeaders(
+		partitionAssignments,
+		eligibleLeaders,
+		fixedLeaderCounts,
+		metadata.Brokers.RacksByBroker(),
+	)
+
+	l.elections = make(map[string][]int32)
+	l.leaderCounts.after = fixedLeaderCounts
+	for i, c := range l.current {
+		replicas := newAssignments[i]
+		l.leaderCounts.after[replicas[0]]++
+
+		if !cmp.Equal(c.Replicas, replicas) {
+			l.moves = append(l.moves, meta.TopicPartitionAssignment{
+				Topic:     c.Topic,
+				Partition: c.Partition,
+				Replicas:  replicas,
+			})
+		} else if currentLeaders[i] != replicas[0] && i32.Contains(replicas[0], eligibleLeaders[i]) {
+			// The preferred leader is unchanged but is not the current leader.
+			l.elections[c.Topic] = append(l.elections[c.Topic], c.Partition)
+		}
+	}
+	for _, partitions := range l.elections {
+		sort.Slice(partitions, func(i, j int) bool {
+			return partitions[i] < partitions[j]
+		})
+	}
+
+	return nil
+}
+
+// electPreferredLeaders elects the preferred leaders of partitions that were not reordered.
+func (l *leaderBalancer) electPreferredLeaders(ctx context.Context) error {
+	if len(l.elections) == 0 {
+		return nil
+	}
+
+	topics := make([]string, 0, len(l.elections))
+	for topic := range l.elections {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	for _, topic := range topics {
+		log.InfoMaybeWithKeyf(
+			"dry-run",
+			l.opts.DryRun,
+			"Electing preferred leaders of topic %q partitions %v",
+			topic,
+			l.elections[topic],
+		)
+		if !l.opts.DryRun {
+			if err := l.srv.ElectLeaders(ctx, topic, l.elections[topic]); err != nil {
+				return err
+			}
+		}
+	}
+	log.InfoMaybeWithKeyf(
+		"dry-run",
+		l.opts.DryRun,
+		"Elected preferred partition leaders for %d topic(s)",
+		len(l.elections),
+	)
+
+	return nil
+}
@@ -0,0 +1,196 @@
+// Package cluster implements operators for cluster-wide operations.
+package cluster
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/helpers/assignments"
+	"github.com/peter-evans/kdef/core/helpers/reassignments"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/meta"
+	opreassignments "github.com/peter-evans/kdef/core/operators/reassignments"
+)
+
+// RebalancerOptions represents options to configure a rebalancer.
+type RebalancerOptions struct {
+	Match             string
+	Exclude           string
+	IncludeInternal   bool
+	ReassMaxMoves     int
+	ReassAwaitTimeout int
+	ThrottleRate      int64
+	DryRun            bool
+}
+
+// NewRebalancer creates a new rebalancer.
+func NewRebalancer(
+	cl *client.Client,
+	opts RebalancerOptions,
+) *rebalancer { //revive:disable-line:unexported-return
+	return &rebalancer{
+		srv:  kafka.NewService(cl),
+		opts: opts,
+	}
+}
+
+type rebalancer struct {
+	// Constructor fields.
+	srv  *kafka.Service
+	opts RebalancerOptions
+
+	// Internal fields.
+	current meta.TopicPartitionAssignments
+	moves   meta.TopicPartitionAssignments
+}
+
+// Execute executes the rebalancer.
+func (r *rebalancer) Execute(ctx context.Context) error {
+	if err := r.buildMoves(ctx); err != nil {
+		return err
+	}
+
+	if len(r.moves) == 0 {
+		log.Infof("Partition assignments are balanced")
+		return nil
+	}
+
+	if err := r.displayPlan(ctx); err != nil {
+		return err
+	}
+
+	executor := opreassignments.NewExecutor(r.srv, opreassignments.ExecutorOptions{
+		MaxMoves:     r.opts.ReassMaxMoves,
+		AwaitTimeout: r.opts.ReassAwaitTimeout,
+		ThrottleRate: r.opts.ThrottleRate,
+		DryRun:       r.opts.DryRun,
+	})
+	return executor.Execute(ctx, r.current, r.moves)
+}
+
+// buildMoves builds the partition moves required to balance the assignments of matching topics.
+func (r *rebalancer) buildMoves(ctx context.Context) error {
+	matchRegExp, err := regexp.Compile(r.opts.Match)
+	if err != nil {
+		return err
+	}
+	excludeRegExp, err := regexp.Compile(r.opts.Exclude)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Fetching cluster metadata...")
+	metadata, err := r.srv.DescribeMetadata(ctx, nil, false)
+	if err != nil {
+		return err
+	}
+
+	// Replica counts of all topics are used to balance replicas across the cluster.
+	clusterReplicaCounts := make(map[int32]int)
+	for _, topic := range metadata.Topics {
+		for _, replicas := range topic.PartitionAssignments {
+			for _, brokerID := range replicas {
+				clusterReplicaCounts[brokerID]++
+			}
+		}
+	}
+	clusterUse := &assignments.ClusterUse{ReplicaCounts: clusterReplicaCounts}
+
+	// Rack constraints are maintained only if all brokers have a rack.
+	rackAware := len(metadata.Brokers.Racks()) > 0
+	for _, broker := range metadata.Brokers {
+		if len(broker.Rack) == 0 {
+			rackAware = false
+		}
+	}
+	brokersByRack := metadata.Brokers.BrokersByRack()
+
+	for _, topic := range metadata.Topics {
+		if !matchRegExp.MatchString(topic.Topic) || excludeRegExp.MatchString(topic.Topic) {
+			continue
+		}
+		// Kafka internal topics are prefixed by double underscores.
+		// Confluent internal topics are prefixed by single underscores.
+		if strings.HasPrefix(topic.Topic, "_") && !r.opts.IncludeInternal {
+			continue
+		}
+		if len(topic.PartitionAssignments) == 0 {
+			continue
+		}
+
+		var newAssignments [][]int32
+		if rackAware && hasRacks(topic.PartitionRacks) {
+			// The current racks of the replicas are used as rack constraints.
+			newAssignments = assignments.RebalanceWithRackConstraints(
+				topic.PartitionAssignments,
+				topic.PartitionRacks,
+				clusterUse,
+				brokersByRack,
+			)
+		} else {
+			newAssignments = assignments.Rebalance(
+				topic.PartitionAssignments,
+				clusterUse,
+				metadata.Brokers.IDs(),
+			)
+		}
+
+		for partition, replicas := range topic.PartitionAssignments {
+			r.current = append(r.current, meta.TopicPartitionAssignment{
+				Topic:     topic.Topic,
+				Partition: int32(partition),
+				Replicas:  replicas,
+			})
+			if !cmp.Equal(replicas, newAssignments[partition]) {
+				r.moves = append(r.moves, meta.TopicPartitionAssignment{
+					Topic:     topic.Topic,
+					Partition: int32(partition),
+					Replicas:  newAssignments[partition],
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// displayPlan displays the partition moves and the estimated data movement.
+func (r *rebalancer) displayPlan(ctx context.Context) error {
+	log.Infof("Fetching partition sizes...")
+	_, replicaLogDirs, err := r.srv.DescribeLogDirs(ctx)
+	if err != nil {
+		return err
+	}
+	replicas, bytes := reassignments.DataMovement(r.current, r.moves, replicaLogDirs.PartitionSizes())
+
+	if !log.Quiet {
+		log.InfoMaybeWithKeyf("dry-run", r.opts.DryRun, "Partition moves to rebalance the cluster:")
+		reassignments.DisplayMoves(r.current, r.moves)
+	}
+	log.InfoMaybeWithKeyf(
+		"dry-run",
+		r.opts.DryRun,
+		"Planned %d partition move(s) adding %d replica(s) with an estimated %s of data movement",
+		len(r.moves),
+		replicas,
+		reassignments.FormatBytes(bytes),
+	)
+
+	return nil
+}
+
+// hasRacks determines if all partition replicas have a rack.
+func hasRacks(partitionRacks [][]string) bool {
+	for _, racks := range partitionRacks {
+		for _, rack := range racks {
+			if len(rack) == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,227 @@
+// Package cluster implements operators for cluster-wide operations.
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/peter-evans/kdef/core/model/res"
+	"github.com/peter-evans/kdef/core/util/i32"
+)
+
+// UncleanElectorOptions represents options to configure an unclean elector.
+type UncleanElectorOptions struct {
+	Match           string
+	Exclude         string
+	IncludeInternal bool
+	Partitions      []int32
+	DryRun          bool
+}
+
+// NewUncleanElector creates a new unclean elector.
+func NewUncleanElector(
+	cl *client.Client,
+	opts UncleanElectorOptions,
+) *uncleanElector { //revive:disable-line:unexported-return
+	return &uncleanElector{
+		srv:  kafka.NewService(cl),
+		opts: opts,
+	}
+}
+
+type uncleanElector struct {
+	// Constructor fields.
+	srv  *kafka.Service
+	opts UncleanElectorOptions
+
+	// Result fields.
+	res res.ElectionsResult
+}
+
+// Execute executes the unclean elector.
+func (u *uncleanElector) Execute(ctx context.Context) *res.ElectionsResult {
+	if err := u.elect(ctx); err != nil {
+		u.res.Err = err.Error()
+		log.Error(err)
+	}
+
+	return &u.res
+}
+
+// elect performs the unclean election operation sequence.
+func (u *uncleanElector) elect(ctx context.Context) error {
+	log.Infof("Fetching offline partitions...")
+	offline, err := u.fetchOfflinePartitions(ctx)
+	if err != nil {
+		return err
+	}
+	u.res.PartitionElections = offline
+
+	if len(offline) == 0 {
+		log.Infof("No offline partitions found")
+		return nil
+	}
+
+	if !log.Quiet {
+		log.Infof("Offline partitions:")
+		displayElections(offline, false)
+	}
+
+	log.InfoMaybeWithKeyf(
+		"dry-run",
+		u.opts.DryRun,
+		"Electing unclean leaders for %d offline partition(s)...",
+		len(offline),
+	)
+	if u.opts.DryRun {
+		return nil
+	}
+
+	// Unclean leader election may lose committed data, so each election is logged.
+	for _, e := range offline {
+		log.Warnf("Electing an unclean leader for partition %d of topic %q", e.Partition, e.Topic)
+	}
+
+	elections, err := u.srv.ElectUncleanLeaders(ctx, offline.Partitions())
+	if err != nil {
+		return err
+	}
+
+	errs := make(map[string]string)
+	for _, e := range elections {
+		errs[fmt.Sprintf("%s:%d", e.Topic, e.Partition)] = e.Err
+	}
+	for i, e := range u.res.PartitionElections {
+		u.res.PartitionElections[i].Err = errs[fmt.Sprintf("%s:%d", e.Topic, e.Partition)]
+	}
+	u.res.Applied = len(elections) > elections.Failed()
+
+	// Fetch the elected leaders.
+	if err := u.updateLeaders(ctx); err != nil {
+		return err
+	}
+
+	if !log.Quiet {
+		log.Infof("Unclean leader election results:")
+		displayElections(u.res.PartitionElections, true)
+	}
+
+	if failed := u.res.PartitionElections.Failed(); failed > 0 {
+		return fmt.Errorf("unclean leader election failed for %d partition(s)", failed)
+	}
+	log.Infof("Elected unclean leaders for %d partition(s)", len(u.res.PartitionElections))
+
+	return nil
+}
+
+// fetchOfflinePartitions fetches the offline partitions of matching topics.
+func (u *uncleanElector) fetchOfflinePartitions(ctx context.Context) (meta.PartitionElections, error) {
+	matchRegExp, err := regexp.Compile(u.opts.Match)
+	if err != nil {
+		return nil, err
+	}
+	excludeRegExp, err := regexp.Compile(u.opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := u.srv.DescribeMetadata(ctx, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var offline meta.PartitionElections
+	for _, topic := range metadata.Topics {
+		if !matchRegExp.MatchString(topic.Topic) || excludeRegExp.MatchString(topic.Topic) {
+			continue
+		}
+		// Kafka internal topics are prefixed by double underscores.
+		// Confluent internal topics are prefixed by single underscores.
+		if strings.HasPrefix(topic.Topic, "_") && !u.opts.IncludeInternal {
+			continue
+		}
+
+		for partition, leader := range topic.PartitionLeaders {
+			if leader >= 0 {
+				continue
+			}
+			if len(u.opts.Partitions) > 0 && !i32.Contains(int32(partition), u.opts.Partitions) {
+				continue
+			}
+			offline = append(offline, meta.PartitionElection{
+				Topic:     topic.Topic,
+				Partition: int32(partition),
+				Replicas:  topic.PartitionAssignments[partition],
+				ISR:       topic.PartitionISR[partition],
+				Leader:    leader,
+			})
+		}
+	}
+
+	offline.Sort()
+
+	return offline, nil
+}
+
+// updateLeaders updates the election results with the current partition leaders.
+func (u *uncleanElector) updateLeaders(ctx context.Context) error {
+	partitions := u.res.PartitionElections.Partitions()
+	topics := make([]string, 0, len(partitions))
+	for topic := range partitions {
+		topics = append(topics, topic)
+	}
+
+	metadata, err := u.srv.DescribeMetadata(ctx, topics, false)
+	if err != nil {
+		return err
+	}
+
+	topicMetadata := make(map[string]kafka.TopicMetadata)
+	for _, t := range metadata.Topics {
+		topicMetadata[t.Topic] = t
+	}
+	for i, e := range u.res.PartitionElections {
+		t, ok := topicMetadata[e.Topic]
+		if !ok || int(e.Partition) >= len(t.PartitionLeaders) {
+			continue
+		}
+		u.res.PartitionElections[i].Leader = t.PartitionLeaders[e.Partition]
+		u.res.PartitionElections[i].ISR = t.PartitionISR[e.Partition]
+	}
+
+	return nil
+}
+
+// displayElections displays partition leader elections in a table.
+func displayElections(elections meta.PartitionElections, includeResult bool) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	header := table.Row{"Topic", "Partition", "Replicas", "ISR", "Leader"}
+	if includeResult {
+		header = append(header, "Error")
+	}
+	t.AppendHeader(header)
+	for _, e := range elections {
+		row := table.Row{
+			e.Topic,
+			fmt.Sprint(e.Partition),
+			fmt.Sprint(e.Replicas),
+			fmt.Sprint(e.ISR),
+			fmt.Sprint(e.Leader),
+		}
+		if includeResult {
+			row = append(row, e.Err)
+		}
+		t.AppendRow(row)
+	}
+	t.SetStyle(table.StyleLight)
+	t.Render()
+}
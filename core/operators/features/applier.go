@@ -0,0 +1,276 @@
+// Package features implements operators for features definition operations.
+package features
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/helpers/jsondiff"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/peter-evans/kdef/core/model/opt"
+	"github.com/peter-evans/kdef/core/model/res"
+)
+
+// ApplierOptions represents options to configure an applier.
+type ApplierOptions struct {
+	DefinitionFormat  opt.DefinitionFormat
+	PropertyOverrides []string
+	DryRun            bool
+	AcceptDowngrades  bool
+}
+
+// NewApplier creates a new applier.
+func NewApplier(
+	cl *client.Client,
+	defDoc string,
+	opts ApplierOptions,
+) *applier { //revive:disable-line:unexported-return
+	return &applier{
+		srv:    kafka.NewService(cl),
+		defDoc: defDoc,
+		opts:   opts,
+	}
+}
+
+type applierOps struct {
+	features kafka.FeatureUpdates
+}
+
+func (a applierOps) pending() bool {
+	return len(a.features) > 0
+}
+
+type applier struct {
+	// Constructor fields.
+	srv    *kafka.Service
+	defDoc string
+	opts   ApplierOptions
+
+	// Internal fields.
+	localDef       def.FeaturesDefinition
+	remoteDef      def.FeaturesDefinition
+	remoteFeatures meta.Features
+	ops            applierOps
+
+	// Result fields.
+	res res.ApplyResult
+}
+
+// Execute executes the applier.
+func (a *applier) Execute(ctx context.Context) *res.ApplyResult {
+	if err := a.apply(ctx); err != nil {
+		a.res.SetErr(err)
+		log.Error(err)
+	} else if a.ops.pending() && !a.opts.DryRun {
+		a.res.Applied = true
+	}
+
+	return &a.res
+}
+
+// apply performs the apply operation sequence.
+func (a *applier) apply(ctx context.Context) error {
+	if err := a.createLocal(); err != nil {
+		return err
+	}
+
+	log.Debugf("Validating features definition")
+	if err := a.localDef.Validate(); err != nil {
+		return err
+	}
+
+	if err := a.fetchRemote(ctx); err != nil {
+		return err
+	}
+
+	log.Debugf("Validating features definition using cluster features")
+	if err := a.localDef.ValidateWithMetadata(a.remoteFeatures); err != nil {
+		return err
+	}
+
+	a.buildOps()
+
+	if err := a.updateApplyResult(); err != nil {
+		return err
+	}
+
+	if a.opts.DryRun && !log.Quiet {
+		log.InfoWithKeyf("dry-run", "Features supported by all brokers:")
+		a.displayFeatures()
+	}
+
+	if a.ops.pending() {
+		if !log.Quiet {
+			a.displayPendingOps()
+		}
+
+		if err := a.executeOps(ctx); err != nil {
+			return err
+		}
+
+		log.InfoMaybeWithKeyf("dry-run", a.opts.DryRun, "Completed apply for features definition %q", a.localDef.Metadata.Name)
+	} else {
+		log.Infof("No changes to apply for features definition %q", a.localDef.Metadata.Name)
+	}
+
+	return nil
+}
+
+// createLocal creates the local definition.
+func (a *applier) createLocal() error {
+	var err error
+	a.localDef, err = def.LoadFeaturesDefinition(a.defDoc, a.opts.DefinitionFormat)
+	if err != nil {
+		return err
+	}
+
+	a.res.LocalDef = &a.localDef
+
+	return nil
+}
+
+// fetchRemote fetches the remote definition and necessary metadata.
+func (a *applier) fetchRemote(ctx context.Context) error {
+	log.Infof("Fetching cluster metadata...")
+	metadata, err := a.srv.DescribeMetadata(ctx, []string{}, false)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Fetching remote features...")
+	a.remoteFeatures, err = a.srv.DescribeFeatures(ctx, metadata.Brokers.IDs())
+	if err != nil {
+		return err
+	}
+
+	a.remoteDef = def.NewFeaturesDefinition(a.localDef.Metadata, a.remoteFeatures.FinalizedLevels())
+
+	return nil
+}
+
+// buildOps builds feature update operations.
+func (a *applier) buildOps() {
+	names := make([]string, 0, len(a.localDef.Spec.Features))
+	for name := range a.localDef.Spec.Features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		level := a.localDef.Spec.Features[name]
+		finalizedLevel := a.remoteDef.Spec.Features[name]
+		if level == finalizedLevel {
+			continue
+		}
+
+		log.Debugf("Finalized level of feature %q will be updated from %d to %d", name, finalizedLevel, level)
+		a.ops.features = append(a.ops.features, kafka.FeatureUpdate{
+			Name:      name,
+			Level:     level,
+			Downgrade: level < finalizedLevel,
+		})
+	}
+}
+
+// updateApplyResult updates the apply result with the remote definition and human readable diff.
+func (a *applier) updateApplyResult() error {
+	remoteCopy := a.remoteDef.Copy()
+
+	// Modify the remote definition to only show features specified in local.
+	// Features that are not finalized have a level of 0.
+	remoteCopy.Spec.Features = make(map[string]int16)
+	for name := range a.localDef.Spec.Features {
+		remoteCopy.Spec.Features[name] = a.remoteDef.Spec.Features[name]
+	}
+
+	// Set properties that are local only and have no remote state.
+	remoteCopy.Spec.AllowDowngrades = a.localDef.Spec.AllowDowngrades
+
+	diff, err := jsondiff.Diff(&remoteCopy, &a.localDef)
+	if err != nil {
+		return fmt.Errorf("failed to compute diff: %v", err)
+	}
+
+	if diffExists := (len(diff) > 0); diffExists != a.ops.pending() {
+		return fmt.Errorf("existence of diff was %v, but expected %v", diffExists, a.ops.pending())
+	}
+
+	a.res.RemoteDef = remoteCopy
+	a.res.Diff = diff
+
+	return nil
+}
+
+// displayFeatures displays the supported version ranges and finalized levels of features.
+func (a *applier) displayFeatures() {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Feature", "Min Version", "Max Version", "Finalized Level", "Level"})
+	for _, f := range a.remoteFeatures {
+		level := ""
+		if l, ok := a.localDef.Spec.Features[f.Name]; ok {
+			level = fmt.Sprint(l)
+		}
+		t.AppendRow(table.Row{
+			f.Name,
+			fmt.Sprint(f.MinVersion),
+			fmt.Sprint(f.MaxVersion),
+			fmt.Sprint(f.FinalizedLevel),
+			level,
+		})
+	}
+	t.SetStyle(table.StyleLight)
+	t.Render()
+}
+
+// displayPendingOps displays pending operations.
+func (a *applier) displayPendingOps() {
+	log.Infof("features definition %q diff (local -> remote):", a.localDef.Metadata.Name)
+	fmt.Println(a.res.Diff)
+}
+
+// executeOps executes update operations.
+func (a *applier) executeOps(ctx context.Context) error {
+	if len(a.ops.features) > 0 {
+		if err := a.updateFeatures(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateFeatures updates the finalized levels of features.
+func (a *applier) updateFeatures(ctx context.Context) error {
+	for _, update := range a.ops.features {
+		if update.Downgrade {
+			log.Warnf(
+				"Finalized level of feature %q will be downgraded to %d",
+				update.Name,
+				update.Level,
+			)
+		}
+	}
+	if a.ops.features.ContainsDowngrade() && !a.opts.AcceptDowngrades && !a.opts.DryRun {
+		return errors.New("cannot apply features because feature downgrades have not been accepted")
+	}
+
+	log.InfoMaybeWithKeyf("dry-run", a.opts.DryRun, "Updating features...")
+	// Update features requests with validate only are not supported by all Kafka versions.
+	if !a.opts.DryRun {
+		if err := a.srv.UpdateFeatures(ctx, a.ops.features); err != nil {
+			return err
+		}
+	}
+	log.InfoMaybeWithKeyf("dry-run", a.opts.DryRun, "Updated features for features definition %q", a.localDef.Metadata.Name)
+
+	return nil
+}
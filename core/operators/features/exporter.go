@@ -0,0 +1,66 @@
+// Package features implements operators for features definition operations.
+package features
+
+import (
+	"context"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/res"
+)
+
+// NewExporter creates a new exporter.
+func NewExporter(
+	cl *client.Client,
+) *exporter { //revive:disable-line:unexported-return
+	return &exporter{
+		srv: kafka.NewService(cl),
+	}
+}
+
+type exporter struct {
+	srv *kafka.Service
+}
+
+// Execute executes the export operation.
+func (e *exporter) Execute(ctx context.Context) (res.ExportResults, error) {
+	log.Infof("Fetching remote features...")
+	featuresDef, err := e.getFeaturesDefinition(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(featuresDef.Spec.Features) == 0 {
+		return nil, nil
+	}
+
+	results := make(res.ExportResults, 1)
+	results[0] = res.ExportResult{
+		ID:  featuresDef.Metadata.Name,
+		Def: featuresDef,
+	}
+
+	return results, nil
+}
+
+func (e *exporter) getFeaturesDefinition(ctx context.Context) (*def.FeaturesDefinition, error) {
+	metadata, err := e.srv.DescribeMetadata(ctx, []string{}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	features, err := e.srv.DescribeFeatures(ctx, metadata.Brokers.IDs())
+	if err != nil {
+		return nil, err
+	}
+
+	featuresDef := def.NewFeaturesDefinition(
+		def.ResourceMetadataDefinition{
+			Name: "features",
+		},
+		features.FinalizedLevels(),
+	)
+
+	return &featuresDef, nil
+}
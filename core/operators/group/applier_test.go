@@ -0,0 +1,149 @@
+//go:build integration
+
+// Package group implements operators for group definition operations.
+package group
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/opt"
+	"github.com/peter-evans/kdef/core/test/compose"
+	"github.com/peter-evans/kdef/core/test/harness"
+	"github.com/peter-evans/kdef/core/test/tutil"
+)
+
+// VERBOSE_TESTS=1 go test --tags=integration -run ^Test_applier_Execute$ ./core/operators/group -v
+func Test_applier_Execute(t *testing.T) {
+	_, log.Verbose = os.LookupEnv("VERBOSE_TESTS")
+
+	type fields struct {
+		cl      *client.Client
+		yamlDoc string
+		opts    ApplierOptions
+	}
+	type testCase struct {
+		name        string
+		fields      fields
+		wantDiff    string
+		wantErr     string
+		wantApplied bool
+	}
+
+	ctx := context.Background()
+
+	runTests := func(t *testing.T, tests []testCase) {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				a := NewApplier(tt.fields.cl, tt.fields.yamlDoc, tt.fields.opts)
+				got := a.Execute(ctx)
+
+				if log.Verbose {
+					// Output apply result JSON
+					jsonOut, err := json.MarshalIndent(got, "", "  ")
+					if err != nil {
+						t.Errorf("failed to convert apply result to json: %v", err)
+						t.FailNow()
+					}
+					fmt.Println("[test] ApplyResult JSON:")
+					fmt.Println(string(jsonOut))
+				}
+
+				if got.Diff != tt.wantDiff {
+					t.Errorf("applier.Execute().Diff = %v, want %v", got.Diff, tt.wantDiff)
+				}
+				if !tutil.ErrorContains(got.GetErr(), tt.wantErr) {
+					t.Errorf("applier.Execute() error = %v, wantErr %v", got.GetErr(), tt.wantErr)
+				}
+				if got.Applied != tt.wantApplied {
+					t.Errorf("applier.Execute().Applied = %v, want %v", got.Applied, tt.wantApplied)
+				}
+			})
+		}
+	}
+
+	// Create client
+	cl := tutil.CreateClient(t,
+		[]string{fmt.Sprintf("seedBrokers=localhost:%d", harness.GroupApplier.BrokerPort)},
+	)
+
+	// Create the test cluster
+	srv := kafka.NewService(cl)
+	maxTries := 3
+	try := 1
+	for {
+		start := time.Now()
+		c := compose.Up(
+			t,
+			harness.GroupApplier.ComposeFilePaths,
+			harness.GroupApplier.Env(),
+		)
+		if srv.IsKafkaReady(ctx, harness.GroupApplier.Brokers, 90) {
+			duration := time.Since(start)
+			log.Infof("kafka cluster ready in %v", duration)
+			break
+		} else {
+			log.Warnf("kafka failed to be ready within timeout")
+			compose.Down(t, c)
+			try++
+		}
+		if try > maxTries {
+			t.Errorf("kafka failed to be ready within timeout after %d tries", maxTries)
+			t.FailNow()
+		}
+		time.Sleep(2 * time.Second)
+	}
+
+	// Tests group definitions applied to a cluster without support for group config resources (Kafka 3.0.x)
+	groupDoc := "apiVersion: v1\nkind: group\nmetadata:\n  name: store.consumer\nspec:\n  configs:\n    consumer.session.timeout.ms: 60000"
+	runTests(t, []testCase{
+		{
+			name: "1: Dry-run group definition without a name",
+			fields: fields{
+				cl:      cl,
+				yamlDoc: "apiVersion: v1\nkind: group\nmetadata:\n  labels:\n    app: store",
+				opts: ApplierOptions{
+					DefinitionFormat: opt.YAMLFormat,
+					DryRun:           true,
+				},
+			},
+			wantDiff:    "",
+			wantErr:     "metadata name must be supplied",
+			wantApplied: false,
+		},
+		{
+			name: "2: Dry-run group store.consumer",
+			fields: fields{
+				cl:      cl,
+				yamlDoc: groupDoc,
+				opts: ApplierOptions{
+					DefinitionFormat: opt.YAMLFormat,
+					DryRun:           true,
+				},
+			},
+			wantDiff:    "",
+			wantErr:     "group config resources are not supported by the target cluster (Kafka 4.0.0+)",
+			wantApplied: false,
+		},
+		{
+			name: "3: Apply group store.consumer",
+			fields: fields{
+				cl:      cl,
+				yamlDoc: groupDoc,
+				opts: ApplierOptions{
+					DefinitionFormat: opt.YAMLFormat,
+				},
+			},
+			wantDiff:    "",
+			wantErr:     "group config resources are not supported by the target cluster (Kafka 4.0.0+)",
+			wantApplied: false,
+		},
+	})
+}
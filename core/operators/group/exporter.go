@@ -0,0 +1,96 @@
+// Package group implements operators for group definition operations.
+package group
+
+import (
+	"context"
+	"errors"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/res"
+)
+
+// NewExporter creates a new exporter.
+func NewExporter(
+	cl *client.Client,
+) *exporter { //revive:disable-line:unexported-return
+	return &exporter{
+		srv: kafka.NewService(cl),
+	}
+}
+
+type exporter struct {
+	// constructor params
+	srv *kafka.Service
+}
+
+// Execute executes the export operation.
+func (e *exporter) Execute(ctx context.Context) (res.ExportResults, error) {
+	log.Debugf("Checking if group config resources are supported by the target cluster...")
+	supported, err := e.srv.IsGroupConfigSupported(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !supported {
+		return nil, errors.New("group config resources are not supported by the target cluster (Kafka 4.0.0+)")
+	}
+
+	log.Infof("Fetching remote group configuration...")
+	groupDefs, err := e.getGroupDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groupDefs) == 0 {
+		return nil, nil
+	}
+
+	results := make(res.ExportResults, len(groupDefs))
+	for i, groupDef := range groupDefs {
+		results[i] = res.ExportResult{
+			ID:  groupDef.Metadata.Name,
+			Def: groupDef,
+		}
+	}
+
+	results.Sort()
+
+	return results, nil
+}
+
+func (e *exporter) getGroupDefinitions(ctx context.Context) ([]def.GroupDefinition, error) {
+	// Configs of groups that are not listed, e.g. groups that do not exist, are not exported.
+	groups, err := e.srv.ListGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	resourceConfigs, err := e.srv.DescribeGroupConfigs(ctx, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	groupDefs := []def.GroupDefinition{}
+	for _, resource := range resourceConfigs {
+		// Only groups with non-default configs are exported.
+		configsMap := resource.Configs.ToDynamicExportableMap()
+		if len(configsMap) == 0 {
+			continue
+		}
+		groupDefs = append(
+			groupDefs, def.NewGroupDefinition(
+				def.ResourceMetadataDefinition{
+					Name: resource.ResourceName,
+				},
+				configsMap,
+			),
+		)
+	}
+
+	return groupDefs, nil
+}
@@ -0,0 +1,297 @@
+// Package groups implements operators for groups definition operations.
+package groups
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/helpers/jsondiff"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/peter-evans/kdef/core/model/opt"
+	"github.com/peter-evans/kdef/core/model/res"
+	"github.com/peter-evans/kdef/core/util/str"
+)
+
+// consumerProtocolTypes are the protocol types of consumer groups.
+// Groups that only commit offsets, and do not use group membership, have an empty protocol type.
+var consumerProtocolTypes = []string{"consumer", ""}
+
+// ApplierOptions represents options to configure an applier.
+type ApplierOptions struct {
+	DefinitionFormat opt.DefinitionFormat
+	DryRun           bool
+}
+
+// NewApplier creates a new applier.
+func NewApplier(
+	cl *client.Client,
+	defDoc string,
+	opts ApplierOptions,
+) *applier { //revive:disable-line:unexported-return
+	return &applier{
+		srv:    kafka.NewService(cl),
+		defDoc: defDoc,
+		opts:   opts,
+	}
+}
+
+type applierOps struct {
+	delete meta.Groups
+}
+
+func (a applierOps) pending() bool {
+	return len(a.delete) > 0
+}
+
+type applier struct {
+	// Constructor fields.
+	srv    *kafka.Service
+	defDoc string
+	opts   ApplierOptions
+
+	// Internal fields.
+	localDef  def.GroupsDefinition
+	remoteDef def.GroupsDefinition
+	ops       applierOps
+
+	// Result fields.
+	res res.ApplyResult
+}
+
+// Execute executes the applier.
+func (a *applier) Execute(ctx context.Context) *res.ApplyResult {
+	if err := a.apply(ctx); err != nil {
+		a.res.SetErr(err)
+		log.Error(err)
+	} else if a.ops.pending() && !a.opts.DryRun {
+		a.res.Applied = true
+	}
+
+	a.res.Data = res.GroupsApplyResultData{
+		StaleGroups: a.ops.delete,
+	}
+
+	return &a.res
+}
+
+// apply performs the apply operation sequence.
+func (a *applier) apply(ctx context.Context) error {
+	if err := a.createLocal(); err != nil {
+		return err
+	}
+
+	log.Debugf("Validating groups definition")
+	if err := a.localDef.Validate(); err != nil {
+		return err
+	}
+
+	if err := a.fetchRemote(ctx); err != nil {
+		return err
+	}
+
+	a.buildRemote()
+
+	if err := a.updateApplyResult(); err != nil {
+		return err
+	}
+
+	if a.ops.pending() {
+		if !log.Quiet {
+			a.displayPendingOps()
+		}
+
+		if err := a.executeOps(ctx); err != nil {
+			return err
+		}
+
+		log.InfoMaybeWithKeyf("dry-run", a.opts.DryRun, "Completed apply for groups definition %q", a.localDef.Metadata.Name)
+	} else {
+		log.Infof("No changes to apply for groups definition %q", a.localDef.Metadata.Name)
+	}
+
+	return nil
+}
+
+// createLocal creates the local definition.
+func (a *applier) createLocal() error {
+	var err error
+	a.localDef, err = def.LoadGroupsDefinition(a.defDoc, a.opts.DefinitionFormat)
+	if err != nil {
+		return err
+	}
+
+	a.res.LocalDef = &a.localDef
+
+	return nil
+}
+
+// fetchRemote fetches the groups of the cluster and determines stale groups to delete.
+func (a *applier) fetchRemote(ctx context.Context) error {
+	log.Infof("Fetching groups...")
+	names, err := a.srv.ListGroups(ctx)
+	if err != nil {
+		return err
+	}
+
+	allowed := a.localDef.AllowedGroupsMatcher()
+	var candidates []string
+	for _, name := range names {
+		if !allowed.Allows(name) {
+			candidates = append(candidates, name)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	log.Infof("Describing %d group(s) not covered by the allowed groups...", len(candidates))
+	groups, err := a.srv.DescribeGroups(ctx, candidates)
+	if err != nil {
+		return err
+	}
+
+	var since int64
+	if a.localDef.HasStaleAfter() {
+		since = time.Now().Add(-a.localDef.StaleAfterDuration()).UnixMilli()
+	}
+
+	for _, group := range groups {
+		if !isConsumerGroup(group) {
+			log.Debugf("Skipping group %q with protocol type %q", group.Group, group.ProtocolType)
+			continue
+		}
+		// Groups with active members are never deleted.
+		if !group.IsEmpty() {
+			log.Debugf("Skipping group %q in state %q with %d member(s)", group.Group, group.State, group.Members)
+			continue
+		}
+
+		if a.localDef.HasStaleAfter() {
+			activity, err := a.activitySince(ctx, group.Group, since)
+			if err != nil {
+				return err
+			}
+			switch activity {
+			case meta.GroupActivityActive:
+				log.Debugf("Skipping group %q that consumed records produced within %s", group.Group, a.localDef.Spec.StaleAfter)
+				continue
+			case meta.GroupActivityUnknown:
+				// Groups are only deleted when they are known to be inactive.
+				log.Infof(
+					"Skipping group %q because no records were produced within %s to partitions it has committed offsets for",
+					group.Group,
+					a.localDef.Spec.StaleAfter,
+				)
+				continue
+			}
+		}
+
+		a.ops.delete = append(a.ops.delete, group)
+	}
+
+	sort.Slice(a.ops.delete, func(i, j int) bool {
+		return a.ops.delete[i].Group < a.ops.delete[j].Group
+	})
+
+	return nil
+}
+
+// activitySince determines if a group has consumed records produced since a timestamp.
+// Kafka does not expose the time of offset commits, so the offsets of records produced since the timestamp are used instead.
+func (a *applier) activitySince(ctx context.Context, group string, timestamp int64) (meta.GroupActivity, error) {
+	committed, err := a.srv.FetchGroupOffsets(ctx, group)
+	if err != nil {
+		return meta.GroupActivityUnknown, err
+	}
+	if len(committed) == 0 {
+		return meta.GroupActivityUnknown, nil
+	}
+
+	offsetsSince, err := a.srv.ListOffsetsSince(ctx, committed.Topics(), timestamp)
+	if err != nil {
+		return meta.GroupActivityUnknown, err
+	}
+
+	return committed.ActivitySince(offsetsSince), nil
+}
+
+// buildRemote builds the remote definition.
+func (a *applier) buildRemote() {
+	a.remoteDef = def.NewGroupsDefinition(
+		a.localDef.Metadata,
+		a.localDef.Spec,
+		a.ops.delete.Names(),
+	)
+}
+
+// updateApplyResult updates the apply result with the remote definition and human readable diff.
+func (a *applier) updateApplyResult() error {
+	// The state of the local definition is the absence of stale groups.
+	localCopy := a.localDef.Copy()
+	localCopy.State = &def.GroupsStateDefinition{}
+
+	diff, err := jsondiff.Diff(&a.remoteDef, &localCopy)
+	if err != nil {
+		return fmt.Errorf("failed to compute diff: %v", err)
+	}
+
+	if diffExists := (len(diff) > 0); diffExists != a.ops.pending() {
+		return fmt.Errorf("existence of diff was %v, but expected %v", diffExists, a.ops.pending())
+	}
+
+	a.res.RemoteDef = &a.remoteDef
+	a.res.Diff = diff
+
+	return nil
+}
+
+// displayPendingOps displays pending operations.
+func (a *applier) displayPendingOps() {
+	log.Infof("groups definition %q diff (local -> remote):", a.localDef.Metadata.Name)
+	fmt.Println(a.res.Diff)
+
+	log.Infof("%d stale group(s) will be deleted: %s", len(a.ops.delete), strings.Join(a.ops.delete.Names(), ", "))
+}
+
+// executeOps executes update operations.
+func (a *applier) executeOps(ctx context.Context) error {
+	if len(a.ops.delete) > 0 {
+		if err := a.deleteGroups(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteGroups deletes stale groups.
+func (a *applier) deleteGroups(ctx context.Context) error {
+	log.InfoMaybeWithKeyf("dry-run", a.opts.DryRun, "Deleting stale groups...")
+	if !a.opts.DryRun {
+		// Kafka rejects the deletion of groups that have gained members since they were described.
+		if err := a.srv.DeleteGroups(ctx, a.ops.delete.Names()); err != nil {
+			return err
+		}
+	}
+	log.InfoMaybeWithKeyf(
+		"dry-run",
+		a.opts.DryRun,
+		"Deleted %d stale group(s) for groups definition %q",
+		len(a.ops.delete),
+		a.localDef.Metadata.Name,
+	)
+
+	return nil
+}
+
+// isConsumerGroup determines if a group is a consumer group.
+func isConsumerGroup(group meta.Group) bool {
+	return str.Contains(group.ProtocolType, consumerProtocolTypes)
+}
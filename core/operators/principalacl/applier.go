@@ -0,0 +1,305 @@
+// Package principalacl implements operators for principal acl definition operations.
+package principalacl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/helpers/acls"
+	"github.com/peter-evans/kdef/core/helpers/jsondiff"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/opt"
+	"github.com/peter-evans/kdef/core/model/res"
+)
+
+// ApplierOptions represents options to configure an applier.
+type ApplierOptions struct {
+	DefinitionFormat opt.DefinitionFormat
+	DryRun           bool
+	PrincipalTypes   []string
+}
+
+// NewApplier creates a new applier.
+func NewApplier(
+	cl *client.Client,
+	defDoc string,
+	opts ApplierOptions,
+) *applier { //revive:disable-line:unexported-return
+	return &applier{
+		srv:    kafka.NewService(cl),
+		defDoc: defDoc,
+		opts:   opts,
+	}
+}
+
+type applierOps struct {
+	addACLs    map[def.ACLResource]def.ACLEntryGroups
+	deleteACLs map[def.ACLResource]def.ACLEntryGroups
+}
+
+func (a applierOps) pending() bool {
+	return len(a.addACLs) > 0 ||
+		len(a.deleteACLs) > 0
+}
+
+type applier struct {
+	// Constructor fields.
+	srv    *kafka.Service
+	defDoc string
+	opts   ApplierOptions
+
+	// Internal fields.
+	localDef   def.PrincipalACLDefinition
+	remoteDef  def.PrincipalACLDefinition
+	localACLs  map[def.ACLResource]def.ACLEntryGroups
+	remoteACLs map[def.ACLResource]def.ACLEntryGroups
+	ops        applierOps
+
+	// Result fields.
+	res res.ApplyResult
+}
+
+// Execute executes the applier.
+func (a *applier) Execute(ctx context.Context) *res.ApplyResult {
+	if err := a.apply(ctx); err != nil {
+		a.res.SetErr(err)
+		log.Error(err)
+	} else if a.ops.pending() && !a.opts.DryRun {
+		a.res.Applied = true
+	}
+
+	return &a.res
+}
+
+// apply performs the apply operation sequence.
+func (a *applier) apply(ctx context.Context) error {
+	if err := a.createLocal(); err != nil {
+		return err
+	}
+
+	log.Debugf("Validating principal acl definition")
+	if err := a.localDef.Validate(); err != nil {
+		return err
+	}
+
+	if err := a.localDef.ValidateWithPrincipalTypes(a.principalTypes()); err != nil {
+		return err
+	}
+
+	if err := a.fetchRemote(ctx); err != nil {
+		return err
+	}
+
+	a.buildOps()
+
+	if err := a.updateApplyResult(); err != nil {
+		return err
+	}
+
+	if a.ops.pending() {
+		if !log.Quiet {
+			a.displayPendingOps()
+		}
+
+		if err := a.executeOps(ctx); err != nil {
+			return err
+		}
+
+		log.InfoMaybeWithKeyf("dry-run", a.opts.DryRun, "Completed apply for principal acl definition %q", a.localDef.Metadata.Name)
+	} else {
+		log.Infof("No changes to apply for principal acl definition %q", a.localDef.Metadata.Name)
+	}
+
+	return nil
+}
+
+// createLocal creates the local definition.
+func (a *applier) createLocal() error {
+	var err error
+	a.localDef, err = def.LoadPrincipalACLDefinition(a.defDoc, a.opts.DefinitionFormat)
+	if err != nil {
+		return err
+	}
+
+	// Explode the local grants to one grant per host and operation.
+	grants := a.localDef.Spec.Grants.Explode()
+	grants.Sort()
+	a.localDef.Spec.Grants = grants
+
+	a.localACLs = grants.ByResource(a.localDef.Metadata.Name)
+
+	a.res.LocalDef = &a.localDef
+
+	return nil
+}
+
+// fetchRemote fetches the remote definition and necessary metadata.
+func (a *applier) fetchRemote(ctx context.Context) error {
+	log.Infof("Fetching remote ACLs of principal %q...", a.localDef.Metadata.Name)
+	resourceACLs, err := a.srv.DescribePrincipalACLs(ctx, a.localDef.Metadata.Name)
+	if err != nil {
+		return err
+	}
+
+	var grants def.PrincipalACLGrants
+	for _, resourceACLs := range resourceACLs {
+		for _, entry := range resourceACLs.ACLs {
+			grants = append(grants, def.PrincipalACLGrant{
+				ResourceType:        resourceACLs.ResourceType,
+				ResourceName:        resourceACLs.ResourceName,
+				ResourcePatternType: resourceACLs.ResourcePatternType,
+				Hosts:               entry.Hosts,
+				Operations:          entry.Operations,
+				PermissionType:      entry.PermissionType,
+			})
+		}
+	}
+	grants.Sort()
+
+	a.remoteACLs = grants.ByResource(a.localDef.Metadata.Name)
+	a.remoteDef = def.NewPrincipalACLDefinition(
+		a.localDef.Metadata,
+		grants,
+	)
+
+	return nil
+}
+
+// buildOps builds acl operations.
+func (a *applier) buildOps() {
+	log.Debugf("Comparing local and remote ACLs for principal acl definition %q", a.localDef.Metadata.Name)
+
+	a.ops.addACLs = make(map[def.ACLResource]def.ACLEntryGroups)
+	for resource, localACLs := range a.localACLs {
+		if add, _ := acls.DiffPatchIntersection(localACLs, a.remoteACLs[resource]); len(add) > 0 {
+			a.ops.addACLs[resource] = add
+		}
+	}
+
+	// Undefined ACLs are deleted from the resources of this principal only.
+	a.ops.deleteACLs = make(map[def.ACLResource]def.ACLEntryGroups)
+	if a.localDef.Spec.DeleteUndefinedACLs {
+		for resource, remoteACLs := range a.remoteACLs {
+			if del, _ := acls.DiffPatchIntersection(remoteACLs, a.localACLs[resource]); len(del) > 0 {
+				a.ops.deleteACLs[resource] = del
+			}
+		}
+	}
+}
+
+// updateApplyResult updates the apply result with the remote definition and human readable diff.
+func (a *applier) updateApplyResult() error {
+	remoteCopy := a.remoteDef.Copy()
+
+	// Modify the remote definition to remove optional properties not specified in local.
+	// Further, set properties that are local only and have no remote state.
+	remoteCopy.Spec.DeleteUndefinedACLs = a.localDef.Spec.DeleteUndefinedACLs
+
+	if !a.localDef.Spec.DeleteUndefinedACLs {
+		// Remove grants from the remote def that are not in local to prevent them showing in the diff.
+		var intersection def.PrincipalACLGrants
+		for _, grant := range remoteCopy.Spec.Grants {
+			if a.localACLs[grant.Resource()].Contains(
+				a.localDef.Metadata.Name,
+				grant.Hosts[0],
+				grant.Operations[0],
+				grant.PermissionType,
+			) {
+				intersection = append(intersection, grant)
+			}
+		}
+		remoteCopy.Spec.Grants = intersection
+	}
+
+	diff, err := jsondiff.Diff(&remoteCopy, &a.localDef)
+	if err != nil {
+		return fmt.Errorf("failed to compute diff: %v", err)
+	}
+
+	if diffExists := (len(diff) > 0); diffExists != a.ops.pending() {
+		return fmt.Errorf("existence of diff was %v, but expected %v", diffExists, a.ops.pending())
+	}
+
+	a.res.RemoteDef = remoteCopy
+	a.res.Diff = diff
+
+	return nil
+}
+
+// displayPendingOps displays pending operations.
+func (a *applier) displayPendingOps() {
+	log.Infof("principal acl definition %q diff (local -> remote):", a.localDef.Metadata.Name)
+	fmt.Println(a.res.Diff)
+}
+
+// executeOps executes update operations.
+func (a *applier) executeOps(ctx context.Context) error {
+	if len(a.ops.addACLs) > 0 {
+		if err := a.addACLs(ctx); err != nil {
+			return err
+		}
+	}
+
+	if len(a.ops.deleteACLs) > 0 {
+		if err := a.deleteACLs(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addACLs adds ACLs.
+func (a *applier) addACLs(ctx context.Context) error {
+	log.InfoMaybeWithKeyf("dry-run", a.opts.DryRun, "Adding ACLs...")
+
+	if !a.opts.DryRun {
+		for _, resource := range acls.SortedResources(a.ops.addACLs) {
+			if err := a.srv.CreateACLs(
+				ctx,
+				resource.Name,
+				resource.Type,
+				resource.PatternType,
+				a.ops.addACLs[resource],
+			); err != nil {
+				return err
+			}
+		}
+	}
+	log.InfoMaybeWithKeyf("dry-run", a.opts.DryRun, "Added ACLs for principal acl definition %q", a.localDef.Metadata.Name)
+
+	return nil
+}
+
+// deleteACLs deletes ACLs.
+func (a *applier) deleteACLs(ctx context.Context) error {
+	log.InfoMaybeWithKeyf("dry-run", a.opts.DryRun, "Deleting ACLs...")
+
+	if !a.opts.DryRun {
+		for _, resource := range acls.SortedResources(a.ops.deleteACLs) {
+			if err := a.srv.DeleteACLs(
+				ctx,
+				resource.Name,
+				resource.Type,
+				resource.PatternType,
+				a.ops.deleteACLs[resource],
+			); err != nil {
+				return err
+			}
+		}
+	}
+	log.InfoMaybeWithKeyf("dry-run", a.opts.DryRun, "Deleted ACLs for principal acl definition %q", a.localDef.Metadata.Name)
+
+	return nil
+}
+
+// principalTypes returns the allowed principal types.
+func (a *applier) principalTypes() []string {
+	if len(a.opts.PrincipalTypes) == 0 {
+		return def.DefaultACLPrincipalTypes
+	}
+	return a.opts.PrincipalTypes
+}
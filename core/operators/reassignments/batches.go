@@ -0,0 +1,133 @@
+package reassignments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/helpers/reassignments"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/meta"
+)
+
+// DefaultAwaitTimeout is the default time in seconds to await the completion of a batch of partition reassignments.
+const DefaultAwaitTimeout = 3600
+
+// ErrAwaitTimeout is returned when partition reassignments do not complete before the await timeout.
+var ErrAwaitTimeout = errors.New("timed out awaiting completion of partition reassignments")
+
+// awaitInterval is the interval between checks for the completion of partition reassignments.
+var awaitInterval = 5 * time.Second
+
+// BatchOptions represents options to configure the submission of partition moves in batches.
+type BatchOptions struct {
+	MaxMoves     int
+	AwaitTimeout int
+	AwaitFinal   bool
+}
+
+// SubmitBatches submits partition moves in batches containing a maximum number of moves.
+// Each batch is submitted only when the partition reassignments of the previous batch have completed.
+// The final batch is awaited only if AwaitFinal is set.
+func SubmitBatches(
+	ctx context.Context,
+	srv *kafka.Service,
+	moves meta.TopicPartitionAssignments,
+	opts BatchOptions,
+) error {
+	batches := moves.Batches(opts.MaxMoves)
+	completed := 0
+	for i, batch := range batches {
+		if len(batches) > 1 {
+			log.Infof("Submitting partition reassignment batch %d/%d (%d partition moves)...", i+1, len(batches), len(batch))
+		}
+		if err := srv.AlterPartitionAssignments(ctx, batch); err != nil {
+			return err
+		}
+		if i == len(batches)-1 && !opts.AwaitFinal {
+			break
+		}
+
+		if err := AwaitBatch(ctx, srv, batch, opts.AwaitTimeout); err != nil {
+			return err
+		}
+
+		completed += len(batch)
+		log.Infof(
+			"Partition reassignment progress: %d/%d batches, %d/%d partition moves completed",
+			i+1,
+			len(batches),
+			completed,
+			len(moves),
+		)
+	}
+
+	return nil
+}
+
+// AwaitBatch awaits the completion of the partition reassignments of a batch of partition moves.
+func AwaitBatch(
+	ctx context.Context,
+	srv *kafka.Service,
+	batch meta.TopicPartitionAssignments,
+	timeoutSec int,
+) error {
+	inBatch := make(map[string]bool)
+	for _, b := range batch {
+		inBatch[fmt.Sprintf("%s:%d", b.Topic, b.Partition)] = true
+	}
+
+	return Await(ctx, timeoutSec, func(ctx context.Context) (meta.PartitionReassignments, error) {
+		all, err := srv.ListAllPartitionReassignments(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var pending meta.PartitionReassignments
+		for _, r := range all {
+			if inBatch[fmt.Sprintf("%s:%d", r.Topic, r.Partition)] {
+				pending = append(pending, r)
+			}
+		}
+		return pending, nil
+	})
+}
+
+// Await awaits the completion of the in-progress partition reassignments returned by a list function.
+// ErrAwaitTimeout is returned if the reassignments do not complete within the timeout.
+// The context error is returned if the context is done before the reassignments complete.
+func Await(
+	ctx context.Context,
+	timeoutSec int,
+	list func(ctx context.Context) (meta.PartitionReassignments, error),
+) error {
+	log.Infof("Awaiting completion of partition reassignments (timeout: %d seconds)...", timeoutSec)
+	timeout := time.NewTimer(time.Duration(timeoutSec) * time.Second)
+	defer timeout.Stop()
+
+	remaining := 0
+	for {
+		pending, err := list(ctx)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			log.Infof("Partition reassignments completed")
+			return nil
+		}
+		if !log.Quiet && len(pending) != remaining {
+			reassignments.Display(pending, true)
+		}
+		remaining = len(pending)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return fmt.Errorf("%w after %d seconds", ErrAwaitTimeout, timeoutSec)
+		case <-time.After(awaitInterval):
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Package reassignments implements operators for partition reassignment operations.
+package reassignments
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/model/meta"
+)
+
+func TestAwait(t *testing.T) {
+	log.Quiet = true
+	awaitInterval = 10 * time.Millisecond
+
+	pending := meta.PartitionReassignments{{Topic: "foo", Partition: 0}}
+
+	t.Run("Test reassignments completing", func(t *testing.T) {
+		calls := 0
+		err := Await(context.Background(), 1, func(_ context.Context) (meta.PartitionReassignments, error) {
+			calls++
+			if calls < 3 {
+				return pending, nil
+			}
+			return nil, nil
+		})
+		if err != nil {
+			t.Errorf("Await() error = %v, want nil", err)
+		}
+		if calls != 3 {
+			t.Errorf("Await() list calls = %d, want 3", calls)
+		}
+	})
+
+	t.Run("Test reassignments timing out", func(t *testing.T) {
+		err := Await(context.Background(), 1, func(_ context.Context) (meta.PartitionReassignments, error) {
+			return pending, nil
+		})
+		if !errors.Is(err, ErrAwaitTimeout) {
+			t.Errorf("Await() error = %v, want %v", err, ErrAwaitTimeout)
+		}
+	})
+
+	t.Run("Test context done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		err := Await(ctx, 60, func(_ context.Context) (meta.PartitionReassignments, error) {
+			cancel()
+			return pending, nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Await() error = %v, want %v", err, context.Canceled)
+		}
+	})
+
+	t.Run("Test list error", func(t *testing.T) {
+		listErr := errors.New("list failed")
+		err := Await(context.Background(), 1, func(_ context.Context) (meta.PartitionReassignments, error) {
+			return nil, listErr
+		})
+		if !errors.Is(err, listErr) {
+			t.Errorf("Await() error = %v, want %v", err, listErr)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+// Package reassignments implements operators for partition reassignment operations.
+package reassignments
+
+import (
+	"context"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/helpers/reassignments"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/res"
+)
+
+// CancellerOptions represents options to configure a canceller.
+type CancellerOptions struct {
+	Match   string
+	Exclude string
+	DryRun  bool
+}
+
+// NewCanceller creates a new canceller.
+func NewCanceller(
+	cl *client.Client,
+	opts CancellerOptions,
+) *canceller { //revive:disable-line:unexported-return
+	return &canceller{
+		srv:  kafka.NewService(cl),
+		opts: opts,
+	}
+}
+
+type canceller struct {
+	srv  *kafka.Service
+	opts CancellerOptions
+
+	// Result fields.
+	res res.ReassignmentsResult
+}
+
+// Execute executes the cancel operation.
+func (c *canceller) Execute(ctx context.Context) *res.ReassignmentsResult {
+	if err := c.cancel(ctx); err != nil {
+		c.res.Err = err.Error()
+		log.Error(err)
+	} else if len(c.res.PartitionReassignments) > 0 && !c.opts.DryRun {
+		c.res.Applied = true
+	}
+
+	return &c.res
+}
+
+// cancel performs the cancel operation sequence.
+func (c *canceller) cancel(ctx context.Context) error {
+	log.Infof("Fetching in-progress partition reassignments...")
+	var err error
+	c.res.PartitionReassignments, err = fetchReassignments(ctx, c.srv, c.opts.Match, c.opts.Exclude)
+	if err != nil {
+		return err
+	}
+
+	if len(c.res.PartitionReassignments) == 0 {
+		log.Infof("No in-progress partition reassignments to cancel")
+		return nil
+	}
+
+	if !log.Quiet {
+		log.Infof("In-progress partition reassignments to cancel:")
+		reassignments.Display(c.res.PartitionReassignments, true)
+	}
+
+	log.InfoMaybeWithKeyf("dry-run", c.opts.DryRun, "Cancelling partition reassignments...")
+	if !c.opts.DryRun {
+		// AlterPartitionAssignments has no 'ValidateOnly' for dry-run mode.
+		if err := c.srv.AlterPartitionAssignments(
+			ctx,
+			reassignments.Cancellations(c.res.PartitionReassignments),
+		); err != nil {
+			return err
+		}
+	}
+	log.InfoMaybeWithKeyf(
+		"dry-run",
+		c.opts.DryRun,
+		"Cancelled %d partition reassignment(s) for %d topic(s)",
+		len(c.res.PartitionReassignments),
+		len(c.res.PartitionReassignments.Topics()),
+	)
+
+	return nil
+}
@@ -0,0 +1,165 @@
+// Package reassignments implements operators for partition reassignment operations.
+package reassignments
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/helpers/reassignments"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/meta"
+)
+
+// ExecutorOptions represents options to configure an executor.
+type ExecutorOptions struct {
+	MaxMoves     int
+	AwaitTimeout int
+	ThrottleRate int64
+	DryRun       bool
+}
+
+// NewExecutor creates a new executor of partition reassignments spanning multiple topics.
+func NewExecutor(
+	srv *kafka.Service,
+	opts ExecutorOptions,
+) *executor { //revive:disable-line:unexported-return
+	return &executor{
+		srv:  srv,
+		opts: opts,
+	}
+}
+
+type executor struct {
+	srv  *kafka.Service
+	opts ExecutorOptions
+}
+
+// Execute executes partition moves in batches, awaiting the completion of each batch.
+// Replication is throttled for the duration of the moves if a throttle rate is set.
+// Preferred leaders are elected for moved partitions with a changed preferred leader.
+func (e *executor) Execute(
+	ctx context.Context,
+	current meta.TopicPartitionAssignments,
+	moves meta.TopicPartitionAssignments,
+) (err error) {
+	if len(moves) == 0 {
+		return nil
+	}
+
+	if err := e.checkInProgress(ctx, moves); err != nil {
+		return err
+	}
+
+	log.InfoMaybeWithKeyf(
+		"dry-run",
+		e.opts.DryRun,
+		"Executing %d partition move(s) in %d batch(es)",
+		len(moves),
+		len(moves.Batches(e.opts.MaxMoves)),
+	)
+
+	if e.opts.ThrottleRate > 0 {
+		brokers := reassignments.Brokers(current, moves)
+		throttles := reassignments.ReplicationThrottles(current, moves)
+		log.InfoMaybeWithKeyf(
+			"dry-run",
+			e.opts.DryRun,
+			"Setting replication throttle of %d bytes/sec on broker(s) %v...",
+			e.opts.ThrottleRate,
+			brokers,
+		)
+		if !e.opts.DryRun {
+			if err := e.srv.SetReplicationThrottle(ctx, e.opts.ThrottleRate, brokers, throttles); err != nil {
+				return err
+			}
+			defer func() {
+				// Remove the throttle on every exit path, including when the context is done.
+				log.Infof("Removing replication throttle...")
+				rerr := e.srv.RemoveReplicationThrottle(context.WithoutCancel(ctx), brokers, throttles.Topics())
+				if rerr != nil {
+					log.Warnf("Replication throttle configs remain set on broker(s) %v and %d topic(s)", brokers, len(throttles))
+					if err == nil {
+						err = rerr
+					}
+				}
+			}()
+		}
+	}
+
+	if !e.opts.DryRun {
+		if err := SubmitBatches(ctx, e.srv, moves, BatchOptions{
+			MaxMoves:     e.opts.MaxMoves,
+			AwaitTimeout: e.opts.AwaitTimeout,
+			AwaitFinal:   true,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return e.electPreferredLeaders(ctx, current, moves)
+}
+
+// checkInProgress checks there are no in-progress partition reassignments of the topics to move.
+func (e *executor) checkInProgress(ctx context.Context, moves meta.TopicPartitionAssignments) error {
+	inProgress, err := e.srv.ListAllPartitionReassignments(ctx)
+	if err != nil {
+		return err
+	}
+
+	topics := make(map[string]bool)
+	for _, m := range moves {
+		topics[m.Topic] = true
+	}
+	for _, r := range inProgress {
+		if topics[r.Topic] {
+			return fmt.Errorf(
+				"topic %q has in-progress partition reassignments; await their completion or cancel them",
+				r.Topic,
+			)
+		}
+	}
+
+	return nil
+}
+
+// electPreferredLeaders elects the preferred leaders of moved partitions with a changed preferred leader.
+func (e *executor) electPreferredLeaders(
+	ctx context.Context,
+	current meta.TopicPartitionAssignments,
+	moves meta.TopicPartitionAssignments,
+) error {
+	preferredLeaders := make(map[string]int32)
+	for _, c := range current {
+		if len(c.Replicas) > 0 {
+			preferredLeaders[fmt.Sprintf("%s:%d", c.Topic, c.Partition)] = c.Replicas[0]
+		}
+	}
+
+	var topics []string
+	partitions := make(map[string][]int32)
+	for _, m := range moves {
+		if preferredLeaders[fmt.Sprintf("%s:%d", m.Topic, m.Partition)] == m.Replicas[0] {
+			continue
+		}
+		if _, ok := partitions[m.Topic]; !ok {
+			topics = append(topics, m.Topic)
+		}
+		partitions[m.Topic] = append(partitions[m.Topic], m.Partition)
+	}
+	if len(topics) == 0 {
+		return nil
+	}
+
+	log.InfoMaybeWithKeyf("dry-run", e.opts.DryRun, "Electing preferred partition leaders...")
+	if !e.opts.DryRun {
+		for _, topic := range topics {
+			if err := e.srv.ElectLeaders(ctx, topic, partitions[topic]); err != nil {
+				return err
+			}
+		}
+	}
+	log.InfoMaybeWithKeyf("dry-run", e.opts.DryRun, "Elected preferred partition leaders for %d topic(s)", len(topics))
+
+	return nil
+}
@@ -0,0 +1,156 @@
+// Package reassignments implements operators for partition reassignment operations.
+package reassignments
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/helpers/reassignments"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/peter-evans/kdef/core/model/res"
+	"github.com/peter-evans/kdef/core/util/i32"
+)
+
+// ImporterOptions represents options to configure an importer.
+type ImporterOptions struct {
+	ReassMaxMoves     int
+	ReassAwaitTimeout int
+	ThrottleRate      int64
+	DryRun            bool
+}
+
+// NewImporter creates a new importer of a reassignment plan.
+func NewImporter(
+	cl *client.Client,
+	plan meta.ReassignmentPlan,
+	opts ImporterOptions,
+) *importer { //revive:disable-line:unexported-return
+	return &importer{
+		srv:  kafka.NewService(cl),
+		plan: plan,
+		opts: opts,
+	}
+}
+
+type importer struct {
+	// Constructor fields.
+	srv  *kafka.Service
+	plan meta.ReassignmentPlan
+	opts ImporterOptions
+
+	// Internal fields.
+	current meta.TopicPartitionAssignments
+	moves   meta.TopicPartitionAssignments
+
+	// Result fields.
+	res res.ReassignmentsResult
+}
+
+// Execute executes the import operation.
+func (i *importer) Execute(ctx context.Context) *res.ReassignmentsResult {
+	if err := i.execute(ctx); err != nil {
+		i.res.Err = err.Error()
+		log.Error(err)
+	} else if len(i.moves) > 0 && !i.opts.DryRun {
+		i.res.Applied = true
+	}
+
+	return &i.res
+}
+
+// execute performs the import operation sequence.
+func (i *importer) execute(ctx context.Context) error {
+	if err := i.buildMoves(ctx); err != nil {
+		return err
+	}
+
+	if len(i.moves) == 0 {
+		log.Infof("No partition moves required by the reassignment plan")
+		return nil
+	}
+
+	if !log.Quiet {
+		log.InfoMaybeWithKeyf("dry-run", i.opts.DryRun, "Partition moves of the reassignment plan:")
+		reassignments.DisplayMoves(i.current, i.moves)
+	}
+
+	executor := NewExecutor(i.srv, ExecutorOptions{
+		MaxMoves:     i.opts.ReassMaxMoves,
+		AwaitTimeout: i.opts.ReassAwaitTimeout,
+		ThrottleRate: i.opts.ThrottleRate,
+		DryRun:       i.opts.DryRun,
+	})
+	return executor.Execute(ctx, i.current, i.moves)
+}
+
+// buildMoves builds the partition moves of the reassignment plan that change current assignments.
+func (i *importer) buildMoves(ctx context.Context) error {
+	assignments := i.plan.Assignments()
+
+	var topics []string
+	seen := make(map[string]bool)
+	for _, a := range assignments {
+		if !seen[a.Topic] {
+			topics = append(topics, a.Topic)
+			seen[a.Topic] = true
+		}
+	}
+	if len(topics) == 0 {
+		return nil
+	}
+
+	log.Infof("Fetching metadata of %d topic(s) in the reassignment plan...", len(topics))
+	metadata, err := i.srv.DescribeMetadata(ctx, topics, true)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range assignments {
+		for _, brokerID := range a.Replicas {
+			if !i32.Contains(brokerID, metadata.Brokers.IDs()) {
+				return fmt.Errorf(
+					"invalid broker id %q in partition %d of topic %q",
+					fmt.Sprint(brokerID),
+					a.Partition,
+					a.Topic,
+				)
+			}
+		}
+	}
+
+	for _, topic := range metadata.Topics {
+		for partition, replicas := range topic.PartitionAssignments {
+			i.current = append(i.current, meta.TopicPartitionAssignment{
+				Topic:     topic.Topic,
+				Partition: int32(partition),
+				Replicas:  replicas,
+			})
+		}
+	}
+
+	i.moves, err = reassignments.Moves(i.current, assignments)
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string][]int32)
+	for _, c := range i.current {
+		current[fmt.Sprintf("%s:%d", c.Topic, c.Partition)] = c.Replicas
+	}
+	for _, m := range i.moves {
+		replicas := current[fmt.Sprintf("%s:%d", m.Topic, m.Partition)]
+		i.res.PartitionReassignments = append(i.res.PartitionReassignments, meta.PartitionReassignment{
+			Topic:            m.Topic,
+			Partition:        m.Partition,
+			Replicas:         m.Replicas,
+			AddingReplicas:   i32.Diff(m.Replicas, replicas),
+			RemovingReplicas: i32.Diff(replicas, m.Replicas),
+		})
+	}
+	i.res.PartitionReassignments.Sort()
+
+	return nil
+}
@@ -0,0 +1,86 @@
+// Package reassignments implements operators for partition reassignment operations.
+package reassignments
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/peter-evans/kdef/cli/log"
+	"github.com/peter-evans/kdef/core/client"
+	"github.com/peter-evans/kdef/core/kafka"
+	"github.com/peter-evans/kdef/core/model/meta"
+	"github.com/peter-evans/kdef/core/model/res"
+)
+
+// ListerOptions represents options to configure a lister.
+type ListerOptions struct {
+	Match   string
+	Exclude string
+}
+
+// NewLister creates a new lister.
+func NewLister(
+	cl *client.Client,
+	opts ListerOptions,
+) *lister { //revive:disable-line:unexported-return
+	return &lister{
+		srv:  kafka.NewService(cl),
+		opts: opts,
+	}
+}
+
+type lister struct {
+	srv  *kafka.Service
+	opts ListerOptions
+}
+
+// Execute executes the list operation.
+func (l *lister) Execute(ctx context.Context) *res.ReassignmentsResult {
+	var result res.ReassignmentsResult
+
+	log.Infof("Fetching in-progress partition reassignments...")
+	reassignments, err := fetchReassignments(ctx, l.srv, l.opts.Match, l.opts.Exclude)
+	if err != nil {
+		result.Err = err.Error()
+		log.Error(err)
+		return &result
+	}
+	result.PartitionReassignments = reassignments
+
+	return &result
+}
+
+// fetchReassignments fetches in-progress partition reassignments of topics matching the regular expressions.
+func fetchReassignments(
+	ctx context.Context,
+	srv *kafka.Service,
+	match string,
+	exclude string,
+) (meta.PartitionReassignments, error) {
+	matchRegExp, err := regexp.Compile(match)
+	if err != nil {
+		return nil, err
+	}
+	excludeRegExp, err := regexp.Compile(exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	reassignments, err := srv.ListAllPartitionReassignments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := meta.PartitionReassignments{}
+	for _, r := range reassignments {
+		if !matchRegExp.MatchString(r.Topic) {
+			continue
+		}
+		if excludeRegExp.MatchString(r.Topic) {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+
+	return filtered, nil
+}
@@ -5,21 +5,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"time"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/peter-evans/kdef/cli/log"
 	"github.com/peter-evans/kdef/core/client"
 	"github.com/peter-evans/kdef/core/helpers/assignments"
 	"github.com/peter-evans/kdef/core/helpers/jsondiff"
+	"github.com/peter-evans/kdef/core/helpers/reassignments"
 	"github.com/peter-evans/kdef/core/kafka"
 	"github.com/peter-evans/kdef/core/model/def"
 	"github.com/peter-evans/kdef/core/model/meta"
 	"github.com/peter-evans/kdef/core/model/opt"
 	"github.com/peter-evans/kdef/core/model/res"
+	opreassignments "github.com/peter-evans/kdef/core/operators/reassignments"
 	"github.com/peter-evans/kdef/core/util/i32"
+	"github.com/peter-evans/kdef/core/util/str"
 )
 
 // ApplierOptions represents options to configure an applier.
@@ -28,6 +28,7 @@ type ApplierOptions struct {
 	PropertyOverrides []string
 	DryRun            bool
 	ReassAwaitTimeout int
+	ReassMaxMoves     int
 }
 
 // NewApplier creates a new applier.
@@ -49,6 +50,7 @@ type applierOps struct {
 	config            kafka.ConfigOperations
 	partitions        def.PartitionAssignments
 	assignments       def.PartitionAssignments
+	logDirs           meta.ReplicaLogDirs
 	leaderElection    struct {
 		leaders    []int32
 		partitions []int32
@@ -60,6 +62,7 @@ func (a applierOps) pending() bool {
 		len(a.config) > 0 ||
 		len(a.partitions) > 0 ||
 		len(a.assignments) > 0 ||
+		len(a.logDirs) > 0 ||
 		len(a.leaderElection.partitions) > 0
 }
 
@@ -70,13 +73,18 @@ type applier struct {
 	opts   ApplierOptions
 
 	// Internal fields.
-	localDef             def.TopicDefinition
-	remoteDef            *def.TopicDefinition
-	remoteConfigs        def.Configs
-	remotePartitionISR   def.PartitionAssignments
-	brokers              meta.Brokers
-	clusterReplicaCounts map[int32]int
-	ops                  applierOps
+	localDef           def.TopicDefinition
+	remoteDef          *def.TopicDefinition
+	remoteConfigs      def.Configs
+	remotePartitionISR def.PartitionAssignments
+	brokers            meta.Brokers
+	clusterUse         *assignments.ClusterUse
+	partitionSizes     map[string]map[int32]int64
+	rackConstraints    def.PartitionRacks
+	logDirs            meta.LogDirs
+	replicaLogDirs     meta.ReplicaLogDirs
+	remoteLogDirs      def.PartitionLogDirs
+	ops                applierOps
 
 	// Result fields.
 	res           res.ApplyResult
@@ -86,14 +94,20 @@ type applier struct {
 // Execute executes the applier.
 func (a *applier) Execute(ctx context.Context) *res.ApplyResult {
 	if err := a.apply(ctx); err != nil {
-		a.res.Err = err.Error()
+		a.res.SetErr(err)
 		log.Error(err)
 	} else if a.ops.pending() && !a.opts.DryRun {
 		a.res.Applied = true
 	}
 
+	var partitionMoves meta.TopicPartitionAssignments
+	if len(a.ops.assignments) > 0 {
+		partitionMoves = a.partitionMoves()
+	}
+
 	a.res.Data = res.TopicApplyResultData{
 		PartitionReassignments: a.reassignments,
+		PartitionMoves:         partitionMoves,
 	}
 
 	return &a.res
@@ -114,6 +128,10 @@ func (a *applier) apply(ctx context.Context) error {
 		return err
 	}
 
+	if err := a.awaitInterruptedReassignments(ctx); err != nil {
+		return err
+	}
+
 	log.Debugf("Validating topic definition using cluster metadata")
 	if err := a.localDef.ValidateWithMetadata(a.brokers); err != nil {
 		return err
@@ -184,19 +202,26 @@ func (a *applier) tryFetchRemote(ctx context.Context) error {
 		return err
 	}
 
-	if a.localDef.Spec.HasManagedAssignments() && a.localDef.Spec.ManagedAssignments.Selection == def.SelectionTopicClusterUse {
-		// Describe metadata for all topics in the cluster.
-		metadata, err := a.srv.DescribeMetadata(ctx, nil, true)
-		if err != nil {
-			return err
-		}
-		a.clusterReplicaCounts = make(map[int32]int)
-		for _, t := range metadata.Topics {
-			for _, replicas := range t.PartitionAssignments {
-				for _, brokerID := range replicas {
-					a.clusterReplicaCounts[brokerID]++
+	if a.localDef.Spec.HasManagedAssignments() {
+		switch a.localDef.Spec.ManagedAssignments.Selection {
+		case def.SelectionTopicClusterUse:
+			// Describe metadata for all topics in the cluster.
+			metadata, err := a.srv.DescribeMetadata(ctx, nil, true)
+			if err != nil {
+				return err
+			}
+			a.clusterUse = &assignments.ClusterUse{ReplicaCounts: make(map[int32]int)}
+			for _, t := range metadata.Topics {
+				for _, replicas := range t.PartitionAssignments {
+					for _, brokerID := range replicas {
+						a.clusterUse.ReplicaCounts[brokerID]++
+					}
 				}
 			}
+		case def.SelectionTopicClusterDiskUse, def.SelectionTopicClusterDiskFree:
+			if err := a.fetchClusterDiskUse(ctx); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -205,13 +230,96 @@ func (a *applier) tryFetchRemote(ctx context.Context) error {
 		log.Debugf("Topic %q does not exist", a.localDef.Metadata.Name)
 	}
 
+	if a.localDef.Spec.HasLogDirs() && !a.ops.create {
+		log.Debugf("Fetching broker log directories...")
+		a.logDirs, a.replicaLogDirs, err = a.srv.DescribeLogDirs(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchClusterDiskUse fetches the disk usage of brokers to weight broker selection.
+// Disk use takes precedence over the number of replicas per broker in the topic, where less is preferred.
+func (a *applier) fetchClusterDiskUse(ctx context.Context) error {
+	log.Debugf("Fetching broker log directories...")
+	logDirs, replicaLogDirs, err := a.srv.DescribeLogDirs(ctx)
+	if err != nil {
+		return err
+	}
+	a.partitionSizes = replicaLogDirs.PartitionSizes()
+
+	a.clusterUse = &assignments.ClusterUse{
+		DiskUse:        make(map[int32]int64),
+		PartitionSizes: a.partitionSizes[a.localDef.Metadata.Name],
+	}
+	switch a.localDef.Spec.ManagedAssignments.Selection {
+	case def.SelectionTopicClusterDiskUse:
+		// Weight brokers by the total size of their partition replicas.
+		sizes := replicaLogDirs.SizesByBroker()
+		for _, brokerID := range a.brokers.IDs() {
+			a.clusterUse.DiskUse[brokerID] = sizes[brokerID]
+		}
+	case def.SelectionTopicClusterDiskFree:
+		// Weight brokers by how much less usable disk space they have than the broker with the most.
+		usable := logDirs.UsableBytesByBroker()
+		var maxUsable int64
+		for _, brokerID := range a.brokers.IDs() {
+			u, ok := usable[brokerID]
+			if !ok || u < 0 {
+				return fmt.Errorf(
+					"selection %q requires usable disk space to be reported by all brokers (Kafka 3.3.0+)",
+					def.SelectionTopicClusterDiskFree,
+				)
+			}
+			if u > maxUsable {
+				maxUsable = u
+			}
+		}
+		for _, brokerID := range a.brokers.IDs() {
+			a.clusterUse.DiskUse[brokerID] = maxUsable - usable[brokerID]
+		}
+	}
+
 	return nil
 }
 
+// awaitInterruptedReassignments awaits in-progress partition reassignments before resuming batched reassignments.
+func (a *applier) awaitInterruptedReassignments(ctx context.Context) error {
+	if a.ops.create || a.opts.ReassMaxMoves <= 0 || a.opts.DryRun {
+		return nil
+	}
+
+	if err := a.fetchPartitionReassignments(ctx, false); err != nil {
+		return err
+	}
+	if len(a.reassignments) == 0 {
+		return nil
+	}
+
+	// A previous batched reassignment may have been interrupted. Metadata for partitions being
+	// reassigned is transient, so wait for completion before determining the remaining moves.
+	log.Infof("Partition reassignments are in progress for topic %q and must complete before resuming", a.localDef.Metadata.Name)
+	if err := opreassignments.Await(ctx, a.opts.ReassAwaitTimeout, a.listPartitionReassignments); err != nil {
+		return a.resumableError(err)
+	}
+
+	return a.tryFetchRemote(ctx)
+}
+
 // buildOps builds topic operations.
 func (a *applier) buildOps(ctx context.Context) error {
+	a.buildRackConstraints()
 	if a.ops.create {
 		a.buildCreateOp()
+		if a.localDef.Spec.HasLogDirs() {
+			log.Warnf(
+				"Log dirs of topic %q will be placed by a subsequent apply once its replicas exist",
+				a.localDef.Metadata.Name,
+			)
+		}
 	} else {
 		if err := a.buildConfigOps(ctx); err != nil {
 			return err
@@ -220,11 +328,57 @@ func (a *applier) buildOps(ctx context.Context) error {
 			return err
 		}
 		a.buildAssignmentsOp()
+		if err := a.buildLogDirsOp(); err != nil {
+			return err
+		}
 		a.buildLeaderElectionOp()
 	}
 	return nil
 }
 
+// buildRackConstraints builds the rack constraints of managed assignments.
+func (a *applier) buildRackConstraints() {
+	if !a.localDef.Spec.HasManagedAssignments() {
+		return
+	}
+	if a.localDef.Spec.ManagedAssignments.HasRackConstraints() {
+		a.rackConstraints = a.localDef.Spec.ManagedAssignments.RackConstraints
+		return
+	}
+	if !a.localDef.Spec.ManagedAssignments.RackAware {
+		return
+	}
+
+	var currentAssignments def.PartitionAssignments
+	if !a.ops.create {
+		currentAssignments = a.remoteDef.Spec.Assignments
+
+		// Report partitions with replicas that are not spread across racks.
+		violations := assignments.RackSpreadViolations(currentAssignments, a.brokers.BrokersByRack())
+		if len(violations) > 0 {
+			if a.localDef.Spec.ManagedAssignments.Balance == def.BalanceAll {
+				log.Debugf("Partitions %v are not spread across racks and will be reassigned", violations)
+			} else {
+				log.Warnf(
+					"Partitions %v of topic %q are not spread across racks (balance %q reassigns them)",
+					violations,
+					a.localDef.Metadata.Name,
+					def.BalanceAll,
+				)
+			}
+		}
+	}
+
+	// Violations of the rack spread are fixed only when all assignments are in scope.
+	a.rackConstraints = assignments.RackSpreadConstraints(
+		currentAssignments,
+		a.localDef.Spec.Partitions,
+		a.localDef.Spec.ReplicationFactor,
+		a.brokers.BrokersByRack(),
+		a.localDef.Spec.ManagedAssignments.Balance == def.BalanceAll,
+	)
+}
+
 // updateLocalState updates the state property group of the local definition.
 func (a *applier) updateLocalState() {
 	// The state property group of the local definition is updated to show the underlying state changes.
@@ -279,6 +433,7 @@ func (a *applier) updateApplyResult() error {
 			if !a.localDef.Spec.ManagedAssignments.HasRackConstraints() {
 				remoteCopy.Spec.ManagedAssignments.RackConstraints = nil
 			}
+			remoteCopy.Spec.ManagedAssignments.RackAware = a.localDef.Spec.ManagedAssignments.RackAware
 			remoteCopy.Spec.ManagedAssignments.Balance = a.localDef.Spec.ManagedAssignments.Balance
 			remoteCopy.Spec.ManagedAssignments.Selection = a.localDef.Spec.ManagedAssignments.Selection
 		}
@@ -294,6 +449,9 @@ func (a *applier) updateApplyResult() error {
 			}
 		}
 
+		// Remote log dirs are only known for replicas with a defined log dir.
+		remoteCopy.Spec.LogDirs = a.remoteLogDirs
+
 		remoteCopy.Spec.DeleteUndefinedConfigs = a.localDef.Spec.DeleteUndefinedConfigs
 		remoteCopy.Spec.MaintainLeaders = a.localDef.Spec.MaintainLeaders
 
@@ -354,6 +512,12 @@ func (a *applier) executeOps(ctx context.Context) error {
 		}
 	}
 
+	if len(a.ops.logDirs) > 0 {
+		if err := a.updateLogDirs(ctx); err != nil {
+			return err
+		}
+	}
+
 	if len(a.ops.assignments) > 0 {
 		if err := a.updateAssignments(ctx); err != nil {
 			return err
@@ -374,25 +538,25 @@ func (a *applier) buildCreateOp() {
 	switch {
 	case a.localDef.Spec.HasAssignments():
 		a.ops.createAssignments = a.localDef.Spec.Assignments
-	case a.localDef.Spec.HasManagedAssignments() && a.localDef.Spec.ManagedAssignments.HasRackConstraints():
+	case len(a.rackConstraints) > 0:
 		// Make an empty set of assignments.
-		newAssignments := make(def.PartitionAssignments, len(a.localDef.Spec.ManagedAssignments.RackConstraints))
+		newAssignments := make(def.PartitionAssignments, len(a.rackConstraints))
 		for i := range newAssignments {
-			newAssignments[i] = make([]int32, len(a.localDef.Spec.ManagedAssignments.RackConstraints[0]))
+			newAssignments[i] = make([]int32, len(a.rackConstraints[0]))
 		}
-		// Populate local assignments from defined rack constraints.
+		// Populate local assignments from rack constraints.
 		a.ops.createAssignments = assignments.SyncRackConstraints(
 			newAssignments,
-			a.localDef.Spec.ManagedAssignments.RackConstraints,
+			a.rackConstraints,
 			a.brokers.BrokersByRack(),
-			a.clusterReplicaCounts,
+			a.clusterUse,
 		)
 	default:
 		a.ops.createAssignments = assignments.AddPartitions(
 			[][]int32{},
 			a.localDef.Spec.Partitions,
 			a.localDef.Spec.ReplicationFactor,
-			a.clusterReplicaCounts,
+			a.clusterUse,
 			a.brokers.IDs(),
 		)
 	}
@@ -474,7 +638,7 @@ func (a *applier) buildPartitionsOp() error {
 			a.remoteDef.Spec.Assignments,
 			a.localDef.Spec.Partitions,
 			targetRepFactor,
-			a.clusterReplicaCounts,
+			a.clusterUse,
 			a.brokers.IDs(),
 		)
 	}
@@ -508,7 +672,7 @@ func (a *applier) buildAssignmentsOp() {
 			a.ops.assignments = a.localDef.Spec.Assignments
 		}
 	} else { // Managed assignments.
-		if a.localDef.Spec.ManagedAssignments.HasRackConstraints() {
+		if len(a.rackConstraints) > 0 {
 			var newAssignments def.PartitionAssignments
 			newAssignments = assignments.Copy(a.remoteDef.Spec.Assignments)
 			if len(a.ops.partitions) > 0 {
@@ -516,9 +680,9 @@ func (a *applier) buildAssignmentsOp() {
 			}
 			newAssignments = assignments.SyncRackConstraints(
 				newAssignments,
-				a.localDef.Spec.ManagedAssignments.RackConstraints,
+				a.rackConstraints,
 				a.brokers.BrokersByRack(),
-				a.clusterReplicaCounts,
+				a.clusterUse,
 			)
 			if !cmp.Equal(a.remoteDef.Spec.Assignments, newAssignments) {
 				log.Debugf("Partition assignments are out of sync with defined racks and will be updated")
@@ -534,7 +698,7 @@ func (a *applier) buildAssignmentsOp() {
 			a.ops.assignments = assignments.AlterReplicationFactor(
 				newAssignments,
 				a.localDef.Spec.ReplicationFactor,
-				a.clusterReplicaCounts,
+				a.clusterUse,
 				a.brokers.IDs(),
 			)
 		}
@@ -546,17 +710,17 @@ func (a *applier) buildAssignmentsOp() {
 			}
 
 			var rebalancedAssignments def.PartitionAssignments
-			if a.localDef.Spec.ManagedAssignments.HasRackConstraints() {
+			if len(a.rackConstraints) > 0 {
 				rebalancedAssignments = assignments.RebalanceWithRackConstraints(
 					prebalancedAssignments,
-					a.localDef.Spec.ManagedAssignments.RackConstraints,
-					a.clusterReplicaCounts,
+					a.rackConstraints,
+					a.clusterUse,
 					a.brokers.BrokersByRack(),
 				)
 			} else {
 				rebalancedAssignments = assignments.Rebalance(
 					prebalancedAssignments,
-					a.clusterReplicaCounts,
+					a.clusterUse,
 					a.brokers.IDs(),
 				)
 			}
@@ -596,25 +760,56 @@ func (a *applier) fetchPartitionReassignments(ctx context.Context, suppressLog b
 // displayPartitionReassignments displays in-progress partition reassignments.
 func (a *applier) displayPartitionReassignments() {
 	log.Infof("In-progress partition reassignments for topic %q:", a.localDef.Metadata.Name)
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"Partition", "Replicas", "Adding Replicas", "Removing Replicas"})
-	for _, r := range a.reassignments {
-		t.AppendRow([]interface{}{
-			fmt.Sprint(r.Partition),
-			fmt.Sprint(r.Replicas),
-			fmt.Sprint(r.AddingReplicas),
-			fmt.Sprint(r.RemovingReplicas),
+	reassignments.Display(a.reassignments, false)
+}
+
+// currentAssignments returns the partition assignments prior to the assignments operation.
+func (a *applier) currentAssignments() def.PartitionAssignments {
+	// Partitions created by the partitions operation are part of the current assignments.
+	currentAssignments := a.remoteDef.Spec.Assignments
+	if len(a.ops.partitions) > 0 {
+		currentAssignments = append(assignments.Copy(currentAssignments), a.ops.partitions...)
+	}
+	return currentAssignments
+}
+
+// partitionMoves returns the assignments of partitions that will be moved by the assignments operation.
+func (a *applier) partitionMoves() meta.TopicPartitionAssignments {
+	currentAssignments := a.currentAssignments()
+
+	var moves meta.TopicPartitionAssignments
+	for partition, replicas := range a.ops.assignments {
+		if partition < len(currentAssignments) && cmp.Equal(currentAssignments[partition], replicas) {
+			continue
+		}
+		moves = append(moves, meta.TopicPartitionAssignment{
+			Topic:     a.localDef.Metadata.Name,
+			Partition: int32(partition),
+			Replicas:  replicas,
 		})
 	}
-	t.SetStyle(table.StyleLight)
-	t.Render()
+
+	return moves
 }
 
-// updateAssignments executes a request to alter assignments.
+// updateAssignments executes requests to alter assignments.
 func (a *applier) updateAssignments(ctx context.Context) error {
 	log.InfoMaybeWithKeyf("dry-run", a.opts.DryRun, "Altering partition assignments...")
 
+	moves := a.partitionMoves()
+	a.displayDataMovement(ctx, moves)
+
+	batches := moves.Batches(a.opts.ReassMaxMoves)
+	if len(batches) > 1 {
+		log.InfoMaybeWithKeyf(
+			"dry-run",
+			a.opts.DryRun,
+			"Partition reassignments will be executed in %d batches of at most %d partition moves",
+			len(batches),
+			a.opts.ReassMaxMoves,
+		)
+	}
+
 	if a.opts.DryRun {
 		// AlterPartitionAssignments has no 'ValidateOnly' for dry-run mode so we check
 		// in-progress partition reassignments and error if found.
@@ -625,12 +820,14 @@ func (a *applier) updateAssignments(ctx context.Context) error {
 			// Kafka would return a very similar error if we attempted to execute the reassignment.
 			return fmt.Errorf("a partition reassignment is in progress for the topic %q", a.localDef.Metadata.Name)
 		}
-	} else if err := a.srv.AlterPartitionAssignments(
-		ctx,
-		a.localDef.Metadata.Name,
-		a.ops.assignments,
-	); err != nil {
-		return err
+	} else {
+		// The final batch is awaited separately according to the await timeout.
+		if err := opreassignments.SubmitBatches(ctx, a.srv, moves, opreassignments.BatchOptions{
+			MaxMoves:     a.opts.ReassMaxMoves,
+			AwaitTimeout: a.opts.ReassAwaitTimeout,
+		}); err != nil {
+			return a.resumableError(err)
+		}
 	}
 
 	log.InfoMaybeWithKeyf("dry-run", a.opts.DryRun, "Altered partition assignments for topic %q", a.localDef.Metadata.Name)
@@ -638,35 +835,146 @@ func (a *applier) updateAssignments(ctx context.Context) error {
 	return nil
 }
 
+// displayDataMovement displays the estimated data movement of partition moves.
+func (a *applier) displayDataMovement(ctx context.Context, moves meta.TopicPartitionAssignments) {
+	if a.partitionSizes == nil {
+		_, replicaLogDirs, err := a.srv.DescribeLogDirs(ctx)
+		if err != nil {
+			// Estimating data movement is best-effort and should not prevent the apply.
+			log.Debugf("Unable to estimate data movement of partition moves: %v", err)
+			return
+		}
+		a.partitionSizes = replicaLogDirs.PartitionSizes()
+	}
+
+	var current meta.TopicPartitionAssignments
+	for partition, replicas := range a.currentAssignments() {
+		current = append(current, meta.TopicPartitionAssignment{
+			Topic:     a.localDef.Metadata.Name,
+			Partition: int32(partition),
+			Replicas:  replicas,
+		})
+	}
+
+	replicas, bytes := reassignments.DataMovement(current, moves, a.partitionSizes)
+	log.InfoMaybeWithKeyf(
+		"dry-run",
+		a.opts.DryRun,
+		"Partition moves add %d replica(s) with an estimated %s of data movement",
+		replicas,
+		reassignments.FormatBytes(bytes),
+	)
+}
+
 // awaitReassignments awaits the completion of in-progress partition reassignments.
+// Reassignments that do not complete within the timeout continue in the cluster.
 func (a *applier) awaitReassignments(ctx context.Context, timeoutSec int) error {
-	log.Infof("Awaiting completion of partition reassignments (timeout: %d seconds)...", timeoutSec)
-	timeout := time.After(time.Duration(timeoutSec) * time.Second)
-
-	remaining := 0
-	for {
-		select {
-		case <-timeout:
-			log.Infof("Awaiting completion of partition reassignments timed out after %d seconds", timeoutSec)
-			return nil
-		default:
-			if err := a.fetchPartitionReassignments(ctx, true); err != nil {
-				return err
+	err := opreassignments.Await(ctx, timeoutSec, a.listPartitionReassignments)
+	if errors.Is(err, opreassignments.ErrAwaitTimeout) {
+		log.Infof("Awaiting completion of partition reassignments timed out after %d seconds", timeoutSec)
+		return nil
+	}
+	return err
+}
+
+// listPartitionReassignments lists in-progress partition reassignments while awaiting their completion.
+func (a *applier) listPartitionReassignments(ctx context.Context) (meta.PartitionReassignments, error) {
+	if err := a.fetchPartitionReassignments(ctx, true); err != nil {
+		return nil, err
+	}
+	return a.reassignments, nil
+}
+
+// resumableError annotates an error that interrupted awaiting batched partition reassignments.
+func (a *applier) resumableError(err error) error {
+	if errors.Is(err, opreassignments.ErrAwaitTimeout) || errors.Is(err, context.Canceled) {
+		return fmt.Errorf(
+			"%w; submitted partition reassignments of topic %q continue in the cluster and re-running apply resumes the remaining moves",
+			err,
+			a.localDef.Metadata.Name,
+		)
+	}
+	return err
+}
+
+// buildLogDirsOp builds an operation to move replicas between log dirs.
+func (a *applier) buildLogDirsOp() error {
+	if !a.localDef.Spec.HasLogDirs() {
+		return nil
+	}
+
+	brokerDirs := make(map[int32][]string)
+	for _, logDir := range a.logDirs {
+		brokerDirs[logDir.Broker] = append(brokerDirs[logDir.Broker], logDir.Dir)
+	}
+	currentDirs := a.replicaLogDirs.Dirs(a.localDef.Metadata.Name, false)
+	futureDirs := a.replicaLogDirs.Dirs(a.localDef.Metadata.Name, true)
+
+	a.remoteLogDirs = make(def.PartitionLogDirs, len(a.localDef.Spec.LogDirs))
+	for partition, logDirs := range a.localDef.Spec.LogDirs {
+		a.remoteLogDirs[partition] = append([]string{}, logDirs...)
+		for replica, logDir := range logDirs {
+			if logDir == def.LogDirAny {
+				continue
 			}
-			if len(a.reassignments) > 0 {
-				if !log.Quiet && len(a.reassignments) != remaining {
-					a.displayPartitionReassignments()
-				}
-				remaining = len(a.reassignments)
-			} else {
-				log.Infof("Partition reassignments completed")
-				return nil
+
+			brokerID := a.localDef.Spec.Assignments[partition][replica]
+			if !str.Contains(logDir, brokerDirs[brokerID]) {
+				return fmt.Errorf("log dir %q does not exist on broker id %q", logDir, fmt.Sprint(brokerID))
 			}
 
-			time.Sleep(5 * time.Second)
-			continue
+			currentDir, ok := currentDirs[int32(partition)][brokerID]
+			if !ok {
+				// The replica is yet to be created by a partitions or assignments operation.
+				log.Warnf(
+					"Replica of partition %d on broker id %q does not exist yet; its log dir will be placed by a subsequent apply",
+					partition,
+					fmt.Sprint(brokerID),
+				)
+				continue
+			}
+			if futureDirs[int32(partition)][brokerID] == logDir {
+				log.Debugf("Replica of partition %d on broker id %q is moving to log dir %q", partition, fmt.Sprint(brokerID), logDir)
+				continue
+			}
+			if currentDir != logDir {
+				a.remoteLogDirs[partition][replica] = currentDir
+				a.ops.logDirs = append(a.ops.logDirs, meta.ReplicaLogDir{
+					Broker:    brokerID,
+					Dir:       logDir,
+					Topic:     a.localDef.Metadata.Name,
+					Partition: int32(partition),
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// updateLogDirs executes requests to move replicas between log dirs.
+func (a *applier) updateLogDirs(ctx context.Context) error {
+	log.InfoMaybeWithKeyf("dry-run", a.opts.DryRun, "Moving replicas between log dirs...")
+	if !log.Quiet {
+		reassignments.DisplayLogDirMoves(a.replicaLogDirs, a.ops.logDirs)
+	}
+
+	if !a.opts.DryRun {
+		// AlterReplicaLogDirs has no 'ValidateOnly' for dry-run mode.
+		if err := a.srv.AlterReplicaLogDirs(ctx, a.ops.logDirs); err != nil {
+			return err
 		}
 	}
+
+	log.InfoMaybeWithKeyf(
+		"dry-run",
+		a.opts.DryRun,
+		"Moving %d replica(s) between log dirs for topic %q",
+		len(a.ops.logDirs),
+		a.localDef.Metadata.Name,
+	)
+
+	return nil
 }
 
 // buildLeaderElectionOp builds a leader election operation.
@@ -10,6 +10,7 @@ import (
 	"github.com/peter-evans/kdef/core/client"
 	"github.com/peter-evans/kdef/core/kafka"
 	"github.com/peter-evans/kdef/core/model/def"
+	"github.com/peter-evans/kdef/core/model/meta"
 	"github.com/peter-evans/kdef/core/model/opt"
 	"github.com/peter-evans/kdef/core/model/res"
 )
@@ -20,6 +21,7 @@ type ExporterOptions struct {
 	Exclude         string
 	IncludeInternal bool
 	Assignments     opt.Assignments
+	LogDirs         bool
 }
 
 // NewExporter creates a new exporter.
@@ -34,8 +36,12 @@ func NewExporter(
 }
 
 type exporter struct {
+	// Constructor fields.
 	srv  *kafka.Service
 	opts ExporterOptions
+
+	// Internal fields.
+	partitionAssignments map[string]meta.TopicPartitionAssignments
 }
 
 // Execute executes the export operation.
@@ -55,6 +61,9 @@ func (e *exporter) Execute(ctx context.Context) (res.ExportResults, error) {
 		results[i] = res.ExportResult{
 			ID:  topicDef.Metadata.Name,
 			Def: topicDef,
+			Data: res.TopicExportResultData{
+				PartitionAssignments: e.partitionAssignments[topicDef.Metadata.Name],
+			},
 		}
 	}
 
@@ -86,6 +95,15 @@ func (e *exporter) getTopicDefinitions(ctx context.Context) ([]def.TopicDefiniti
 		topicConfigsMapMap[resource.ResourceName] = resource.Configs.ToExportableMap()
 	}
 
+	var replicaLogDirs meta.ReplicaLogDirs
+	if e.opts.LogDirs {
+		log.Infof("Fetching broker log directories...")
+		_, replicaLogDirs, err = e.srv.DescribeLogDirs(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	matchRegExp, err := regexp.Compile(e.opts.Match)
 	if err != nil {
 		return nil, err
@@ -95,6 +113,7 @@ func (e *exporter) getTopicDefinitions(ctx context.Context) ([]def.TopicDefiniti
 		return nil, err
 	}
 
+	e.partitionAssignments = make(map[string]meta.TopicPartitionAssignments)
 	topicDefs := []def.TopicDefinition{}
 	for _, topic := range topicNames {
 		// Kafka internal topics are prefixed by double underscores.
@@ -124,8 +143,43 @@ func (e *exporter) getTopicDefinitions(ctx context.Context) ([]def.TopicDefiniti
 		// Default to delete undefined configs.
 		topicDef.Spec.DeleteUndefinedConfigs = true
 
+		if e.opts.LogDirs {
+			topicDef.Spec.LogDirs = partitionLogDirs(
+				topicMetadataMap[topic].PartitionAssignments,
+				replicaLogDirs.Dirs(topic, false),
+			)
+		}
+
+		for partition, replicas := range topicMetadataMap[topic].PartitionAssignments {
+			e.partitionAssignments[topic] = append(e.partitionAssignments[topic], meta.TopicPartitionAssignment{
+				Topic:     topic,
+				Partition: int32(partition),
+				Replicas:  replicas,
+			})
+		}
+
 		topicDefs = append(topicDefs, topicDef)
 	}
 
 	return topicDefs, nil
 }
+
+// partitionLogDirs returns the log dirs of partition replicas in the order of the partition assignments.
+func partitionLogDirs(
+	partitionAssignments def.PartitionAssignments,
+	dirs map[int32]map[int32]string,
+) def.PartitionLogDirs {
+	logDirs := make(def.PartitionLogDirs, len(partitionAssignments))
+	for partition, replicas := range partitionAssignments {
+		logDirs[partition] = make([]string, len(replicas))
+		for i, brokerID := range replicas {
+			logDir, ok := dirs[int32(partition)][brokerID]
+			if !ok {
+				// The log dir of offline replicas is unknown.
+				logDir = def.LogDirAny
+			}
+			logDirs[partition][i] = logDir
+		}
+	}
+	return logDirs
+}
@@ -95,3 +95,11 @@ var ACLExporter = ComposeHarness{
 	BrokerPort:       brokerPort + 10700,
 	Brokers:          1,
 }
+
+// GroupApplier represents the compose harness for the group applier tests.
+var GroupApplier = ComposeHarness{
+	ComposeFilePaths: []string{"../../test/fixtures/compose/1-broker-plaintext-compose.yml"},
+	ZookeeperPort:    zookeeperPort + 10800,
+	BrokerPort:       brokerPort + 10800,
+	Brokers:          1,
+}
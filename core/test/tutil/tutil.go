@@ -40,12 +40,12 @@ func Fixture(t *testing.T, path string) []byte {
 // FileToYAMLDocs wraps docparse.FromFile to simplify test usage.
 func FileToYAMLDocs(t *testing.T, path string) []string {
 	t.Helper()
-	yamlDocs, err := docparse.FromFile(path, docparse.Format(opt.YAMLFormat))
+	yamlDocs, _, err := docparse.FromFile(path, docparse.Format(opt.YAMLFormat))
 	if err != nil {
 		t.Errorf("failed to load test fixture %q: %v", path, err)
 		t.FailNow()
 	}
-	return yamlDocs
+	return yamlDocs.Contents()
 }
 
 // EqualJSON determines if two strings are equal JSON.
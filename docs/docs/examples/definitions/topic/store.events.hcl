@@ -0,0 +1,32 @@
+topic "store.events.order-created" {
+  apiVersion = "v1"
+  metadata {
+    labels = {
+      producer = "storefront"
+    }
+  }
+  spec {
+    configs = {
+      "retention.ms" = "86400000"
+    }
+    partitions        = 3
+    replicationFactor = 2
+  }
+}
+
+topic "store.events.order-updated" {
+  apiVersion = "v1"
+  metadata {
+    labels = {
+      producer = "storefront"
+    }
+  }
+  spec {
+    configs = {
+      "retention.ms" = "86400000"
+    }
+    partitions        = 6
+    replicationFactor = 2
+    assignments       = [[1, 2], [2, 3], [3, 1], [1, 2], [2, 3], [3, 1]]
+  }
+}